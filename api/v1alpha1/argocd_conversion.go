@@ -45,7 +45,7 @@ func (src *ArgoCD) ConvertTo(dstRaw conversion.Hub) error {
 			sso = &argoproj.ArgoCDSSOSpec{}
 		}
 		sso.Provider = argoproj.SSOProviderTypeDex
-		sso.Dex = (*argoproj.ArgoCDDexSpec)(src.Spec.Dex)
+		sso.Dex = convertAlphaToBetaDex(src.Spec.Dex)
 	}
 
 	dst.Spec.SSO = sso
@@ -73,11 +73,11 @@ func (src *ArgoCD) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.OIDCConfig = src.Spec.OIDCConfig
 	dst.Spec.Monitoring = argoproj.ArgoCDMonitoringSpec(src.Spec.Monitoring)
 	dst.Spec.NodePlacement = (*argoproj.ArgoCDNodePlacementSpec)(src.Spec.NodePlacement)
-	dst.Spec.Notifications = argoproj.ArgoCDNotifications(src.Spec.Notifications)
+	dst.Spec.Notifications = convertAlphaToBetaNotifications(src.Spec.Notifications)
 	dst.Spec.Prometheus = *convertAlphaToBetaPrometheus(&src.Spec.Prometheus)
-	dst.Spec.RBAC = argoproj.ArgoCDRBACSpec(src.Spec.RBAC)
-	dst.Spec.Redis = argoproj.ArgoCDRedisSpec(src.Spec.Redis)
-	dst.Spec.Repo = argoproj.ArgoCDRepoSpec(src.Spec.Repo)
+	dst.Spec.RBAC = convertAlphaToBetaRBAC(src.Spec.RBAC)
+	dst.Spec.Redis = convertAlphaToBetaRedis(src.Spec.Redis)
+	dst.Spec.Repo = convertAlphaToBetaRepo(src.Spec.Repo)
 	dst.Spec.RepositoryCredentials = src.Spec.RepositoryCredentials
 	dst.Spec.ResourceHealthChecks = convertAlphaToBetaResourceHealthChecks(src.Spec.ResourceHealthChecks)
 	dst.Spec.ResourceIgnoreDifferences = convertAlphaToBetaResourceIgnoreDifferences(src.Spec.ResourceIgnoreDifferences)
@@ -94,7 +94,7 @@ func (src *ArgoCD) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.Banner = (*argoproj.Banner)(src.Spec.Banner)
 
 	// Status conversion
-	dst.Status = argoproj.ArgoCDStatus(src.Status)
+	dst.Status = convertAlphaToBetaStatus(src.Status)
 
 	return nil
 }
@@ -140,11 +140,11 @@ func (dst *ArgoCD) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.Spec.OIDCConfig = src.Spec.OIDCConfig
 	dst.Spec.Monitoring = ArgoCDMonitoringSpec(src.Spec.Monitoring)
 	dst.Spec.NodePlacement = (*ArgoCDNodePlacementSpec)(src.Spec.NodePlacement)
-	dst.Spec.Notifications = ArgoCDNotifications(src.Spec.Notifications)
+	dst.Spec.Notifications = convertBetaToAlphaNotifications(src.Spec.Notifications)
 	dst.Spec.Prometheus = *convertBetaToAlphaPrometheus(&src.Spec.Prometheus)
-	dst.Spec.RBAC = ArgoCDRBACSpec(src.Spec.RBAC)
-	dst.Spec.Redis = ArgoCDRedisSpec(src.Spec.Redis)
-	dst.Spec.Repo = ArgoCDRepoSpec(src.Spec.Repo)
+	dst.Spec.RBAC = convertBetaToAlphaRBAC(src.Spec.RBAC)
+	dst.Spec.Redis = convertBetaToAlphaRedis(src.Spec.Redis)
+	dst.Spec.Repo = convertBetaToAlphaRepo(src.Spec.Repo)
 	dst.Spec.RepositoryCredentials = src.Spec.RepositoryCredentials
 	dst.Spec.ResourceHealthChecks = convertBetaToAlphaResourceHealthChecks(src.Spec.ResourceHealthChecks)
 	dst.Spec.ResourceIgnoreDifferences = convertBetaToAlphaResourceIgnoreDifferences(src.Spec.ResourceIgnoreDifferences)
@@ -161,7 +161,7 @@ func (dst *ArgoCD) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.Spec.Banner = (*Banner)(src.Spec.Banner)
 
 	// Status conversion
-	dst.Status = ArgoCDStatus(src.Status)
+	dst.Status = convertBetaToAlphaStatus(src.Status)
 
 	return nil
 }
@@ -244,8 +244,106 @@ func convertAlphaToBetaSSO(src *ArgoCDSSOSpec) *argoproj.ArgoCDSSOSpec {
 	if src != nil {
 		dst = &argoproj.ArgoCDSSOSpec{
 			Provider: argoproj.SSOProviderType(src.Provider),
-			Dex:      (*argoproj.ArgoCDDexSpec)(src.Dex),
-			Keycloak: (*argoproj.ArgoCDKeycloakSpec)(src.Keycloak),
+			Dex:      convertAlphaToBetaDex(src.Dex),
+			Keycloak: convertAlphaToBetaKeycloak(src.Keycloak),
+		}
+	}
+	return dst
+}
+
+func convertAlphaToBetaNotifications(src ArgoCDNotifications) argoproj.ArgoCDNotifications {
+	return argoproj.ArgoCDNotifications{
+		Replicas:  src.Replicas,
+		Enabled:   src.Enabled,
+		Env:       src.Env,
+		Image:     src.Image,
+		Version:   src.Version,
+		Resources: src.Resources,
+		LogLevel:  src.LogLevel,
+	}
+}
+
+func convertAlphaToBetaRepo(src ArgoCDRepoSpec) argoproj.ArgoCDRepoSpec {
+	return argoproj.ArgoCDRepoSpec{
+		ExtraRepoCommandArgs: src.ExtraRepoCommandArgs,
+		LogLevel:             src.LogLevel,
+		LogFormat:            src.LogFormat,
+		MountSAToken:         src.MountSAToken,
+		Replicas:             src.Replicas,
+		Resources:            src.Resources,
+		ServiceAccount:       src.ServiceAccount,
+		VerifyTLS:            src.VerifyTLS,
+		AutoTLS:              src.AutoTLS,
+		Image:                src.Image,
+		Version:              src.Version,
+		ExecTimeout:          src.ExecTimeout,
+		Env:                  src.Env,
+		Volumes:              src.Volumes,
+		VolumeMounts:         src.VolumeMounts,
+		InitContainers:       src.InitContainers,
+		SidecarContainers:    src.SidecarContainers,
+	}
+}
+
+func convertAlphaToBetaRBAC(src ArgoCDRBACSpec) argoproj.ArgoCDRBACSpec {
+	return argoproj.ArgoCDRBACSpec{
+		DefaultPolicy:     src.DefaultPolicy,
+		Policy:            src.Policy,
+		Scopes:            src.Scopes,
+		PolicyMatcherMode: src.PolicyMatcherMode,
+	}
+}
+
+func convertAlphaToBetaRedis(src ArgoCDRedisSpec) argoproj.ArgoCDRedisSpec {
+	return argoproj.ArgoCDRedisSpec{
+		Image:                  src.Image,
+		Resources:              src.Resources,
+		Version:                src.Version,
+		DisableTLSVerification: src.DisableTLSVerification,
+		AutoTLS:                src.AutoTLS,
+	}
+}
+
+func convertAlphaToBetaStatus(src ArgoCDStatus) argoproj.ArgoCDStatus {
+	return argoproj.ArgoCDStatus{
+		ApplicationController:    src.ApplicationController,
+		ApplicationSetController: src.ApplicationSetController,
+		SSO:                      src.SSO,
+		NotificationsController:  src.NotificationsController,
+		Phase:                    src.Phase,
+		Redis:                    src.Redis,
+		Repo:                     src.Repo,
+		Server:                   src.Server,
+		RepoTLSChecksum:          src.RepoTLSChecksum,
+		RedisTLSChecksum:         src.RedisTLSChecksum,
+		Host:                     src.Host,
+	}
+}
+
+func convertAlphaToBetaDex(src *ArgoCDDexSpec) *argoproj.ArgoCDDexSpec {
+	var dst *argoproj.ArgoCDDexSpec
+	if src != nil {
+		dst = &argoproj.ArgoCDDexSpec{
+			Config:         src.Config,
+			Groups:         src.Groups,
+			Image:          src.Image,
+			OpenShiftOAuth: src.OpenShiftOAuth,
+			Resources:      src.Resources,
+			Version:        src.Version,
+		}
+	}
+	return dst
+}
+
+func convertAlphaToBetaKeycloak(src *ArgoCDKeycloakSpec) *argoproj.ArgoCDKeycloakSpec {
+	var dst *argoproj.ArgoCDKeycloakSpec
+	if src != nil {
+		dst = &argoproj.ArgoCDKeycloakSpec{
+			Image:     src.Image,
+			Resources: src.Resources,
+			RootCA:    src.RootCA,
+			Version:   src.Version,
+			VerifyTLS: src.VerifyTLS,
 		}
 	}
 	return dst
@@ -448,8 +546,106 @@ func convertBetaToAlphaSSO(src *argoproj.ArgoCDSSOSpec) *ArgoCDSSOSpec {
 	if src != nil {
 		dst = &ArgoCDSSOSpec{
 			Provider: SSOProviderType(src.Provider),
-			Dex:      (*ArgoCDDexSpec)(src.Dex),
-			Keycloak: (*ArgoCDKeycloakSpec)(src.Keycloak),
+			Dex:      convertBetaToAlphaDex(src.Dex),
+			Keycloak: convertBetaToAlphaKeycloak(src.Keycloak),
+		}
+	}
+	return dst
+}
+
+func convertBetaToAlphaNotifications(src argoproj.ArgoCDNotifications) ArgoCDNotifications {
+	return ArgoCDNotifications{
+		Replicas:  src.Replicas,
+		Enabled:   src.Enabled,
+		Env:       src.Env,
+		Image:     src.Image,
+		Version:   src.Version,
+		Resources: src.Resources,
+		LogLevel:  src.LogLevel,
+	}
+}
+
+func convertBetaToAlphaRepo(src argoproj.ArgoCDRepoSpec) ArgoCDRepoSpec {
+	return ArgoCDRepoSpec{
+		ExtraRepoCommandArgs: src.ExtraRepoCommandArgs,
+		LogLevel:             src.LogLevel,
+		LogFormat:            src.LogFormat,
+		MountSAToken:         src.MountSAToken,
+		Replicas:             src.Replicas,
+		Resources:            src.Resources,
+		ServiceAccount:       src.ServiceAccount,
+		VerifyTLS:            src.VerifyTLS,
+		AutoTLS:              src.AutoTLS,
+		Image:                src.Image,
+		Version:              src.Version,
+		ExecTimeout:          src.ExecTimeout,
+		Env:                  src.Env,
+		Volumes:              src.Volumes,
+		VolumeMounts:         src.VolumeMounts,
+		InitContainers:       src.InitContainers,
+		SidecarContainers:    src.SidecarContainers,
+	}
+}
+
+func convertBetaToAlphaRBAC(src argoproj.ArgoCDRBACSpec) ArgoCDRBACSpec {
+	return ArgoCDRBACSpec{
+		DefaultPolicy:     src.DefaultPolicy,
+		Policy:            src.Policy,
+		Scopes:            src.Scopes,
+		PolicyMatcherMode: src.PolicyMatcherMode,
+	}
+}
+
+func convertBetaToAlphaRedis(src argoproj.ArgoCDRedisSpec) ArgoCDRedisSpec {
+	return ArgoCDRedisSpec{
+		Image:                  src.Image,
+		Resources:              src.Resources,
+		Version:                src.Version,
+		DisableTLSVerification: src.DisableTLSVerification,
+		AutoTLS:                src.AutoTLS,
+	}
+}
+
+func convertBetaToAlphaStatus(src argoproj.ArgoCDStatus) ArgoCDStatus {
+	return ArgoCDStatus{
+		ApplicationController:    src.ApplicationController,
+		ApplicationSetController: src.ApplicationSetController,
+		SSO:                      src.SSO,
+		NotificationsController:  src.NotificationsController,
+		Phase:                    src.Phase,
+		Redis:                    src.Redis,
+		Repo:                     src.Repo,
+		Server:                   src.Server,
+		RepoTLSChecksum:          src.RepoTLSChecksum,
+		RedisTLSChecksum:         src.RedisTLSChecksum,
+		Host:                     src.Host,
+	}
+}
+
+func convertBetaToAlphaDex(src *argoproj.ArgoCDDexSpec) *ArgoCDDexSpec {
+	var dst *ArgoCDDexSpec
+	if src != nil {
+		dst = &ArgoCDDexSpec{
+			Config:         src.Config,
+			Groups:         src.Groups,
+			Image:          src.Image,
+			OpenShiftOAuth: src.OpenShiftOAuth,
+			Resources:      src.Resources,
+			Version:        src.Version,
+		}
+	}
+	return dst
+}
+
+func convertBetaToAlphaKeycloak(src *argoproj.ArgoCDKeycloakSpec) *ArgoCDKeycloakSpec {
+	var dst *ArgoCDKeycloakSpec
+	if src != nil {
+		dst = &ArgoCDKeycloakSpec{
+			Image:     src.Image,
+			Resources: src.Resources,
+			RootCA:    src.RootCA,
+			Version:   src.Version,
+			VerifyTLS: src.VerifyTLS,
 		}
 	}
 	return dst
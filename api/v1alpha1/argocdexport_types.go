@@ -70,9 +70,15 @@ type ArgoCDExportSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="ArgoCD",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	Argocd string `json:"argocd"`
 
+	// Filter restricts the export to a subset of the Argo CD data.
+	Filter *ArgoCDExportFilterSpec `json:"filter,omitempty"`
+
 	// Image is the container image to use for the export Job.
 	Image string `json:"image,omitempty"`
 
+	// Monitoring defines the alerting options for the export process.
+	Monitoring ArgoCDExportMonitoringSpec `json:"monitoring,omitempty"`
+
 	// Schedule in Cron format, see https://en.wikipedia.org/wiki/Cron.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Schedule",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	Schedule *string `json:"schedule,omitempty"`
@@ -97,6 +103,44 @@ type ArgoCDExportStatus struct {
 	// Unknown: For some reason the state of the ArgoCDExport could not be obtained.
 	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Phase",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	Phase string `json:"phase"`
+
+	// Conditions describe the latest observations of the ArgoCDExport, such as the outcome of validating the storage options.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ArgoCDExportKind is a kind of Argo CD data that can be selected for export.
+// +kubebuilder:validation:Enum=applications;applicationsets;repositories;clusters;settings
+type ArgoCDExportKind string
+
+// ArgoCDExportFilterSpec defines the subset of the Argo CD data to export.
+// An empty include list selects everything, exclusions are applied after inclusions.
+type ArgoCDExportFilterSpec struct {
+	// IncludeProjects is the list of AppProjects to export, along with the Applications, ApplicationSets, repositories and clusters scoped to them.
+	IncludeProjects []string `json:"includeProjects,omitempty"`
+
+	// ExcludeProjects is the list of AppProjects to leave out of the export.
+	ExcludeProjects []string `json:"excludeProjects,omitempty"`
+
+	// IncludeNamespaces is the list of namespaces whose Applications and ApplicationSets are exported.
+	IncludeNamespaces []string `json:"includeNamespaces,omitempty"`
+
+	// ExcludeNamespaces is the list of namespaces whose Applications and ApplicationSets are left out of the export.
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+
+	// IncludeKinds is the list of kinds to export.
+	IncludeKinds []ArgoCDExportKind `json:"includeKinds,omitempty"`
+
+	// ExcludeKinds is the list of kinds to leave out of the export.
+	ExcludeKinds []ArgoCDExportKind `json:"excludeKinds,omitempty"`
+}
+
+// ArgoCDExportMonitoringSpec defines the alerting options for the export process.
+type ArgoCDExportMonitoringSpec struct {
+	// Enabled toggles the PrometheusRule that alerts on failed or stale exports.
+	Enabled bool `json:"enabled"`
+
+	// MaxAge is how long after the last successful export the stale export alert fires. Defaults to 24h.
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
 }
 
 // ArgoCDExportStorageSpec defines the desired state for ArgoCDExport storage options.
@@ -109,6 +153,60 @@ type ArgoCDExportStorageSpec struct {
 
 	// SecretName is the name of a Secret with encryption key, credentials, etc.
 	SecretName string `json:"secretName,omitempty"`
+
+	// Azure defines the options for the "azure" storage backend.
+	Azure *ArgoCDExportAzureStorageSpec `json:"azure,omitempty"`
+
+	// GCP defines the options for the "gcp" storage backend.
+	GCP *ArgoCDExportGCPStorageSpec `json:"gcp,omitempty"`
+}
+
+// ArgoCDExportAzureStorageSpec defines the options for exporting to Azure Blob Storage.
+type ArgoCDExportAzureStorageSpec struct {
+	// StorageAccount is the name of the Azure storage account.
+	StorageAccount string `json:"storageAccount"`
+
+	// Container is the name of the Blob container the export is written to.
+	Container string `json:"container"`
+
+	// Prefix is prepended to the name of the exported blob.
+	Prefix string `json:"prefix,omitempty"`
+
+	// Endpoint overrides the Blob service endpoint, e.g. to point at Azurite.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// CredentialsSecretName is the name of a Secret holding the storage account key under the "azure.storage.account.key" key.
+	// Defaults to the export Secret. Ignored when WorkloadIdentity is set.
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+
+	// WorkloadIdentity configures the export Job to authenticate using Azure AD Workload Identity instead of a credentials Secret.
+	WorkloadIdentity *ArgoCDExportWorkloadIdentitySpec `json:"workloadIdentity,omitempty"`
+}
+
+// ArgoCDExportGCPStorageSpec defines the options for exporting to Google Cloud Storage.
+type ArgoCDExportGCPStorageSpec struct {
+	// Bucket is the name of the GCS bucket the export is written to.
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to the name of the exported object.
+	Prefix string `json:"prefix,omitempty"`
+
+	// Endpoint overrides the GCS endpoint, e.g. to point at fake-gcs-server.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// CredentialsSecretName is the name of a Secret holding a service account key file under the "gcp.credentials.json" key.
+	// Defaults to the export Secret. Ignored when WorkloadIdentity is set.
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+
+	// WorkloadIdentity configures the export Job to authenticate using GKE Workload Identity instead of a credentials Secret.
+	WorkloadIdentity *ArgoCDExportWorkloadIdentitySpec `json:"workloadIdentity,omitempty"`
+}
+
+// ArgoCDExportWorkloadIdentitySpec defines the cloud identity the export ServiceAccount is federated with.
+type ArgoCDExportWorkloadIdentitySpec struct {
+	// Identity is the client ID of the Azure managed identity, or the email of the GCP service account,
+	// that is set as an annotation on the ServiceAccount used by the export Job.
+	Identity string `json:"identity"`
 }
 
 func init() {
@@ -220,7 +220,7 @@ func (in *ArgoCDExport) DeepCopyInto(out *ArgoCDExport) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExport.
@@ -241,6 +241,91 @@ func (in *ArgoCDExport) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDExportAzureStorageSpec) DeepCopyInto(out *ArgoCDExportAzureStorageSpec) {
+	*out = *in
+	if in.WorkloadIdentity != nil {
+		in, out := &in.WorkloadIdentity, &out.WorkloadIdentity
+		*out = new(ArgoCDExportWorkloadIdentitySpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExportAzureStorageSpec.
+func (in *ArgoCDExportAzureStorageSpec) DeepCopy() *ArgoCDExportAzureStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDExportAzureStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDExportFilterSpec) DeepCopyInto(out *ArgoCDExportFilterSpec) {
+	*out = *in
+	if in.IncludeProjects != nil {
+		in, out := &in.IncludeProjects, &out.IncludeProjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeProjects != nil {
+		in, out := &in.ExcludeProjects, &out.ExcludeProjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeNamespaces != nil {
+		in, out := &in.IncludeNamespaces, &out.IncludeNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeNamespaces != nil {
+		in, out := &in.ExcludeNamespaces, &out.ExcludeNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeKinds != nil {
+		in, out := &in.IncludeKinds, &out.IncludeKinds
+		*out = make([]ArgoCDExportKind, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeKinds != nil {
+		in, out := &in.ExcludeKinds, &out.ExcludeKinds
+		*out = make([]ArgoCDExportKind, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExportFilterSpec.
+func (in *ArgoCDExportFilterSpec) DeepCopy() *ArgoCDExportFilterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDExportFilterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDExportGCPStorageSpec) DeepCopyInto(out *ArgoCDExportGCPStorageSpec) {
+	*out = *in
+	if in.WorkloadIdentity != nil {
+		in, out := &in.WorkloadIdentity, &out.WorkloadIdentity
+		*out = new(ArgoCDExportWorkloadIdentitySpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExportGCPStorageSpec.
+func (in *ArgoCDExportGCPStorageSpec) DeepCopy() *ArgoCDExportGCPStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDExportGCPStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDExportList) DeepCopyInto(out *ArgoCDExportList) {
 	*out = *in
@@ -273,9 +358,35 @@ func (in *ArgoCDExportList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDExportMonitoringSpec) DeepCopyInto(out *ArgoCDExportMonitoringSpec) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExportMonitoringSpec.
+func (in *ArgoCDExportMonitoringSpec) DeepCopy() *ArgoCDExportMonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDExportMonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDExportSpec) DeepCopyInto(out *ArgoCDExportSpec) {
 	*out = *in
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(ArgoCDExportFilterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Monitoring.DeepCopyInto(&out.Monitoring)
 	if in.Schedule != nil {
 		in, out := &in.Schedule, &out.Schedule
 		*out = new(string)
@@ -301,6 +412,13 @@ func (in *ArgoCDExportSpec) DeepCopy() *ArgoCDExportSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDExportStatus) DeepCopyInto(out *ArgoCDExportStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExportStatus.
@@ -321,6 +439,16 @@ func (in *ArgoCDExportStorageSpec) DeepCopyInto(out *ArgoCDExportStorageSpec) {
 		*out = new(v1.PersistentVolumeClaimSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(ArgoCDExportAzureStorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(ArgoCDExportGCPStorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExportStorageSpec.
@@ -333,6 +461,21 @@ func (in *ArgoCDExportStorageSpec) DeepCopy() *ArgoCDExportStorageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDExportWorkloadIdentitySpec) DeepCopyInto(out *ArgoCDExportWorkloadIdentitySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDExportWorkloadIdentitySpec.
+func (in *ArgoCDExportWorkloadIdentitySpec) DeepCopy() *ArgoCDExportWorkloadIdentitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDExportWorkloadIdentitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDGrafanaSpec) DeepCopyInto(out *ArgoCDGrafanaSpec) {
 	*out = *in
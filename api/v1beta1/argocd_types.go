@@ -26,6 +26,7 @@ import (
 	autoscaling "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -158,10 +159,36 @@ type ArgoCDApplicationSet struct {
 	// LogLevel describes the log level that should be used by the ApplicationSet controller. Defaults to ArgoCDDefaultLogLevel if not set.  Valid options are debug,info, error, and warn.
 	LogLevel string `json:"logLevel,omitempty"`
 
+	// LogFormat describes the log format that should be used by the ApplicationSet controller. Defaults to ArgoCDDefaultLogFormat if not configured. Valid options are text or json.
+	LogFormat string `json:"logFormat,omitempty"`
+
+	// EnableProgressiveSyncs enables the RollingSync strategy of ApplicationSets.
+	EnableProgressiveSyncs bool `json:"enableProgressiveSyncs,omitempty"`
+
+	// Policy is the default policy of the ApplicationSet controller when managing Applications, overridden by the
+	// policy of an ApplicationSet. Defaults to sync.
+	// +kubebuilder:validation:Enum=sync;create-only;create-update;create-delete
+	Policy string `json:"policy,omitempty"`
+
+	// DryRun makes the ApplicationSet controller log the changes it would make to Applications instead of applying them.
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// ConcurrentReconciles is the number of ApplicationSets reconciled concurrently. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	ConcurrentReconciles *int32 `json:"concurrentReconciles,omitempty"`
+
 	WebhookServer WebhookServerSpec `json:"webhookServer,omitempty"`
 
 	// SCMRootCAConfigMap is the name of the config map that stores the Gitlab SCM Provider's TLS certificate which will be mounted on the ApplicationSet Controller (optional).
 	SCMRootCAConfigMap string `json:"scmRootCAConfigMap,omitempty"`
+
+	// SourceNamespaces defines the namespaces, other than the namespace of the ArgoCD, ApplicationSet resources are
+	// allowed to be created in. Only honoured for cluster scoped instances.
+	SourceNamespaces []string `json:"sourceNamespaces,omitempty"`
+
+	// SCMProviders defines the URLs of the SCM providers ApplicationSets are allowed to use with the SCM Provider and
+	// Pull Request generators. Should be set together with SourceNamespaces.
+	SCMProviders []string `json:"scmProviders,omitempty"`
 }
 
 // ArgoCDCASpec defines the CA options for ArgCD.
@@ -185,6 +212,10 @@ type ArgoCDDexSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Configuration",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Dex","urn:alm:descriptor:com.tectonic.ui:text"}
 	Config string `json:"config,omitempty"`
 
+	// Connectors are typed Dex connectors, rendered by the operator into the dex configuration. They are ignored if
+	// Config is set, and are added to the OpenShift connector when OpenShiftOAuth is enabled.
+	Connectors []ArgoCDDexConnector `json:"connectors,omitempty"`
+
 	// Optional list of required groups a user must be a member of
 	Groups []string `json:"groups,omitempty"`
 
@@ -205,12 +236,320 @@ type ArgoCDDexSpec struct {
 	Version string `json:"version,omitempty"`
 }
 
+// ArgoCDDexConnector defines a Dex connector. Exactly one of the connector types must be set.
+type ArgoCDDexConnector struct {
+	// ID uniquely identifies the connector.
+	ID string `json:"id"`
+
+	// Name is the name of the connector shown on the login page.
+	Name string `json:"name"`
+
+	// GitHub configures a GitHub connector.
+	GitHub *ArgoCDDexGitHubConnector `json:"github,omitempty"`
+
+	// GitLab configures a GitLab connector.
+	GitLab *ArgoCDDexGitLabConnector `json:"gitlab,omitempty"`
+
+	// LDAP configures an LDAP connector.
+	LDAP *ArgoCDDexLDAPConnector `json:"ldap,omitempty"`
+
+	// Microsoft configures a Microsoft connector.
+	Microsoft *ArgoCDDexMicrosoftConnector `json:"microsoft,omitempty"`
+
+	// OIDC configures an OpenID Connect connector.
+	OIDC *ArgoCDDexOIDCConnector `json:"oidc,omitempty"`
+
+	// SAML configures a SAML 2.0 connector.
+	SAML *ArgoCDDexSAMLConnector `json:"saml,omitempty"`
+}
+
+// ArgoCDDexGitHubOrg defines a GitHub organization, and optionally its teams, a user must be a member of.
+type ArgoCDDexGitHubOrg struct {
+	// Name of the organization.
+	Name string `json:"name"`
+
+	// Teams of the organization the user must be a member of.
+	Teams []string `json:"teams,omitempty"`
+}
+
+// ArgoCDDexGitHubConnector defines a Dex GitHub connector.
+type ArgoCDDexGitHubConnector struct {
+	// ClientID is the client ID of the GitHub OAuth app.
+	ClientID string `json:"clientID"`
+
+	// ClientSecret references the key of a Secret holding the client secret of the GitHub OAuth app.
+	ClientSecret corev1.SecretKeySelector `json:"clientSecret"`
+
+	// HostName is the host name of a GitHub Enterprise instance.
+	HostName string `json:"hostName,omitempty"`
+
+	// LoadAllGroups requests all the organizations and teams of the user as groups.
+	LoadAllGroups bool `json:"loadAllGroups,omitempty"`
+
+	// Orgs restricts the login to the members of the given organizations.
+	Orgs []ArgoCDDexGitHubOrg `json:"orgs,omitempty"`
+
+	// TeamNameField is the team name format of the groups, one of name, slug or both.
+	// +kubebuilder:validation:Enum=name;slug;both
+	TeamNameField string `json:"teamNameField,omitempty"`
+}
+
+// ArgoCDDexGitLabConnector defines a Dex GitLab connector.
+type ArgoCDDexGitLabConnector struct {
+	// BaseURL is the URL of a self-hosted GitLab instance. Defaults to https://gitlab.com.
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// ClientID is the application ID of the GitLab application.
+	ClientID string `json:"clientID"`
+
+	// ClientSecret references the key of a Secret holding the secret of the GitLab application.
+	ClientSecret corev1.SecretKeySelector `json:"clientSecret"`
+
+	// Groups restricts the login to the members of the given groups.
+	Groups []string `json:"groups,omitempty"`
+
+	// UseLoginAsID uses the GitLab username instead of the user ID as the Dex user ID.
+	UseLoginAsID bool `json:"useLoginAsID,omitempty"`
+}
+
+// ArgoCDDexLDAPUserSearch defines how LDAP users are looked up.
+type ArgoCDDexLDAPUserSearch struct {
+	// BaseDN to start the search from.
+	BaseDN string `json:"baseDN"`
+
+	// Filter applied to the search, such as "(objectClass=person)".
+	Filter string `json:"filter,omitempty"`
+
+	// Username is the attribute matched against the username entered on the login page.
+	Username string `json:"username"`
+
+	// IDAttr is the attribute used as the user ID. Defaults to uid.
+	IDAttr string `json:"idAttr,omitempty"`
+
+	// EmailAttr is the attribute used as the user email. Defaults to mail.
+	EmailAttr string `json:"emailAttr,omitempty"`
+
+	// NameAttr is the attribute used as the display name of the user.
+	NameAttr string `json:"nameAttr,omitempty"`
+}
+
+// ArgoCDDexLDAPGroupSearch defines how the LDAP groups of a user are looked up.
+type ArgoCDDexLDAPGroupSearch struct {
+	// BaseDN to start the search from.
+	BaseDN string `json:"baseDN"`
+
+	// Filter applied to the search, such as "(objectClass=group)".
+	Filter string `json:"filter,omitempty"`
+
+	// UserAttr is the attribute of the user entry matched against GroupAttr.
+	UserAttr string `json:"userAttr"`
+
+	// GroupAttr is the attribute of the group entry holding its members.
+	GroupAttr string `json:"groupAttr"`
+
+	// NameAttr is the attribute used as the group name.
+	NameAttr string `json:"nameAttr"`
+}
+
+// ArgoCDDexLDAPConnector defines a Dex LDAP connector.
+type ArgoCDDexLDAPConnector struct {
+	// Host and optional port of the LDAP server, such as ldap.example.com:636.
+	Host string `json:"host"`
+
+	// InsecureNoSSL connects to the LDAP server without TLS.
+	InsecureNoSSL bool `json:"insecureNoSSL,omitempty"`
+
+	// InsecureSkipVerify skips the verification of the certificate of the LDAP server.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// StartTLS connects to the LDAP server with StartTLS instead of LDAPS.
+	StartTLS bool `json:"startTLS,omitempty"`
+
+	// BindDN is the DN used to search the directory. Anonymous bind is used if empty.
+	BindDN string `json:"bindDN,omitempty"`
+
+	// BindPW references the key of a Secret holding the password of BindDN.
+	BindPW *corev1.SecretKeySelector `json:"bindPW,omitempty"`
+
+	// UsernamePrompt is the label of the username field on the login page.
+	UsernamePrompt string `json:"usernamePrompt,omitempty"`
+
+	// UserSearch defines how users are looked up.
+	UserSearch ArgoCDDexLDAPUserSearch `json:"userSearch"`
+
+	// GroupSearch defines how the groups of a user are looked up.
+	GroupSearch *ArgoCDDexLDAPGroupSearch `json:"groupSearch,omitempty"`
+}
+
+// ArgoCDDexMicrosoftConnector defines a Dex Microsoft connector.
+type ArgoCDDexMicrosoftConnector struct {
+	// ClientID is the application ID of the Azure AD application.
+	ClientID string `json:"clientID"`
+
+	// ClientSecret references the key of a Secret holding the client secret of the Azure AD application.
+	ClientSecret corev1.SecretKeySelector `json:"clientSecret"`
+
+	// Tenant is the Azure AD tenant. Defaults to common.
+	Tenant string `json:"tenant,omitempty"`
+
+	// Groups restricts the login to the members of the given groups. Requires a tenant to be set.
+	Groups []string `json:"groups,omitempty"`
+
+	// OnlySecurityGroups only requests the security groups of the user.
+	OnlySecurityGroups bool `json:"onlySecurityGroups,omitempty"`
+}
+
+// ArgoCDDexOIDCConnector defines a Dex OpenID Connect connector.
+type ArgoCDDexOIDCConnector struct {
+	// Issuer is the URL of the OpenID Connect provider.
+	Issuer string `json:"issuer"`
+
+	// ClientID is the client ID registered with the OpenID Connect provider.
+	ClientID string `json:"clientID"`
+
+	// ClientSecret references the key of a Secret holding the client secret registered with the provider.
+	ClientSecret corev1.SecretKeySelector `json:"clientSecret"`
+
+	// Scopes requested in addition to openid. Defaults to profile and email.
+	Scopes []string `json:"scopes,omitempty"`
+
+	// GetUserInfo requests additional claims from the UserInfo endpoint.
+	GetUserInfo bool `json:"getUserInfo,omitempty"`
+
+	// InsecureEnableGroups uses the groups claim of the provider.
+	InsecureEnableGroups bool `json:"insecureEnableGroups,omitempty"`
+
+	// UserNameKey is the claim used as the name of the user. Defaults to name.
+	UserNameKey string `json:"userNameKey,omitempty"`
+}
+
+// ArgoCDDexSAMLConnector defines a Dex SAML 2.0 connector.
+type ArgoCDDexSAMLConnector struct {
+	// SSOURL is the URL of the SAML single sign-on service of the identity provider.
+	SSOURL string `json:"ssoURL"`
+
+	// CA is the PEM encoded CA certificate the SAML responses are signed with.
+	CA string `json:"ca,omitempty"`
+
+	// InsecureSkipSignatureValidation skips the signature validation of the SAML responses. Only use for testing.
+	InsecureSkipSignatureValidation bool `json:"insecureSkipSignatureValidation,omitempty"`
+
+	// EntityIssuer is the issuer of the SAML requests sent to the identity provider.
+	EntityIssuer string `json:"entityIssuer,omitempty"`
+
+	// SSOIssuer is the expected issuer of the SAML responses.
+	SSOIssuer string `json:"ssoIssuer,omitempty"`
+
+	// UsernameAttr is the attribute mapped to the username.
+	UsernameAttr string `json:"usernameAttr"`
+
+	// EmailAttr is the attribute mapped to the user email.
+	EmailAttr string `json:"emailAttr"`
+
+	// GroupsAttr is the attribute mapped to the user groups.
+	GroupsAttr string `json:"groupsAttr,omitempty"`
+
+	// NameIDPolicyFormat is the format of the NameID requested from the identity provider.
+	NameIDPolicyFormat string `json:"nameIDPolicyFormat,omitempty"`
+}
+
+// ArgoCDGatewayParentReference identifies a Gateway, or one of its listeners, that a route attaches to.
+type ArgoCDGatewayParentReference struct {
+	// Name of the Gateway.
+	Name string `json:"name"`
+
+	// Namespace of the Gateway, defaults to the namespace of the route.
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the name of the listener on the Gateway to attach to, all listeners are used if empty.
+	SectionName string `json:"sectionName,omitempty"`
+
+	// Port is the port of the listeners on the Gateway to attach to.
+	Port *int32 `json:"port,omitempty"`
+}
+
+// ArgoCDGatewaySpec defines the desired state for the Gateway API route of a component.
+type ArgoCDGatewaySpec struct {
+	// Annotations is the map of annotations to apply to the route.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Enabled will toggle the creation of the Gateway API route.
+	Enabled bool `json:"enabled"`
+
+	// Hostnames the route matches, defaults to the Host of the component if set.
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// Labels is the map of labels to apply to the route.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// ParentRefs are the Gateways the route attaches to.
+	ParentRefs []ArgoCDGatewayParentReference `json:"parentRefs,omitempty"`
+
+	// Path prefix the route matches, defaults to "/". Not used for GRPCRoutes.
+	Path string `json:"path,omitempty"`
+
+	// TLS selects the listeners of the parent Gateways that terminate and redirect to TLS.
+	TLS *ArgoCDGatewayTLSSpec `json:"tls,omitempty"`
+}
+
+// ArgoCDGatewayTLSSpec defines the TLS listeners a Gateway API route attaches to.
+// TLS is terminated by the Gateway listener, the certificates are configured on the Gateway itself.
+type ArgoCDGatewayTLSSpec struct {
+	// SectionName is the name of the HTTPS listener on the parent Gateways that terminates TLS for the route.
+	SectionName string `json:"sectionName,omitempty"`
+
+	// RedirectSectionName is the name of the HTTP listener on the parent Gateways on which requests are redirected to HTTPS.
+	RedirectSectionName string `json:"redirectSectionName,omitempty"`
+}
+
+// ArgoCDGPGKey defines an ASCII-armoured GPG public key used by Argo CD to verify commit signatures. Exactly one of
+// Key, SecretRef and ConfigMapRef should be set.
+type ArgoCDGPGKey struct {
+	// Name identifies the key in the status. It must be unique within the list.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Key is the ASCII-armoured public key. It may hold several keys.
+	Key string `json:"key,omitempty"`
+
+	// SecretRef references the key of a Secret holding the ASCII-armoured public key.
+	SecretRef *corev1.SecretKeySelector `json:"secretRef,omitempty"`
+
+	// ConfigMapRef references the key of a ConfigMap holding the ASCII-armoured public key.
+	ConfigMapRef *corev1.ConfigMapKeySelector `json:"configMapRef,omitempty"`
+}
+
+// ArgoCDGPGKeyStatus defines the observed state of a GPG public key.
+type ArgoCDGPGKeyStatus struct {
+	// Name is the name of the key in the spec.
+	Name string `json:"name"`
+
+	// KeyID is the ID of the key, as used as a key of the argocd-gpg-keys-cm ConfigMap.
+	KeyID string `json:"keyID,omitempty"`
+
+	// Expires is the time the key expires at, if any.
+	Expires *metav1.Time `json:"expires,omitempty"`
+
+	// Phase is a simple, high-level summary of the key.
+	// There are three possible phase values:
+	// Valid: The key could be parsed and has not expired.
+	// Expired: The key could be parsed but has expired.
+	// Invalid: The key could not be read or parsed. The last known key, if any, is kept in the ConfigMap.
+	Phase string `json:"phase"`
+
+	// Message is a human readable description of the problem, if any.
+	Message string `json:"message,omitempty"`
+}
+
 // ArgoCDGrafanaSpec defines the desired state for the Grafana component.
 type ArgoCDGrafanaSpec struct {
 	// Enabled will toggle Grafana support globally for ArgoCD.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
 
+	// Gateway defines the desired state for a Gateway API HTTPRoute for the Grafana component.
+	Gateway ArgoCDGatewaySpec `json:"gateway,omitempty"`
+
 	// Host is the hostname to use for Ingress/Route resources.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Host",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:text"}
 	Host string `json:"host,omitempty"`
@@ -289,15 +628,34 @@ type ArgoCDIngressSpec struct {
 	TLS []networkingv1.IngressTLS `json:"tls,omitempty"`
 }
 
+// ArgoCDIssuerReference references the cert-manager issuer that signs the Argo CD TLS certificates.
+type ArgoCDIssuerReference struct {
+	// Name is the name of the issuer.
+	Name string `json:"name"`
+
+	// Kind is the kind of the issuer, such as Issuer or ClusterIssuer. Defaults to Issuer.
+	Kind string `json:"kind,omitempty"`
+
+	// Group is the API group of the issuer. Defaults to cert-manager.io, set it to use an external issuer.
+	Group string `json:"group,omitempty"`
+}
+
 // ArgoCDKeycloakSpec defines the desired state for the Keycloak component.
 type ArgoCDKeycloakSpec struct {
+	// External defines an existing Keycloak to configure instead of deploying one.
+	External *ArgoCDKeycloakExternalSpec `json:"external,omitempty"`
+
+	// Gateway defines the desired state for a Gateway API HTTPRoute for the Keycloak component.
+	Gateway ArgoCDGatewaySpec `json:"gateway,omitempty"`
+
 	// Image is the Keycloak container image.
 	Image string `json:"image,omitempty"`
 
 	// Resources defines the Compute Resources required by the container for Keycloak.
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 
-	// Custom root CA certificate for communicating with the Keycloak OIDC provider
+	// Custom root CA certificate for communicating with the Keycloak OIDC provider, and with the admin API of an
+	// external Keycloak.
 	RootCA string `json:"rootCA,omitempty"`
 
 	// Version is the Keycloak container image tag.
@@ -307,6 +665,23 @@ type ArgoCDKeycloakSpec struct {
 	VerifyTLS *bool `json:"verifyTLS,omitempty"`
 }
 
+// ArgoCDKeycloakExternalSpec defines an existing Keycloak whose Argo CD realm is managed by the operator.
+type ArgoCDKeycloakExternalSpec struct {
+	// URL is the base URL of the Keycloak server, including the context path (e.g. /auth) if any.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// CredentialsSecret is the name of the Secret holding the Keycloak admin username and password under the
+	// `username` and `password` keys.
+	CredentialsSecret string `json:"credentialsSecret"`
+
+	// Realm is the name of the Keycloak realm managed for Argo CD. Defaults to argocd.
+	Realm string `json:"realm,omitempty"`
+
+	// RedirectURIs are additional redirect URIs allowed for the Argo CD client.
+	RedirectURIs []string `json:"redirectURIs,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 
 // ArgoCDList contains a list of ArgoCD
@@ -339,6 +714,248 @@ type ArgoCDNotifications struct {
 
 	// LogLevel describes the log level that should be used by the argocd-notifications. Defaults to ArgoCDDefaultLogLevel if not set.  Valid options are debug,info, error, and warn.
 	LogLevel string `json:"logLevel,omitempty"`
+
+	// SelfServiceNamespaces are the namespaces, other than the namespace of the ArgoCD, whose Applications are watched
+	// by the notifications controller. Each of them may hold its own argocd-notifications-cm ConfigMap and
+	// argocd-notifications-secret Secret. Only supported for a cluster scoped ArgoCD, and namespaces already managed
+	// by another instance are skipped.
+	SelfServiceNamespaces []string `json:"selfServiceNamespaces,omitempty"`
+
+	// Config is the typed configuration of the notification services, triggers, templates and subscriptions. It is
+	// merged with the built-in triggers and templates, and the argocd-notifications-cm ConfigMap and
+	// argocd-notifications-secret Secret are kept in sync with it. Both are only seeded once when omitted.
+	Config *ArgoCDNotificationsConfigSpec `json:"config,omitempty"`
+}
+
+// ArgoCDNotificationsConfigSpec defines the typed configuration of Argo CD Notifications.
+type ArgoCDNotificationsConfigSpec struct {
+	// Services are the notification services that notifications are sent with.
+	Services []ArgoCDNotificationsService `json:"services,omitempty"`
+
+	// Triggers define when notifications are sent. A trigger overrides the built-in trigger of the same name.
+	Triggers []ArgoCDNotificationsTrigger `json:"triggers,omitempty"`
+
+	// Templates define the content of notifications. A template overrides the built-in template of the same name.
+	Templates []ArgoCDNotificationsTemplate `json:"templates,omitempty"`
+
+	// Subscriptions are the default subscriptions, applied to all Applications.
+	Subscriptions []ArgoCDNotificationsSubscription `json:"subscriptions,omitempty"`
+}
+
+// ArgoCDNotificationsService defines a notification service. Exactly one of Slack, Email, Webhook and Teams should
+// be set.
+type ArgoCDNotificationsService struct {
+	// Name is the name of the service, used as the service of subscription recipients. It must be unique within the
+	// list.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Slack configures a Slack service.
+	Slack *ArgoCDNotificationsSlackService `json:"slack,omitempty"`
+
+	// Email configures an email service.
+	Email *ArgoCDNotificationsEmailService `json:"email,omitempty"`
+
+	// Webhook configures a webhook service.
+	Webhook *ArgoCDNotificationsWebhookService `json:"webhook,omitempty"`
+
+	// Teams configures a Microsoft Teams service.
+	Teams *ArgoCDNotificationsTeamsService `json:"teams,omitempty"`
+}
+
+// ArgoCDNotificationsSlackService defines a Slack notification service.
+type ArgoCDNotificationsSlackService struct {
+	// TokenSecret references the key of a Secret holding the Slack app token.
+	TokenSecret corev1.SecretKeySelector `json:"tokenSecret"`
+
+	// Username is the name notifications are sent as (optional).
+	Username string `json:"username,omitempty"`
+
+	// Icon is the emoji or URL of the icon notifications are sent with (optional).
+	Icon string `json:"icon,omitempty"`
+
+	// APIURL is the URL of the Slack API, for Slack compatible services (optional).
+	APIURL string `json:"apiURL,omitempty"`
+}
+
+// ArgoCDNotificationsEmailService defines an email notification service.
+type ArgoCDNotificationsEmailService struct {
+	// Host is the host of the SMTP server.
+	Host string `json:"host"`
+
+	// Port is the port of the SMTP server.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// From is the sender address of the notifications.
+	From string `json:"from"`
+
+	// Username is the username to authenticate to the SMTP server with (optional).
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecret references the key of a Secret holding the password to authenticate to the SMTP server with
+	// (optional).
+	PasswordSecret *corev1.SecretKeySelector `json:"passwordSecret,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the certificate of the SMTP server.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// ArgoCDNotificationsWebhookService defines a webhook notification service.
+type ArgoCDNotificationsWebhookService struct {
+	// URL is the base URL of the webhook.
+	URL string `json:"url"`
+
+	// Headers are the HTTP headers sent with each request.
+	Headers []ArgoCDNotificationsWebhookHeader `json:"headers,omitempty"`
+
+	// BasicAuth configures basic authentication to the webhook (optional).
+	BasicAuth *ArgoCDNotificationsBasicAuth `json:"basicAuth,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the certificate of the webhook.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// ArgoCDNotificationsWebhookHeader defines an HTTP header of a webhook. Exactly one of Value and ValueSecret should
+// be set.
+type ArgoCDNotificationsWebhookHeader struct {
+	// Name is the name of the header.
+	Name string `json:"name"`
+
+	// Value is the value of the header.
+	Value string `json:"value,omitempty"`
+
+	// ValueSecret references the key of a Secret holding the value of the header.
+	ValueSecret *corev1.SecretKeySelector `json:"valueSecret,omitempty"`
+}
+
+// ArgoCDNotificationsBasicAuth defines the basic authentication to a webhook.
+type ArgoCDNotificationsBasicAuth struct {
+	// Username is the username to authenticate with.
+	Username string `json:"username"`
+
+	// PasswordSecret references the key of a Secret holding the password to authenticate with.
+	PasswordSecret corev1.SecretKeySelector `json:"passwordSecret"`
+}
+
+// ArgoCDNotificationsTeamsService defines a Microsoft Teams notification service.
+type ArgoCDNotificationsTeamsService struct {
+	// Recipients are the channels notifications may be sent to, by name.
+	Recipients []ArgoCDNotificationsTeamsRecipient `json:"recipients"`
+}
+
+// ArgoCDNotificationsTeamsRecipient defines a Microsoft Teams channel.
+type ArgoCDNotificationsTeamsRecipient struct {
+	// Name is the name of the channel, used as the recipient of subscriptions.
+	Name string `json:"name"`
+
+	// URLSecret references the key of a Secret holding the incoming webhook URL of the channel.
+	URLSecret corev1.SecretKeySelector `json:"urlSecret"`
+}
+
+// ArgoCDNotificationsTrigger defines a notification trigger.
+type ArgoCDNotificationsTrigger struct {
+	// Name is the name of the trigger. It must be unique within the list.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Conditions are the conditions of the trigger, each sending its own templates.
+	Conditions []ArgoCDNotificationsTriggerCondition `json:"conditions"`
+}
+
+// ArgoCDNotificationsTriggerCondition defines a condition of a notification trigger.
+type ArgoCDNotificationsTriggerCondition struct {
+	// Description is a human readable description of the condition (optional).
+	Description string `json:"description,omitempty"`
+
+	// When is the expression the condition is met on, e.g. app.status.operationState.phase in ['Succeeded'].
+	When string `json:"when"`
+
+	// Send are the names of the templates to send when the condition is met.
+	Send []string `json:"send"`
+
+	// OncePer is the field of the Application the notification is sent once per value of (optional).
+	OncePer string `json:"oncePer,omitempty"`
+}
+
+// ArgoCDNotificationsTemplate defines a notification template. Fields are Go templates.
+type ArgoCDNotificationsTemplate struct {
+	// Name is the name of the template. It must be unique within the list.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Message is the body of the notification.
+	Message string `json:"message,omitempty"`
+
+	// Email defines the email specific fields of the notification (optional).
+	Email *ArgoCDNotificationsEmailTemplate `json:"email,omitempty"`
+
+	// Slack defines the Slack specific fields of the notification (optional).
+	Slack *ArgoCDNotificationsSlackTemplate `json:"slack,omitempty"`
+
+	// Teams defines the Microsoft Teams specific fields of the notification (optional).
+	Teams *ArgoCDNotificationsTeamsTemplate `json:"teams,omitempty"`
+
+	// Webhook defines the requests sent by webhook services, by service name (optional).
+	Webhook map[string]ArgoCDNotificationsWebhookTemplate `json:"webhook,omitempty"`
+}
+
+// ArgoCDNotificationsEmailTemplate defines the email specific fields of a notification template.
+type ArgoCDNotificationsEmailTemplate struct {
+	// Subject is the subject of the email.
+	Subject string `json:"subject,omitempty"`
+}
+
+// ArgoCDNotificationsSlackTemplate defines the Slack specific fields of a notification template.
+type ArgoCDNotificationsSlackTemplate struct {
+	// Attachments is the JSON array of the attachments of the message.
+	Attachments string `json:"attachments,omitempty"`
+
+	// Blocks is the JSON array of the blocks of the message.
+	Blocks string `json:"blocks,omitempty"`
+}
+
+// ArgoCDNotificationsTeamsTemplate defines the Microsoft Teams specific fields of a notification template.
+type ArgoCDNotificationsTeamsTemplate struct {
+	// Title is the title of the message card.
+	Title string `json:"title,omitempty"`
+
+	// Facts is the JSON array of the facts of the message card.
+	Facts string `json:"facts,omitempty"`
+
+	// Sections is the JSON array of the sections of the message card.
+	Sections string `json:"sections,omitempty"`
+
+	// PotentialAction is the JSON array of the actions of the message card.
+	PotentialAction string `json:"potentialAction,omitempty"`
+
+	// ThemeColor is the hex color of the message card.
+	ThemeColor string `json:"themeColor,omitempty"`
+}
+
+// ArgoCDNotificationsWebhookTemplate defines the request sent by a webhook service.
+type ArgoCDNotificationsWebhookTemplate struct {
+	// Method is the HTTP method of the request. Defaults to GET.
+	Method string `json:"method,omitempty"`
+
+	// Path is the path of the request, relative to the URL of the webhook.
+	Path string `json:"path,omitempty"`
+
+	// Body is the body of the request.
+	Body string `json:"body,omitempty"`
+}
+
+// ArgoCDNotificationsSubscription defines a default subscription.
+type ArgoCDNotificationsSubscription struct {
+	// Recipients are the recipients of the notifications, as <service>:<recipient>, e.g. slack:my-channel.
+	Recipients []string `json:"recipients"`
+
+	// Triggers are the names of the triggers the recipients are subscribed to.
+	Triggers []string `json:"triggers"`
+
+	// Selector is the label selector of the Applications the subscription applies to (optional).
+	Selector string `json:"selector,omitempty"`
 }
 
 // ArgoCDPrometheusSpec defines the desired state for the Prometheus component.
@@ -347,6 +964,9 @@ type ArgoCDPrometheusSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
 
+	// Gateway defines the desired state for a Gateway API HTTPRoute for the Prometheus component.
+	Gateway ArgoCDGatewaySpec `json:"gateway,omitempty"`
+
 	// Host is the hostname to use for Ingress/Route resources.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Host",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:text"}
 	Host string `json:"host,omitempty"`
@@ -362,6 +982,96 @@ type ArgoCDPrometheusSpec struct {
 	Size *int32 `json:"size,omitempty"`
 }
 
+// ArgoCDClusterSpec defines a managed cluster to register with Argo CD.
+type ArgoCDClusterSpec struct {
+	// Name is the name of the cluster in Argo CD. It must be unique within the list and is part of the name of the
+	// generated Secret.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Server is the URL of the API server of the cluster. It is required with BearerTokenSecret and overrides the
+	// server of the current context with KubeconfigSecret.
+	Server string `json:"server,omitempty"`
+
+	// KubeconfigSecret references the key of a Secret holding a kubeconfig for the cluster. The cluster and the user
+	// of the current context are used, certificates and tokens must be embedded in the kubeconfig.
+	KubeconfigSecret *corev1.SecretKeySelector `json:"kubeconfigSecret,omitempty"`
+
+	// BearerTokenSecret references a Secret holding a bearer token for the cluster in the token key, and optionally
+	// the CA certificate of the API server in the ca.crt key, as in a ServiceAccount token Secret.
+	BearerTokenSecret *corev1.LocalObjectReference `json:"bearerTokenSecret,omitempty"`
+
+	// Namespaces restricts Argo CD to the given namespaces of the cluster (optional).
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Labels are added to the generated cluster Secret, and are available to cluster generators of ApplicationSets.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Shard pins the cluster to the Application Controller shard with the given index (optional).
+	// +kubebuilder:validation:Minimum=0
+	Shard *int32 `json:"shard,omitempty"`
+
+	// Project is the AppProject the cluster is scoped to (optional).
+	Project string `json:"project,omitempty"`
+}
+
+// ArgoCDDefaultProjectSpec defines the desired state for the Argo CD default AppProject.
+type ArgoCDDefaultProjectSpec struct {
+	// Locked restricts the default project so that no Application can use it, ignoring all the other fields.
+	Locked bool `json:"locked,omitempty"`
+
+	// SourceRepos are the repositories that Applications of the default project may deploy from.
+	// Left as is when omitted.
+	SourceRepos []string `json:"sourceRepos,omitempty"`
+
+	// Destinations are the clusters and namespaces that Applications of the default project may deploy to.
+	// Left as is when omitted.
+	Destinations []ArgoCDProjectDestination `json:"destinations,omitempty"`
+
+	// ClusterResourceWhitelist are the cluster-scoped resources that Applications of the default project may deploy.
+	// Left as is when omitted.
+	ClusterResourceWhitelist []metav1.GroupKind `json:"clusterResourceWhitelist,omitempty"`
+
+	// ClusterResourceBlacklist are the cluster-scoped resources that Applications of the default project may not
+	// deploy. Left as is when omitted.
+	ClusterResourceBlacklist []metav1.GroupKind `json:"clusterResourceBlacklist,omitempty"`
+}
+
+// ArgoCDProjectDestination defines a cluster and namespace that Applications of a project may deploy to.
+type ArgoCDProjectDestination struct {
+	// Server is the URL of the destination cluster, e.g. https://kubernetes.default.svc.
+	Server string `json:"server,omitempty"`
+
+	// Name is the name of the destination cluster, as an alternative to Server.
+	Name string `json:"name,omitempty"`
+
+	// Namespace is the destination namespace, or a glob pattern such as *.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ArgoCDRepository defines a repository, or a repository credential template, for Argo CD.
+type ArgoCDRepository struct {
+	// Name is the name of the repository. It must be unique within the list and is part of the name of the generated
+	// Secret.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// URL is the URL of the repository, or the URL prefix of the repositories for a credential template.
+	URL string `json:"url"`
+
+	// Type is the type of the repository. Defaults to git.
+	// +kubebuilder:validation:Enum=git;helm;oci
+	Type string `json:"type,omitempty"`
+
+	// Project is the AppProject the repository is scoped to (optional).
+	Project string `json:"project,omitempty"`
+
+	// CredentialsSecret references a Secret in the namespace of the ArgoCD with the credentials of the repository,
+	// using the Argo CD keys such as username and password, sshPrivateKey, tlsClientCertData and tlsClientCertKey, or
+	// githubAppID, githubAppInstallationID and githubAppPrivateKey.
+	CredentialsSecret *corev1.LocalObjectReference `json:"credentialsSecret,omitempty"`
+}
+
 // ArgoCDRBACSpec defines the desired state for the Argo CD RBAC configuration.
 type ArgoCDRBACSpec struct {
 	// DefaultPolicy is the name of the default role which Argo CD will falls back to, when
@@ -387,6 +1097,47 @@ type ArgoCDRBACSpec struct {
 	// PolicyMatcherMode configures the matchers function mode for casbin.
 	// There are two options for this, 'glob' for glob matcher or 'regex' for regex matcher.
 	PolicyMatcherMode *string `json:"policyMatcherMode,omitempty"`
+
+	// Roles are RBAC roles rendered as policy rules, in addition to the rules of Policy.
+	Roles []ArgoCDRBACRole `json:"roles,omitempty"`
+
+	// GroupBindings bind SSO groups or local users to RBAC roles, in addition to the role definitions of Policy.
+	GroupBindings []ArgoCDRBACGroupBinding `json:"groupBindings,omitempty"`
+}
+
+// ArgoCDRBACRole defines an RBAC role and its policy rules.
+type ArgoCDRBACRole struct {
+	// Name is the name of the role. The role: prefix is added if missing.
+	Name string `json:"name"`
+
+	// Policies are the policy rules of the role.
+	Policies []ArgoCDRBACPolicy `json:"policies,omitempty"`
+}
+
+// ArgoCDRBACPolicy defines an RBAC policy rule.
+type ArgoCDRBACPolicy struct {
+	// Resource is the Argo CD resource the rule applies to, e.g. applications or clusters.
+	Resource string `json:"resource"`
+
+	// Action is the action the rule applies to, e.g. get or sync.
+	Action string `json:"action"`
+
+	// Object is the object the rule applies to, e.g. <project>/<application>.
+	Object string `json:"object"`
+
+	// Effect is the effect of the rule. Defaults to allow.
+	// +kubebuilder:validation:Enum=allow;deny
+	Effect string `json:"effect,omitempty"`
+}
+
+// ArgoCDRBACGroupBinding binds an SSO group or a local user to an RBAC role.
+type ArgoCDRBACGroupBinding struct {
+	// Group is the name of the SSO group or the local user.
+	Group string `json:"group"`
+
+	// Role is the name of the bound role, either defined in Roles or built in (admin, readonly). The role: prefix is
+	// added if missing.
+	Role string `json:"role"`
 }
 
 // ArgoCDRedisSpec defines the desired state for the Redis server component.
@@ -410,6 +1161,165 @@ type ArgoCDRedisSpec struct {
 	// The value specified here can currently be:
 	// - openshift - Use the OpenShift service CA to request TLS config
 	AutoTLS string `json:"autotls,omitempty"`
+
+	// External configures an external Redis, such as a managed Redis service, to be used by the Argo CD components
+	// instead of the Redis deployed by the operator. When set, no Redis workloads are reconciled.
+	External *ArgoCDRedisExternalSpec `json:"external,omitempty"`
+}
+
+// ArgoCDRedisExternalSpec defines the connection to an external Redis.
+type ArgoCDRedisExternalSpec struct {
+	// Address is the address of the Redis server, as host:port. Ignored if Sentinel is set.
+	Address string `json:"address,omitempty"`
+
+	// Username is the username used to authenticate to Redis, when using Redis ACLs.
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecret refers to the key of a Secret, in the namespace of the ArgoCD, holding the password used to
+	// authenticate to Redis.
+	PasswordSecret *corev1.SecretKeySelector `json:"passwordSecret,omitempty"`
+
+	// TLS enables TLS for the connections to Redis when set.
+	TLS *ArgoCDRedisExternalTLSSpec `json:"tls,omitempty"`
+
+	// Sentinel configures the Redis Sentinels used to discover the Redis master.
+	Sentinel *ArgoCDRedisSentinelSpec `json:"sentinel,omitempty"`
+}
+
+// ArgoCDRedisExternalTLSSpec defines the TLS options for the connections to an external Redis.
+type ArgoCDRedisExternalTLSSpec struct {
+	// CASecret refers to the key of a Secret, in the namespace of the ArgoCD, holding the PEM encoded CA certificate
+	// used to verify the Redis server certificate. The system CAs are used if not set.
+	CASecret *corev1.SecretKeySelector `json:"caSecret,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the Redis server certificate.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// ArgoCDRedisSentinelSpec defines the Redis Sentinels used to discover the Redis master.
+type ArgoCDRedisSentinelSpec struct {
+	// Addresses are the addresses of the Sentinels, as host:port.
+	// +kubebuilder:validation:MinItems=1
+	Addresses []string `json:"addresses"`
+
+	// MasterName is the name of the Redis master monitored by the Sentinels.
+	// +kubebuilder:default=master
+	MasterName string `json:"masterName,omitempty"`
+
+	// Username is the username used to authenticate to the Sentinels, when using Redis ACLs. It is passed to the Argo
+	// CD components in the REDIS_SENTINEL_USERNAME environment variable.
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecret refers to the key of a Secret, in the namespace of the ArgoCD, holding the password used to
+	// authenticate to the Sentinels. It is passed to the Argo CD components in the REDIS_SENTINEL_PASSWORD environment
+	// variable.
+	PasswordSecret *corev1.SecretKeySelector `json:"passwordSecret,omitempty"`
+}
+
+// ArgoCDConfigManagementPlugin defines a Config Management Plugin sidecar of the repo server.
+type ArgoCDConfigManagementPlugin struct {
+	// Name is the name of the plugin. It must be unique within the list and is also the name of the sidecar container.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Image is the container image of the plugin sidecar, providing the tools run by the plugin commands.
+	Image string `json:"image"`
+
+	// Spec is the spec of the plugin.yaml of the plugin.
+	Spec ArgoCDConfigManagementPluginSpec `json:"spec"`
+
+	// Resources defines the compute resource requirements of the plugin sidecar.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env lets you specify environment variables for the plugin sidecar.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// ArgoCDConfigManagementPluginSpec defines the spec of the plugin.yaml of a Config Management Plugin.
+type ArgoCDConfigManagementPluginSpec struct {
+	// Version is the version of the plugin. Applications refer to a versioned plugin as <name>-<version>.
+	Version string `json:"version,omitempty"`
+
+	// Init is the command run in the application source directory before Generate.
+	Init *ArgoCDConfigManagementPluginCommand `json:"init,omitempty"`
+
+	// Generate is the command run in the application source directory, which must print the manifests to stdout.
+	Generate ArgoCDConfigManagementPluginCommand `json:"generate"`
+
+	// Discover defines how the plugin detects the applications it supports.
+	Discover *ArgoCDConfigManagementPluginDiscover `json:"discover,omitempty"`
+
+	// Parameters are the parameters announced by the plugin in the UI.
+	Parameters *ArgoCDConfigManagementPluginParameters `json:"parameters,omitempty"`
+
+	// PreserveFileMode preserves the file mode of the application source files.
+	PreserveFileMode bool `json:"preserveFileMode,omitempty"`
+}
+
+// ArgoCDConfigManagementPluginCommand defines a command run by a Config Management Plugin.
+type ArgoCDConfigManagementPluginCommand struct {
+	// Command is the command to run.
+	Command []string `json:"command,omitempty"`
+
+	// Args are the arguments of the command.
+	Args []string `json:"args,omitempty"`
+}
+
+// ArgoCDConfigManagementPluginDiscover defines how a Config Management Plugin detects the applications it supports.
+type ArgoCDConfigManagementPluginDiscover struct {
+	// FileName is a glob pattern matched against the files of the application source directory.
+	FileName string `json:"fileName,omitempty"`
+
+	// Find is a command, or a glob pattern, whose output or match selects the application.
+	Find *ArgoCDConfigManagementPluginFind `json:"find,omitempty"`
+}
+
+// ArgoCDConfigManagementPluginFind defines the find command of a Config Management Plugin.
+type ArgoCDConfigManagementPluginFind struct {
+	ArgoCDConfigManagementPluginCommand `json:",inline"`
+
+	// Glob is a glob pattern matched against the files of the application source directory.
+	Glob string `json:"glob,omitempty"`
+}
+
+// ArgoCDConfigManagementPluginParameters defines the parameters announced by a Config Management Plugin.
+type ArgoCDConfigManagementPluginParameters struct {
+	// Static are the parameters announced for all applications.
+	Static []ArgoCDConfigManagementPluginParameter `json:"static,omitempty"`
+
+	// Dynamic is a command printing the parameters announced for an application as JSON.
+	Dynamic *ArgoCDConfigManagementPluginCommand `json:"dynamic,omitempty"`
+}
+
+// ArgoCDConfigManagementPluginParameter defines a parameter announced by a Config Management Plugin.
+type ArgoCDConfigManagementPluginParameter struct {
+	// Name is the name of the parameter.
+	Name string `json:"name"`
+
+	// Title is the title of the parameter in the UI.
+	Title string `json:"title,omitempty"`
+
+	// Tooltip is the tooltip of the parameter in the UI.
+	Tooltip string `json:"tooltip,omitempty"`
+
+	// Required marks the parameter as required in the UI.
+	Required bool `json:"required,omitempty"`
+
+	// ItemType is the type of the items of the parameter. Defaults to string.
+	ItemType string `json:"itemType,omitempty"`
+
+	// CollectionType is the type of the collection of the parameter, one of string, array or map. Defaults to string.
+	// +kubebuilder:validation:Enum=string;array;map
+	CollectionType string `json:"collectionType,omitempty"`
+
+	// String is the default value of a string parameter.
+	String string `json:"string,omitempty"`
+
+	// Array is the default value of an array parameter.
+	Array []string `json:"array,omitempty"`
+
+	// Map is the default value of a map parameter.
+	Map map[string]string `json:"map,omitempty"`
 }
 
 // ArgoCDRepoSpec defines the desired state for the Argo CD repo server component.
@@ -470,6 +1380,36 @@ type ArgoCDRepoSpec struct {
 
 	// SidecarContainers defines the list of sidecar containers for the repo server deployment
 	SidecarContainers []corev1.Container `json:"sidecarContainers,omitempty"`
+
+	// Plugins are the Config Management Plugins to run as sidecars of the repo server. The operator generates the
+	// plugin.yaml of each plugin and injects the argocd-cmp-server entrypoint.
+	Plugins []ArgoCDConfigManagementPlugin `json:"plugins,omitempty"`
+
+	// Autoscale defines the autoscale options for the Repo Server. When enabled, Replicas is ignored.
+	Autoscale ArgoCDRepoAutoscaleSpec `json:"autoscale,omitempty"`
+
+	// TmpVolume defines the working volume mounted at /tmp of the repo server, where repositories are checked out and
+	// Helm charts are cached. An emptyDir without size limit is used when omitted.
+	TmpVolume *ArgoCDRepoWorkingVolumeSpec `json:"tmpVolume,omitempty"`
+}
+
+// ArgoCDRepoAutoscaleSpec defines the desired state for autoscaling the Argo CD Repo Server component.
+type ArgoCDRepoAutoscaleSpec struct {
+	// Enabled will toggle autoscaling support for the Argo CD Repo Server component.
+	Enabled bool `json:"enabled"`
+
+	// HPA defines the HorizontalPodAutoscaler options for the Argo CD Repo Server component.
+	HPA *autoscaling.HorizontalPodAutoscalerSpec `json:"hpa,omitempty"`
+}
+
+// ArgoCDRepoWorkingVolumeSpec defines a working volume of the repo server.
+type ArgoCDRepoWorkingVolumeSpec struct {
+	// SizeLimit is the size limit of the emptyDir volume.
+	SizeLimit *resource.Quantity `json:"sizeLimit,omitempty"`
+
+	// VolumeClaimTemplate is the template of an ephemeral PersistentVolumeClaim to use instead of an emptyDir. It
+	// takes precedence over SizeLimit.
+	VolumeClaimTemplate *corev1.PersistentVolumeClaimTemplate `json:"volumeClaimTemplate,omitempty"`
 }
 
 // ArgoCDRouteSpec defines the desired state for an OpenShift Route.
@@ -506,6 +1446,9 @@ type ArgoCDServerAutoscaleSpec struct {
 
 // ArgoCDServerGRPCSpec defines the desired state for the Argo CD Server GRPC options.
 type ArgoCDServerGRPCSpec struct {
+	// Gateway defines the desired state for the Argo CD Server Gateway API GRPCRoute.
+	Gateway ArgoCDGatewaySpec `json:"gateway,omitempty"`
+
 	// Host is the hostname to use for Ingress/Route resources.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="GRPC Host",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:text"}
 	Host string `json:"host,omitempty"`
@@ -523,6 +1466,9 @@ type ArgoCDServerSpec struct {
 	// GRPC defines the state for the Argo CD Server GRPC options.
 	GRPC ArgoCDServerGRPCSpec `json:"grpc,omitempty"`
 
+	// Gateway defines the desired state for a Gateway API HTTPRoute for the Argo CD Server component.
+	Gateway ArgoCDGatewaySpec `json:"gateway,omitempty"`
+
 	// Host is the hostname to use for Ingress/Route resources.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Host",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:text"}
 	Host string `json:"host,omitempty"`
@@ -612,6 +1558,9 @@ const (
 
 	// SSOProviderTypeDex means dex will be Installed and Integrated with Argo CD.
 	SSOProviderTypeDex SSOProviderType = "dex"
+
+	// SSOProviderTypeOIDC means an existing OIDC provider will be Integrated with Argo CD.
+	SSOProviderTypeOIDC SSOProviderType = "oidc"
 )
 
 // ArgoCDSSOSpec defines SSO provider.
@@ -624,6 +1573,48 @@ type ArgoCDSSOSpec struct {
 
 	// Keycloak contains the configuration for Argo CD keycloak authentication
 	Keycloak *ArgoCDKeycloakSpec `json:"keycloak,omitempty"`
+
+	// OIDC contains the configuration for Argo CD authentication with an existing OIDC provider
+	OIDC *ArgoCDOIDCSpec `json:"oidc,omitempty"`
+}
+
+// ArgoCDOIDCClaim defines a claim requested in the ID token.
+type ArgoCDOIDCClaim struct {
+	// Essential defines whether the claim is required.
+	Essential bool `json:"essential,omitempty"`
+
+	// Value is the requested value of the claim.
+	Value string `json:"value,omitempty"`
+
+	// Values are the requested values of the claim.
+	Values []string `json:"values,omitempty"`
+}
+
+// ArgoCDOIDCSpec defines the configuration of an existing OIDC provider.
+type ArgoCDOIDCSpec struct {
+	// Name is the name of the provider shown on the login page. Defaults to OIDC.
+	Name string `json:"name,omitempty"`
+
+	// Issuer is the URL of the OIDC issuer.
+	Issuer string `json:"issuer"`
+
+	// ClientID is the OAuth client ID of Argo CD.
+	ClientID string `json:"clientID"`
+
+	// ClientSecret is a reference to the key of a Secret holding the OAuth client secret of Argo CD.
+	ClientSecret *corev1.SecretKeySelector `json:"clientSecret,omitempty"`
+
+	// RequestedScopes are the scopes requested from the provider. Defaults to openid, profile and email.
+	RequestedScopes []string `json:"requestedScopes,omitempty"`
+
+	// RequestedIDTokenClaims are the claims requested in the ID token.
+	RequestedIDTokenClaims map[string]ArgoCDOIDCClaim `json:"requestedIDTokenClaims,omitempty"`
+
+	// RootCA is a reference to the key of a ConfigMap holding the PEM encoded root CA certificate of the provider.
+	RootCA *corev1.ConfigMapKeySelector `json:"rootCA,omitempty"`
+
+	// LogoutURL is the URL of the provider to redirect to on logout.
+	LogoutURL string `json:"logoutURL,omitempty"`
 }
 
 // KustomizeVersionSpec is used to specify information about a kustomize version to be used within ArgoCD.
@@ -662,6 +1653,10 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Application Instance Label Key'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ApplicationInstanceLabelKey string `json:"applicationInstanceLabelKey,omitempty"`
 
+	// Clusters are the managed clusters to register with Argo CD. A labelled cluster Secret is generated for each
+	// cluster.
+	Clusters []ArgoCDClusterSpec `json:"clusters,omitempty"`
+
 	// ConfigManagementPlugins is used to specify additional config management plugins.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Config Management Plugins'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ConfigManagementPlugins string `json:"configManagementPlugins,omitempty"`
@@ -669,6 +1664,9 @@ type ArgoCDSpec struct {
 	// Controller defines the Application Controller options for ArgoCD.
 	Controller ArgoCDApplicationControllerSpec `json:"controller,omitempty"`
 
+	// DefaultProject configures the Argo CD default AppProject. The project is left unmanaged when omitted.
+	DefaultProject *ArgoCDDefaultProjectSpec `json:"defaultProject,omitempty"`
+
 	// DisableAdmin will disable the admin user.
 	DisableAdmin bool `json:"disableAdmin,omitempty"`
 
@@ -688,6 +1686,10 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Google Analytics Anonymize Users'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	GAAnonymizeUsers bool `json:"gaAnonymizeUsers,omitempty"`
 
+	// GPGKeys are the GPG public keys used to verify commit signatures. The keys are added to the
+	// argocd-gpg-keys-cm ConfigMap, next to any key added by hand.
+	GPGKeys []ArgoCDGPGKey `json:"gpgKeys,omitempty"`
+
 	// Grafana defines the Grafana server options for ArgoCD.
 	Grafana ArgoCDGrafanaSpec `json:"grafana,omitempty"`
 
@@ -710,6 +1712,7 @@ type ArgoCDSpec struct {
 	Import *ArgoCDImportSpec `json:"import,omitempty"`
 
 	// InitialRepositories to configure Argo CD with upon creation of the cluster.
+	// Deprecated: use Repositories instead.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Initial Repositories'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	InitialRepositories string `json:"initialRepositories,omitempty"`
 
@@ -749,8 +1752,17 @@ type ArgoCDSpec struct {
 	Repo ArgoCDRepoSpec `json:"repo,omitempty"`
 
 	// RepositoryCredentials are the Git pull credentials to configure Argo CD with upon creation of the cluster.
+	// Deprecated: use RepositoryCredentialTemplates instead.
 	RepositoryCredentials string `json:"repositoryCredentials,omitempty"`
 
+	// Repositories are the repositories that Argo CD may deploy from. A labelled repository Secret is generated for
+	// each repository.
+	Repositories []ArgoCDRepository `json:"repositories,omitempty"`
+
+	// RepositoryCredentialTemplates are credentials used for all the repositories whose URL starts with the URL of the
+	// template. A labelled repository credential Secret is generated for each template.
+	RepositoryCredentialTemplates []ArgoCDRepository `json:"repositoryCredentialTemplates,omitempty"`
+
 	// ResourceHealthChecks customizes resource health check behavior.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Health Check Customizations'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ResourceHealthChecks []ResourceHealthCheck `json:"resourceHealthChecks,omitempty"`
@@ -855,8 +1867,8 @@ type ArgoCDStatus struct {
 	// Redis is a simple, high-level summary of where the Argo CD Redis component is in its lifecycle.
 	// There are four possible redis values:
 	// Pending: The Argo CD Redis component has been accepted by the Kubernetes system, but one or more of the required resources have not been created.
-	// Running: All of the required Pods for the Argo CD Redis component are in a Ready state.
-	// Failed: At least one of the  Argo CD Redis component Pods had a failure.
+	// Running: All of the required Pods for the Argo CD Redis component are in a Ready state, or an external Redis is configured.
+	// Failed: At least one of the  Argo CD Redis component Pods had a failure, or the external Redis has no address.
 	// Unknown: The state of the Argo CD Redis component could not be obtained.
 	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Redis",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	Redis string `json:"redis,omitempty"`
@@ -887,6 +1899,38 @@ type ArgoCDStatus struct {
 
 	// Host is the hostname of the Ingress.
 	Host string `json:"host,omitempty"`
+
+	// Conditions describe the latest observations of the ArgoCD, such as the outcome of validating the RBAC policy.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Clusters is the status of the managed clusters registered with Argo CD.
+	Clusters []ArgoCDClusterStatus `json:"clusters,omitempty"`
+
+	// GPGKeys is the status of the GPG public keys of the spec.
+	GPGKeys []ArgoCDGPGKeyStatus `json:"gpgKeys,omitempty"`
+}
+
+// ArgoCDClusterStatus defines the observed state of a managed cluster.
+type ArgoCDClusterStatus struct {
+	// Name is the name of the cluster.
+	Name string `json:"name"`
+
+	// Server is the URL of the API server of the cluster.
+	Server string `json:"server,omitempty"`
+
+	// Phase is a simple, high-level summary of the connection to the cluster.
+	// There are three possible phase values:
+	// Connected: The API server of the cluster could be reached with the configured credentials.
+	// Failed: The cluster could not be registered, or its API server could not be reached.
+	// Unknown: The connection to the API server of the cluster has not been checked yet.
+	Phase string `json:"phase"`
+
+	// Message is a human readable description of the failure, or of a shard the cluster is pinned to but which is not
+	// run by the Application Controller, if any.
+	Message string `json:"message,omitempty"`
+
+	// ServerVersion is the Kubernetes version reported by the API server of the cluster.
+	ServerVersion string `json:"serverVersion,omitempty"`
 }
 
 // Banner defines an additional banner message to be displayed in Argo CD UI
@@ -905,6 +1949,36 @@ type ArgoCDTLSSpec struct {
 
 	// InitialCerts defines custom TLS certificates upon creation of the cluster for connecting Git repositories via HTTPS.
 	InitialCerts map[string]string `json:"initialCerts,omitempty"`
+
+	// IssuerRef references the cert-manager issuer used to request the TLS certificates of the Argo CD server, repo server,
+	// redis and ApplicationSet webhook. When set, the operator manages cert-manager Certificates for these components
+	// instead of relying on self-signed or OpenShift service CA certificates.
+	IssuerRef *ArgoCDIssuerReference `json:"issuerRef,omitempty"`
+
+	// RenewBefore is how long before their expiry the CA and TLS certificates generated by the operator are re-issued.
+	// Defaults to 720h (30 days).
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// KeyAlgorithm is the algorithm of the private keys generated by the operator for its CA and TLS certificates.
+	// Defaults to RSA.
+	// +kubebuilder:validation:Enum=RSA;ECDSA;Ed25519
+	KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+
+	// KeySize is the size of the private keys generated by the operator: the modulus length in bits for RSA, at least
+	// and defaulting to 2048, or the curve size for ECDSA, one of 256, 384 or 521 and defaulting to 256. It is ignored
+	// for Ed25519.
+	KeySize int `json:"keySize,omitempty"`
+
+	// Validity is how long the CA and TLS certificates generated by the operator are valid for. Defaults to 8760h (365 days).
+	Validity *metav1.Duration `json:"validity,omitempty"`
+
+	// ExtraSANs are additional DNS names or IP addresses added to the TLS certificate generated by the operator.
+	ExtraSANs []string `json:"extraSANs,omitempty"`
+
+	// MutualTLS enables mutual TLS between the Argo CD components. The operator CA issues client certificates to the
+	// Argo CD server, application controller and repo server, Redis requires them from its clients, and the server and
+	// application controller strictly verify the certificate of the repo server. Requires Redis TLS to be enabled.
+	MutualTLS bool `json:"mutualTLS,omitempty"`
 }
 
 type SSHHostsSpec struct {
@@ -919,6 +1993,8 @@ type SSHHostsSpec struct {
 
 // WebhookServerSpec defines the options for the ApplicationSet Webhook Server component.
 type WebhookServerSpec struct {
+	// Gateway defines the desired state for a Gateway API HTTPRoute for the Application set webhook component.
+	Gateway ArgoCDGatewaySpec `json:"gateway,omitempty"`
 
 	// Host is the hostname to use for Ingress/Route resources.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Host",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:text"}
@@ -36,7 +36,7 @@ func (in *ArgoCD) DeepCopyInto(out *ArgoCD) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCD.
@@ -146,7 +146,22 @@ func (in *ArgoCDApplicationSet) DeepCopyInto(out *ArgoCDApplicationSet) {
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ConcurrentReconciles != nil {
+		in, out := &in.ConcurrentReconciles, &out.ConcurrentReconciles
+		*out = new(int32)
+		**out = **in
+	}
 	in.WebhookServer.DeepCopyInto(&out.WebhookServer)
+	if in.SourceNamespaces != nil {
+		in, out := &in.SourceNamespaces, &out.SourceNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SCMProviders != nil {
+		in, out := &in.SCMProviders, &out.SCMProviders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationSet.
@@ -190,260 +205,1383 @@ func (in *ArgoCDCertificateSpec) DeepCopy() *ArgoCDCertificateSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDDexSpec) DeepCopyInto(out *ArgoCDDexSpec) {
+func (in *ArgoCDClusterSpec) DeepCopyInto(out *ArgoCDClusterSpec) {
 	*out = *in
-	if in.Groups != nil {
-		in, out := &in.Groups, &out.Groups
+	if in.KubeconfigSecret != nil {
+		in, out := &in.KubeconfigSecret, &out.KubeconfigSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BearerTokenSecret != nil {
+		in, out := &in.BearerTokenSecret, &out.BearerTokenSecret
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Shard != nil {
+		in, out := &in.Shard, &out.Shard
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexSpec.
-func (in *ArgoCDDexSpec) DeepCopy() *ArgoCDDexSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDClusterSpec.
+func (in *ArgoCDClusterSpec) DeepCopy() *ArgoCDClusterSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDDexSpec)
+	out := new(ArgoCDClusterSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDGrafanaSpec) DeepCopyInto(out *ArgoCDGrafanaSpec) {
+func (in *ArgoCDClusterStatus) DeepCopyInto(out *ArgoCDClusterStatus) {
 	*out = *in
-	in.Ingress.DeepCopyInto(&out.Ingress)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDClusterStatus.
+func (in *ArgoCDClusterStatus) DeepCopy() *ArgoCDClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDConfigManagementPlugin) DeepCopyInto(out *ArgoCDConfigManagementPlugin) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
-	in.Route.DeepCopyInto(&out.Route)
-	if in.Size != nil {
-		in, out := &in.Size, &out.Size
-		*out = new(int32)
-		**out = **in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGrafanaSpec.
-func (in *ArgoCDGrafanaSpec) DeepCopy() *ArgoCDGrafanaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDConfigManagementPlugin.
+func (in *ArgoCDConfigManagementPlugin) DeepCopy() *ArgoCDConfigManagementPlugin {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDGrafanaSpec)
+	out := new(ArgoCDConfigManagementPlugin)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDHASpec) DeepCopyInto(out *ArgoCDHASpec) {
+func (in *ArgoCDConfigManagementPluginCommand) DeepCopyInto(out *ArgoCDConfigManagementPluginCommand) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDHASpec.
-func (in *ArgoCDHASpec) DeepCopy() *ArgoCDHASpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDConfigManagementPluginCommand.
+func (in *ArgoCDConfigManagementPluginCommand) DeepCopy() *ArgoCDConfigManagementPluginCommand {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDHASpec)
+	out := new(ArgoCDConfigManagementPluginCommand)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDImportSpec) DeepCopyInto(out *ArgoCDImportSpec) {
+func (in *ArgoCDConfigManagementPluginDiscover) DeepCopyInto(out *ArgoCDConfigManagementPluginDiscover) {
 	*out = *in
-	if in.Namespace != nil {
-		in, out := &in.Namespace, &out.Namespace
-		*out = new(string)
-		**out = **in
+	if in.Find != nil {
+		in, out := &in.Find, &out.Find
+		*out = new(ArgoCDConfigManagementPluginFind)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDImportSpec.
-func (in *ArgoCDImportSpec) DeepCopy() *ArgoCDImportSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDConfigManagementPluginDiscover.
+func (in *ArgoCDConfigManagementPluginDiscover) DeepCopy() *ArgoCDConfigManagementPluginDiscover {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDImportSpec)
+	out := new(ArgoCDConfigManagementPluginDiscover)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDIngressSpec) DeepCopyInto(out *ArgoCDIngressSpec) {
+func (in *ArgoCDConfigManagementPluginFind) DeepCopyInto(out *ArgoCDConfigManagementPluginFind) {
 	*out = *in
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
+	in.ArgoCDConfigManagementPluginCommand.DeepCopyInto(&out.ArgoCDConfigManagementPluginCommand)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDConfigManagementPluginFind.
+func (in *ArgoCDConfigManagementPluginFind) DeepCopy() *ArgoCDConfigManagementPluginFind {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDConfigManagementPluginFind)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDConfigManagementPluginParameter) DeepCopyInto(out *ArgoCDConfigManagementPluginParameter) {
+	*out = *in
+	if in.Array != nil {
+		in, out := &in.Array, &out.Array
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Map != nil {
+		in, out := &in.Map, &out.Map
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.IngressClassName != nil {
-		in, out := &in.IngressClassName, &out.IngressClassName
-		*out = new(string)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDConfigManagementPluginParameter.
+func (in *ArgoCDConfigManagementPluginParameter) DeepCopy() *ArgoCDConfigManagementPluginParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDConfigManagementPluginParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDConfigManagementPluginParameters) DeepCopyInto(out *ArgoCDConfigManagementPluginParameters) {
+	*out = *in
+	if in.Static != nil {
+		in, out := &in.Static, &out.Static
+		*out = make([]ArgoCDConfigManagementPluginParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Dynamic != nil {
+		in, out := &in.Dynamic, &out.Dynamic
+		*out = new(ArgoCDConfigManagementPluginCommand)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDConfigManagementPluginParameters.
+func (in *ArgoCDConfigManagementPluginParameters) DeepCopy() *ArgoCDConfigManagementPluginParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDConfigManagementPluginParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDConfigManagementPluginSpec) DeepCopyInto(out *ArgoCDConfigManagementPluginSpec) {
+	*out = *in
+	if in.Init != nil {
+		in, out := &in.Init, &out.Init
+		*out = new(ArgoCDConfigManagementPluginCommand)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Generate.DeepCopyInto(&out.Generate)
+	if in.Discover != nil {
+		in, out := &in.Discover, &out.Discover
+		*out = new(ArgoCDConfigManagementPluginDiscover)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = new(ArgoCDConfigManagementPluginParameters)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDConfigManagementPluginSpec.
+func (in *ArgoCDConfigManagementPluginSpec) DeepCopy() *ArgoCDConfigManagementPluginSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDConfigManagementPluginSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDefaultProjectSpec) DeepCopyInto(out *ArgoCDDefaultProjectSpec) {
+	*out = *in
+	if in.SourceRepos != nil {
+		in, out := &in.SourceRepos, &out.SourceRepos
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]ArgoCDProjectDestination, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterResourceWhitelist != nil {
+		in, out := &in.ClusterResourceWhitelist, &out.ClusterResourceWhitelist
+		*out = make([]metav1.GroupKind, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterResourceBlacklist != nil {
+		in, out := &in.ClusterResourceBlacklist, &out.ClusterResourceBlacklist
+		*out = make([]metav1.GroupKind, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDefaultProjectSpec.
+func (in *ArgoCDDefaultProjectSpec) DeepCopy() *ArgoCDDefaultProjectSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDefaultProjectSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexConnector) DeepCopyInto(out *ArgoCDDexConnector) {
+	*out = *in
+	if in.GitHub != nil {
+		in, out := &in.GitHub, &out.GitHub
+		*out = new(ArgoCDDexGitHubConnector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitLab != nil {
+		in, out := &in.GitLab, &out.GitLab
+		*out = new(ArgoCDDexGitLabConnector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(ArgoCDDexLDAPConnector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Microsoft != nil {
+		in, out := &in.Microsoft, &out.Microsoft
+		*out = new(ArgoCDDexMicrosoftConnector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(ArgoCDDexOIDCConnector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SAML != nil {
+		in, out := &in.SAML, &out.SAML
+		*out = new(ArgoCDDexSAMLConnector)
 		**out = **in
 	}
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = make([]networkingv1.IngressTLS, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexConnector.
+func (in *ArgoCDDexConnector) DeepCopy() *ArgoCDDexConnector {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexConnector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexGitHubConnector) DeepCopyInto(out *ArgoCDDexGitHubConnector) {
+	*out = *in
+	in.ClientSecret.DeepCopyInto(&out.ClientSecret)
+	if in.Orgs != nil {
+		in, out := &in.Orgs, &out.Orgs
+		*out = make([]ArgoCDDexGitHubOrg, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDIngressSpec.
-func (in *ArgoCDIngressSpec) DeepCopy() *ArgoCDIngressSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexGitHubConnector.
+func (in *ArgoCDDexGitHubConnector) DeepCopy() *ArgoCDDexGitHubConnector {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDIngressSpec)
+	out := new(ArgoCDDexGitHubConnector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDKeycloakSpec) DeepCopyInto(out *ArgoCDKeycloakSpec) {
+func (in *ArgoCDDexGitHubOrg) DeepCopyInto(out *ArgoCDDexGitHubOrg) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexGitHubOrg.
+func (in *ArgoCDDexGitHubOrg) DeepCopy() *ArgoCDDexGitHubOrg {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexGitHubOrg)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexGitLabConnector) DeepCopyInto(out *ArgoCDDexGitLabConnector) {
+	*out = *in
+	in.ClientSecret.DeepCopyInto(&out.ClientSecret)
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexGitLabConnector.
+func (in *ArgoCDDexGitLabConnector) DeepCopy() *ArgoCDDexGitLabConnector {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexGitLabConnector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexLDAPConnector) DeepCopyInto(out *ArgoCDDexLDAPConnector) {
+	*out = *in
+	if in.BindPW != nil {
+		in, out := &in.BindPW, &out.BindPW
+		*out = new(v1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.VerifyTLS != nil {
-		in, out := &in.VerifyTLS, &out.VerifyTLS
-		*out = new(bool)
+	out.UserSearch = in.UserSearch
+	if in.GroupSearch != nil {
+		in, out := &in.GroupSearch, &out.GroupSearch
+		*out = new(ArgoCDDexLDAPGroupSearch)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakSpec.
-func (in *ArgoCDKeycloakSpec) DeepCopy() *ArgoCDKeycloakSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexLDAPConnector.
+func (in *ArgoCDDexLDAPConnector) DeepCopy() *ArgoCDDexLDAPConnector {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexLDAPConnector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexLDAPGroupSearch) DeepCopyInto(out *ArgoCDDexLDAPGroupSearch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexLDAPGroupSearch.
+func (in *ArgoCDDexLDAPGroupSearch) DeepCopy() *ArgoCDDexLDAPGroupSearch {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexLDAPGroupSearch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexLDAPUserSearch) DeepCopyInto(out *ArgoCDDexLDAPUserSearch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexLDAPUserSearch.
+func (in *ArgoCDDexLDAPUserSearch) DeepCopy() *ArgoCDDexLDAPUserSearch {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexLDAPUserSearch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexMicrosoftConnector) DeepCopyInto(out *ArgoCDDexMicrosoftConnector) {
+	*out = *in
+	in.ClientSecret.DeepCopyInto(&out.ClientSecret)
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexMicrosoftConnector.
+func (in *ArgoCDDexMicrosoftConnector) DeepCopy() *ArgoCDDexMicrosoftConnector {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexMicrosoftConnector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexOIDCConnector) DeepCopyInto(out *ArgoCDDexOIDCConnector) {
+	*out = *in
+	in.ClientSecret.DeepCopyInto(&out.ClientSecret)
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexOIDCConnector.
+func (in *ArgoCDDexOIDCConnector) DeepCopy() *ArgoCDDexOIDCConnector {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexOIDCConnector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexSAMLConnector) DeepCopyInto(out *ArgoCDDexSAMLConnector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexSAMLConnector.
+func (in *ArgoCDDexSAMLConnector) DeepCopy() *ArgoCDDexSAMLConnector {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexSAMLConnector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexSpec) DeepCopyInto(out *ArgoCDDexSpec) {
+	*out = *in
+	if in.Connectors != nil {
+		in, out := &in.Connectors, &out.Connectors
+		*out = make([]ArgoCDDexConnector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexSpec.
+func (in *ArgoCDDexSpec) DeepCopy() *ArgoCDDexSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDGPGKey) DeepCopyInto(out *ArgoCDGPGKey) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGPGKey.
+func (in *ArgoCDGPGKey) DeepCopy() *ArgoCDGPGKey {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDGPGKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDGPGKeyStatus) DeepCopyInto(out *ArgoCDGPGKeyStatus) {
+	*out = *in
+	if in.Expires != nil {
+		in, out := &in.Expires, &out.Expires
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGPGKeyStatus.
+func (in *ArgoCDGPGKeyStatus) DeepCopy() *ArgoCDGPGKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDGPGKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDGatewayParentReference) DeepCopyInto(out *ArgoCDGatewayParentReference) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGatewayParentReference.
+func (in *ArgoCDGatewayParentReference) DeepCopy() *ArgoCDGatewayParentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDGatewayParentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDGatewaySpec) DeepCopyInto(out *ArgoCDGatewaySpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]ArgoCDGatewayParentReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ArgoCDGatewayTLSSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGatewaySpec.
+func (in *ArgoCDGatewaySpec) DeepCopy() *ArgoCDGatewaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDGatewaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDGatewayTLSSpec) DeepCopyInto(out *ArgoCDGatewayTLSSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGatewayTLSSpec.
+func (in *ArgoCDGatewayTLSSpec) DeepCopy() *ArgoCDGatewayTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDGatewayTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDGrafanaSpec) DeepCopyInto(out *ArgoCDGrafanaSpec) {
+	*out = *in
+	in.Gateway.DeepCopyInto(&out.Gateway)
+	in.Ingress.DeepCopyInto(&out.Ingress)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Route.DeepCopyInto(&out.Route)
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGrafanaSpec.
+func (in *ArgoCDGrafanaSpec) DeepCopy() *ArgoCDGrafanaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDGrafanaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDHASpec) DeepCopyInto(out *ArgoCDHASpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDHASpec.
+func (in *ArgoCDHASpec) DeepCopy() *ArgoCDHASpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDHASpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDImportSpec) DeepCopyInto(out *ArgoCDImportSpec) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDImportSpec.
+func (in *ArgoCDImportSpec) DeepCopy() *ArgoCDImportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDImportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDIngressSpec) DeepCopyInto(out *ArgoCDIngressSpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = make([]networkingv1.IngressTLS, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDIngressSpec.
+func (in *ArgoCDIngressSpec) DeepCopy() *ArgoCDIngressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDIngressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDIssuerReference) DeepCopyInto(out *ArgoCDIssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDIssuerReference.
+func (in *ArgoCDIssuerReference) DeepCopy() *ArgoCDIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDKeycloakExternalSpec) DeepCopyInto(out *ArgoCDKeycloakExternalSpec) {
+	*out = *in
+	if in.RedirectURIs != nil {
+		in, out := &in.RedirectURIs, &out.RedirectURIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakExternalSpec.
+func (in *ArgoCDKeycloakExternalSpec) DeepCopy() *ArgoCDKeycloakExternalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDKeycloakExternalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDKeycloakSpec) DeepCopyInto(out *ArgoCDKeycloakSpec) {
+	*out = *in
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ArgoCDKeycloakExternalSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Gateway.DeepCopyInto(&out.Gateway)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VerifyTLS != nil {
+		in, out := &in.VerifyTLS, &out.VerifyTLS
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakSpec.
+func (in *ArgoCDKeycloakSpec) DeepCopy() *ArgoCDKeycloakSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDKeycloakSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDList) DeepCopyInto(out *ArgoCDList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ArgoCD, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDList.
+func (in *ArgoCDList) DeepCopy() *ArgoCDList {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCDList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDMonitoringSpec) DeepCopyInto(out *ArgoCDMonitoringSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDMonitoringSpec.
+func (in *ArgoCDMonitoringSpec) DeepCopy() *ArgoCDMonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDMonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNodePlacementSpec) DeepCopyInto(out *ArgoCDNodePlacementSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNodePlacementSpec.
+func (in *ArgoCDNodePlacementSpec) DeepCopy() *ArgoCDNodePlacementSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNodePlacementSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotifications) DeepCopyInto(out *ArgoCDNotifications) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SelfServiceNamespaces != nil {
+		in, out := &in.SelfServiceNamespaces, &out.SelfServiceNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(ArgoCDNotificationsConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotifications.
+func (in *ArgoCDNotifications) DeepCopy() *ArgoCDNotifications {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotifications)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsBasicAuth) DeepCopyInto(out *ArgoCDNotificationsBasicAuth) {
+	*out = *in
+	in.PasswordSecret.DeepCopyInto(&out.PasswordSecret)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsBasicAuth.
+func (in *ArgoCDNotificationsBasicAuth) DeepCopy() *ArgoCDNotificationsBasicAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsBasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsConfigSpec) DeepCopyInto(out *ArgoCDNotificationsConfigSpec) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]ArgoCDNotificationsService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]ArgoCDNotificationsTrigger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]ArgoCDNotificationsTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Subscriptions != nil {
+		in, out := &in.Subscriptions, &out.Subscriptions
+		*out = make([]ArgoCDNotificationsSubscription, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsConfigSpec.
+func (in *ArgoCDNotificationsConfigSpec) DeepCopy() *ArgoCDNotificationsConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsEmailService) DeepCopyInto(out *ArgoCDNotificationsEmailService) {
+	*out = *in
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsEmailService.
+func (in *ArgoCDNotificationsEmailService) DeepCopy() *ArgoCDNotificationsEmailService {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsEmailService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsEmailTemplate) DeepCopyInto(out *ArgoCDNotificationsEmailTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsEmailTemplate.
+func (in *ArgoCDNotificationsEmailTemplate) DeepCopy() *ArgoCDNotificationsEmailTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsEmailTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsService) DeepCopyInto(out *ArgoCDNotificationsService) {
+	*out = *in
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(ArgoCDNotificationsSlackService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(ArgoCDNotificationsEmailService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(ArgoCDNotificationsWebhookService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = new(ArgoCDNotificationsTeamsService)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsService.
+func (in *ArgoCDNotificationsService) DeepCopy() *ArgoCDNotificationsService {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsSlackService) DeepCopyInto(out *ArgoCDNotificationsSlackService) {
+	*out = *in
+	in.TokenSecret.DeepCopyInto(&out.TokenSecret)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsSlackService.
+func (in *ArgoCDNotificationsSlackService) DeepCopy() *ArgoCDNotificationsSlackService {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsSlackService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsSlackTemplate) DeepCopyInto(out *ArgoCDNotificationsSlackTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsSlackTemplate.
+func (in *ArgoCDNotificationsSlackTemplate) DeepCopy() *ArgoCDNotificationsSlackTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsSlackTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsSubscription) DeepCopyInto(out *ArgoCDNotificationsSubscription) {
+	*out = *in
+	if in.Recipients != nil {
+		in, out := &in.Recipients, &out.Recipients
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsSubscription.
+func (in *ArgoCDNotificationsSubscription) DeepCopy() *ArgoCDNotificationsSubscription {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsSubscription)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsTeamsRecipient) DeepCopyInto(out *ArgoCDNotificationsTeamsRecipient) {
+	*out = *in
+	in.URLSecret.DeepCopyInto(&out.URLSecret)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsTeamsRecipient.
+func (in *ArgoCDNotificationsTeamsRecipient) DeepCopy() *ArgoCDNotificationsTeamsRecipient {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsTeamsRecipient)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsTeamsService) DeepCopyInto(out *ArgoCDNotificationsTeamsService) {
+	*out = *in
+	if in.Recipients != nil {
+		in, out := &in.Recipients, &out.Recipients
+		*out = make([]ArgoCDNotificationsTeamsRecipient, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsTeamsService.
+func (in *ArgoCDNotificationsTeamsService) DeepCopy() *ArgoCDNotificationsTeamsService {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsTeamsService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsTeamsTemplate) DeepCopyInto(out *ArgoCDNotificationsTeamsTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsTeamsTemplate.
+func (in *ArgoCDNotificationsTeamsTemplate) DeepCopy() *ArgoCDNotificationsTeamsTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsTeamsTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsTemplate) DeepCopyInto(out *ArgoCDNotificationsTemplate) {
+	*out = *in
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(ArgoCDNotificationsEmailTemplate)
+		**out = **in
+	}
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(ArgoCDNotificationsSlackTemplate)
+		**out = **in
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = new(ArgoCDNotificationsTeamsTemplate)
+		**out = **in
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = make(map[string]ArgoCDNotificationsWebhookTemplate, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsTemplate.
+func (in *ArgoCDNotificationsTemplate) DeepCopy() *ArgoCDNotificationsTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsTrigger) DeepCopyInto(out *ArgoCDNotificationsTrigger) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ArgoCDNotificationsTriggerCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsTrigger.
+func (in *ArgoCDNotificationsTrigger) DeepCopy() *ArgoCDNotificationsTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsTriggerCondition) DeepCopyInto(out *ArgoCDNotificationsTriggerCondition) {
+	*out = *in
+	if in.Send != nil {
+		in, out := &in.Send, &out.Send
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsTriggerCondition.
+func (in *ArgoCDNotificationsTriggerCondition) DeepCopy() *ArgoCDNotificationsTriggerCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsTriggerCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsWebhookHeader) DeepCopyInto(out *ArgoCDNotificationsWebhookHeader) {
+	*out = *in
+	if in.ValueSecret != nil {
+		in, out := &in.ValueSecret, &out.ValueSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsWebhookHeader.
+func (in *ArgoCDNotificationsWebhookHeader) DeepCopy() *ArgoCDNotificationsWebhookHeader {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDKeycloakSpec)
+	out := new(ArgoCDNotificationsWebhookHeader)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDList) DeepCopyInto(out *ArgoCDList) {
+func (in *ArgoCDNotificationsWebhookService) DeepCopyInto(out *ArgoCDNotificationsWebhookService) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ArgoCD, len(*in))
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]ArgoCDNotificationsWebhookHeader, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(ArgoCDNotificationsBasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDList.
-func (in *ArgoCDList) DeepCopy() *ArgoCDList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsWebhookService.
+func (in *ArgoCDNotificationsWebhookService) DeepCopy() *ArgoCDNotificationsWebhookService {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDList)
+	out := new(ArgoCDNotificationsWebhookService)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ArgoCDList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsWebhookTemplate) DeepCopyInto(out *ArgoCDNotificationsWebhookTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsWebhookTemplate.
+func (in *ArgoCDNotificationsWebhookTemplate) DeepCopy() *ArgoCDNotificationsWebhookTemplate {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(ArgoCDNotificationsWebhookTemplate)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDMonitoringSpec) DeepCopyInto(out *ArgoCDMonitoringSpec) {
+func (in *ArgoCDOIDCClaim) DeepCopyInto(out *ArgoCDOIDCClaim) {
 	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDMonitoringSpec.
-func (in *ArgoCDMonitoringSpec) DeepCopy() *ArgoCDMonitoringSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDOIDCClaim.
+func (in *ArgoCDOIDCClaim) DeepCopy() *ArgoCDOIDCClaim {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDMonitoringSpec)
+	out := new(ArgoCDOIDCClaim)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDNodePlacementSpec) DeepCopyInto(out *ArgoCDNodePlacementSpec) {
+func (in *ArgoCDOIDCSpec) DeepCopyInto(out *ArgoCDOIDCSpec) {
 	*out = *in
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
+	if in.ClientSecret != nil {
+		in, out := &in.ClientSecret, &out.ClientSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestedScopes != nil {
+		in, out := &in.RequestedScopes, &out.RequestedScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequestedIDTokenClaims != nil {
+		in, out := &in.RequestedIDTokenClaims, &out.RequestedIDTokenClaims
+		*out = make(map[string]ArgoCDOIDCClaim, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.RootCA != nil {
+		in, out := &in.RootCA, &out.RootCA
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNodePlacementSpec.
-func (in *ArgoCDNodePlacementSpec) DeepCopy() *ArgoCDNodePlacementSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDOIDCSpec.
+func (in *ArgoCDOIDCSpec) DeepCopy() *ArgoCDOIDCSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDNodePlacementSpec)
+	out := new(ArgoCDOIDCSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDNotifications) DeepCopyInto(out *ArgoCDNotifications) {
+func (in *ArgoCDProjectDestination) DeepCopyInto(out *ArgoCDProjectDestination) {
 	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotifications.
-func (in *ArgoCDNotifications) DeepCopy() *ArgoCDNotifications {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDProjectDestination.
+func (in *ArgoCDProjectDestination) DeepCopy() *ArgoCDProjectDestination {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDNotifications)
+	out := new(ArgoCDProjectDestination)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -451,6 +1589,7 @@ func (in *ArgoCDNotifications) DeepCopy() *ArgoCDNotifications {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDPrometheusSpec) DeepCopyInto(out *ArgoCDPrometheusSpec) {
 	*out = *in
+	in.Gateway.DeepCopyInto(&out.Gateway)
 	in.Ingress.DeepCopyInto(&out.Ingress)
 	in.Route.DeepCopyInto(&out.Route)
 	if in.Size != nil {
@@ -470,6 +1609,56 @@ func (in *ArgoCDPrometheusSpec) DeepCopy() *ArgoCDPrometheusSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACGroupBinding) DeepCopyInto(out *ArgoCDRBACGroupBinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACGroupBinding.
+func (in *ArgoCDRBACGroupBinding) DeepCopy() *ArgoCDRBACGroupBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRBACGroupBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACPolicy) DeepCopyInto(out *ArgoCDRBACPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACPolicy.
+func (in *ArgoCDRBACPolicy) DeepCopy() *ArgoCDRBACPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRBACPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACRole) DeepCopyInto(out *ArgoCDRBACRole) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]ArgoCDRBACPolicy, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACRole.
+func (in *ArgoCDRBACRole) DeepCopy() *ArgoCDRBACRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRBACRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDRBACSpec) DeepCopyInto(out *ArgoCDRBACSpec) {
 	*out = *in
@@ -493,6 +1682,18 @@ func (in *ArgoCDRBACSpec) DeepCopyInto(out *ArgoCDRBACSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]ArgoCDRBACRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GroupBindings != nil {
+		in, out := &in.GroupBindings, &out.GroupBindings
+		*out = make([]ArgoCDRBACGroupBinding, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACSpec.
@@ -505,6 +1706,81 @@ func (in *ArgoCDRBACSpec) DeepCopy() *ArgoCDRBACSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRedisExternalSpec) DeepCopyInto(out *ArgoCDRedisExternalSpec) {
+	*out = *in
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ArgoCDRedisExternalTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sentinel != nil {
+		in, out := &in.Sentinel, &out.Sentinel
+		*out = new(ArgoCDRedisSentinelSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisExternalSpec.
+func (in *ArgoCDRedisExternalSpec) DeepCopy() *ArgoCDRedisExternalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRedisExternalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRedisExternalTLSSpec) DeepCopyInto(out *ArgoCDRedisExternalTLSSpec) {
+	*out = *in
+	if in.CASecret != nil {
+		in, out := &in.CASecret, &out.CASecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisExternalTLSSpec.
+func (in *ArgoCDRedisExternalTLSSpec) DeepCopy() *ArgoCDRedisExternalTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRedisExternalTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRedisSentinelSpec) DeepCopyInto(out *ArgoCDRedisSentinelSpec) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisSentinelSpec.
+func (in *ArgoCDRedisSentinelSpec) DeepCopy() *ArgoCDRedisSentinelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRedisSentinelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDRedisSpec) DeepCopyInto(out *ArgoCDRedisSpec) {
 	*out = *in
@@ -513,6 +1789,11 @@ func (in *ArgoCDRedisSpec) DeepCopyInto(out *ArgoCDRedisSpec) {
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ArgoCDRedisExternalSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisSpec.
@@ -525,6 +1806,26 @@ func (in *ArgoCDRedisSpec) DeepCopy() *ArgoCDRedisSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepoAutoscaleSpec) DeepCopyInto(out *ArgoCDRepoAutoscaleSpec) {
+	*out = *in
+	if in.HPA != nil {
+		in, out := &in.HPA, &out.HPA
+		*out = new(autoscalingv1.HorizontalPodAutoscalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoAutoscaleSpec.
+func (in *ArgoCDRepoAutoscaleSpec) DeepCopy() *ArgoCDRepoAutoscaleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepoAutoscaleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDRepoSpec) DeepCopyInto(out *ArgoCDRepoSpec) {
 	*out = *in
@@ -583,6 +1884,19 @@ func (in *ArgoCDRepoSpec) DeepCopyInto(out *ArgoCDRepoSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]ArgoCDConfigManagementPlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Autoscale.DeepCopyInto(&out.Autoscale)
+	if in.TmpVolume != nil {
+		in, out := &in.TmpVolume, &out.TmpVolume
+		*out = new(ArgoCDRepoWorkingVolumeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoSpec.
@@ -595,6 +1909,51 @@ func (in *ArgoCDRepoSpec) DeepCopy() *ArgoCDRepoSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepoWorkingVolumeSpec) DeepCopyInto(out *ArgoCDRepoWorkingVolumeSpec) {
+	*out = *in
+	if in.SizeLimit != nil {
+		in, out := &in.SizeLimit, &out.SizeLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.VolumeClaimTemplate != nil {
+		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
+		*out = new(v1.PersistentVolumeClaimTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoWorkingVolumeSpec.
+func (in *ArgoCDRepoWorkingVolumeSpec) DeepCopy() *ArgoCDRepoWorkingVolumeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepoWorkingVolumeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepository) DeepCopyInto(out *ArgoCDRepository) {
+	*out = *in
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepository.
+func (in *ArgoCDRepository) DeepCopy() *ArgoCDRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDRouteSpec) DeepCopyInto(out *ArgoCDRouteSpec) {
 	*out = *in
@@ -647,6 +2006,11 @@ func (in *ArgoCDSSOSpec) DeepCopyInto(out *ArgoCDSSOSpec) {
 		*out = new(ArgoCDKeycloakSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(ArgoCDOIDCSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSSOSpec.
@@ -682,6 +2046,7 @@ func (in *ArgoCDServerAutoscaleSpec) DeepCopy() *ArgoCDServerAutoscaleSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDServerGRPCSpec) DeepCopyInto(out *ArgoCDServerGRPCSpec) {
 	*out = *in
+	in.Gateway.DeepCopyInto(&out.Gateway)
 	in.Ingress.DeepCopyInto(&out.Ingress)
 }
 
@@ -715,6 +2080,7 @@ func (in *ArgoCDServerSpec) DeepCopyInto(out *ArgoCDServerSpec) {
 	*out = *in
 	in.Autoscale.DeepCopyInto(&out.Autoscale)
 	in.GRPC.DeepCopyInto(&out.GRPC)
+	in.Gateway.DeepCopyInto(&out.Gateway)
 	in.Ingress.DeepCopyInto(&out.Ingress)
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
@@ -760,7 +2126,19 @@ func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
 		*out = new(ArgoCDApplicationSet)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ArgoCDClusterSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.Controller.DeepCopyInto(&out.Controller)
+	if in.DefaultProject != nil {
+		in, out := &in.DefaultProject, &out.DefaultProject
+		*out = new(ArgoCDDefaultProjectSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ExtraConfig != nil {
 		in, out := &in.ExtraConfig, &out.ExtraConfig
 		*out = make(map[string]string, len(*in))
@@ -768,6 +2146,13 @@ func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.GPGKeys != nil {
+		in, out := &in.GPGKeys, &out.GPGKeys
+		*out = make([]ArgoCDGPGKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.Grafana.DeepCopyInto(&out.Grafana)
 	in.HA.DeepCopyInto(&out.HA)
 	if in.Import != nil {
@@ -792,6 +2177,20 @@ func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
 	in.RBAC.DeepCopyInto(&out.RBAC)
 	in.Redis.DeepCopyInto(&out.Redis)
 	in.Repo.DeepCopyInto(&out.Repo)
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]ArgoCDRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RepositoryCredentialTemplates != nil {
+		in, out := &in.RepositoryCredentialTemplates, &out.RepositoryCredentialTemplates
+		*out = make([]ArgoCDRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ResourceHealthChecks != nil {
 		in, out := &in.ResourceHealthChecks, &out.ResourceHealthChecks
 		*out = make([]ResourceHealthCheck, len(*in))
@@ -839,6 +2238,25 @@ func (in *ArgoCDSpec) DeepCopy() *ArgoCDSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDStatus) DeepCopyInto(out *ArgoCDStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ArgoCDClusterStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.GPGKeys != nil {
+		in, out := &in.GPGKeys, &out.GPGKeys
+		*out = make([]ArgoCDGPGKeyStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDStatus.
@@ -862,6 +2280,26 @@ func (in *ArgoCDTLSSpec) DeepCopyInto(out *ArgoCDTLSSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(ArgoCDIssuerReference)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Validity != nil {
+		in, out := &in.Validity, &out.Validity
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ExtraSANs != nil {
+		in, out := &in.ExtraSANs, &out.ExtraSANs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDTLSSpec.
@@ -1025,6 +2463,7 @@ func (in *SSHHostsSpec) DeepCopy() *SSHHostsSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookServerSpec) DeepCopyInto(out *WebhookServerSpec) {
 	*out = *in
+	in.Gateway.DeepCopyInto(&out.Gateway)
 	in.Ingress.DeepCopyInto(&out.Ingress)
 	in.Route.DeepCopyInto(&out.Route)
 }
@@ -0,0 +1,93 @@
+#!/usr/bin/env python3
+
+# Copyright 2020 ArgoCD Operator Developers
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+# 	http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Filters the output of "argocd admin export" read from stdin and writes the selected documents to stdout.
+
+import argparse
+import base64
+import sys
+
+import yaml
+
+SECRET_TYPE_LABEL = "argocd.argoproj.io/secret-type"
+
+
+def split(value):
+    return [v for v in value.split(",") if v] if value else []
+
+
+def parse_args():
+    parser = argparse.ArgumentParser()
+    for flag in ["projects", "namespaces", "kinds"]:
+        parser.add_argument("--include-" + flag, type=split, default=[])
+        parser.add_argument("--exclude-" + flag, type=split, default=[])
+    return parser.parse_args()
+
+
+def selected(value, include, exclude):
+    if include and value not in include:
+        return False
+    return value not in exclude
+
+
+def secret_project(doc):
+    project = (doc.get("stringData") or {}).get("project")
+    if project is None and (doc.get("data") or {}).get("project"):
+        project = base64.b64decode(doc["data"]["project"]).decode()
+    return project
+
+
+def classify(doc):
+    """Returns the export kind, project and application namespace of the given document."""
+    kind = doc.get("kind")
+    metadata = doc.get("metadata") or {}
+    spec = doc.get("spec") or {}
+    if kind == "AppProject":
+        return None, metadata.get("name"), None
+    if kind == "Application":
+        return "applications", spec.get("project", "default"), metadata.get("namespace")
+    if kind == "ApplicationSet":
+        template = (spec.get("template") or {}).get("spec") or {}
+        return "applicationsets", template.get("project"), metadata.get("namespace")
+    if kind == "Secret":
+        secret_type = (metadata.get("labels") or {}).get(SECRET_TYPE_LABEL)
+        if secret_type == "cluster":
+            return "clusters", secret_project(doc), None
+        if secret_type in ("repository", "repo-creds"):
+            return "repositories", secret_project(doc), None
+    return "settings", None, None
+
+
+def main():
+    args = parse_args()
+    out = []
+    for doc in yaml.safe_load_all(sys.stdin):
+        if not doc:
+            continue
+        kind, project, namespace = classify(doc)
+        if kind is not None and not selected(kind, args.include_kinds, args.exclude_kinds):
+            continue
+        # Documents without a project, such as settings and global repositories and clusters, are always kept.
+        if project is not None and not selected(project, args.include_projects, args.exclude_projects):
+            continue
+        if namespace is not None and not selected(namespace, args.include_namespaces, args.exclude_namespaces):
+            continue
+        out.append(doc)
+    yaml.safe_dump_all(out, sys.stdout, default_flow_style=False)
+
+
+if __name__ == "__main__":
+    main()
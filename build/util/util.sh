@@ -17,10 +17,12 @@
 BACKUP_SCRIPT=$0
 BACKUP_ACTION=$1
 BACKUP_LOCATION=$2
+BACKUP_FILTER_ARGS=("${@:3}")
 BACKUP_FILENAME=argocd-backup.yaml
 BACKUP_EXPORT_LOCATION=/tmp/${BACKUP_FILENAME}
 BACKUP_ENCRYPT_LOCATION=/backups/${BACKUP_FILENAME}
 BACKUP_KEY_LOCATION=/secrets/backup.key
+TERMINATION_MESSAGE_LOCATION=/dev/termination-log
 DEFAULT_BACKUP_BUCKET_REGION="us-east-1"
 
 export_argocd () {
@@ -28,12 +30,25 @@ export_argocd () {
     create_backup
     encrypt_backup
     push_backup
+    write_termination_message
     echo "argo-cd export complete"
 }
 
+# write_termination_message reports the size of the exported archive to the operator through the termination message.
+write_termination_message () {
+    BACKUP_SIZE=`stat -c %s ${BACKUP_ENCRYPT_LOCATION}`
+    echo "{\"size\":${BACKUP_SIZE}}" > ${TERMINATION_MESSAGE_LOCATION} || true
+}
+
 create_backup () {
     echo "creating argo-cd backup"
-    argocd admin export > ${BACKUP_EXPORT_LOCATION}
+    if [[ ${#BACKUP_FILTER_ARGS[@]} -gt 0 ]]; then
+        echo "filtering argo-cd backup: ${BACKUP_FILTER_ARGS[*]}"
+        set -o pipefail
+        argocd admin export | argocd-operator-util-filter "${BACKUP_FILTER_ARGS[@]}" > ${BACKUP_EXPORT_LOCATION}
+    else
+        argocd admin export > ${BACKUP_EXPORT_LOCATION}
+    fi
 }
 
 encrypt_backup () {
@@ -80,6 +95,12 @@ push_aws () {
 
 push_azure () {
     echo "pushing argo-cd backup to azure"
+    if [[ -n "${AZURE_STORAGE_ACCOUNT}" ]]; then
+        azure_storage_args
+        az storage container create "${AZURE_STORAGE_ARGS[@]}" --name ${AZURE_STORAGE_CONTAINER}
+        az storage blob upload "${AZURE_STORAGE_ARGS[@]}" --container-name ${AZURE_STORAGE_CONTAINER} --file ${BACKUP_ENCRYPT_LOCATION} --name $(object_name ${AZURE_STORAGE_PREFIX}) --overwrite
+        return
+    fi
     BACKUP_STORAGE_ACCOUNT=`cat /secrets/azure.storage.account`
     BACKUP_SERVICE_ID=`cat /secrets/azure.service.id`
     BACKUP_CERT_PATH="/secrets/azure.service.cert"
@@ -90,8 +111,28 @@ push_azure () {
     az storage blob upload --auth-mode login --account-name ${BACKUP_STORAGE_ACCOUNT} --container-name ${BACKUP_CONTAINER_NAME} --file ${BACKUP_ENCRYPT_LOCATION} --name ${BACKUP_FILENAME}
 }
 
+# azure_storage_args logs in and sets AZURE_STORAGE_ARGS from the environment configured by the ArgoCDExport azure
+# storage options: an account key from AZURE_STORAGE_KEY, or the token injected by Azure AD Workload Identity.
+azure_storage_args () {
+    AZURE_STORAGE_ARGS=(--account-name ${AZURE_STORAGE_ACCOUNT})
+    if [[ -n "${AZURE_STORAGE_ENDPOINT}" ]]; then
+        AZURE_STORAGE_ARGS+=(--blob-endpoint ${AZURE_STORAGE_ENDPOINT})
+    fi
+    if [[ -n "${AZURE_STORAGE_KEY}" ]]; then
+        AZURE_STORAGE_ARGS+=(--auth-mode key --account-key ${AZURE_STORAGE_KEY})
+    else
+        az login --service-principal -u ${AZURE_CLIENT_ID} --tenant ${AZURE_TENANT_ID} --federated-token "$(cat ${AZURE_FEDERATED_TOKEN_FILE})"
+        AZURE_STORAGE_ARGS+=(--auth-mode login)
+    fi
+}
+
 push_gcp () {
     echo "pushing argo-cd backup to gcp"
+    if [[ -n "${GCS_BUCKET}" ]]; then
+        gcs_login
+        gcloud storage cp ${BACKUP_ENCRYPT_LOCATION} gs://${GCS_BUCKET}/$(object_name ${GCS_PREFIX})
+        return
+    fi
     BACKUP_BUCKET_KEY="/secrets/gcp.key.file"
     BACKUP_PROJECT_ID=`cat /secrets/gcp.project.id`
     BACKUP_BUCKET_NAME=`cat /secrets/gcp.bucket.name`
@@ -101,6 +142,27 @@ push_gcp () {
     gsutil cp ${BACKUP_ENCRYPT_LOCATION} ${BACKUP_BUCKET_URI}/${BACKUP_FILENAME}
 }
 
+# gcs_login configures gcloud from the environment configured by the ArgoCDExport gcp storage options: an emulator
+# from STORAGE_EMULATOR_HOST, a key file from GOOGLE_APPLICATION_CREDENTIALS, or else the GKE Workload Identity
+# credentials served by the metadata server.
+gcs_login () {
+    if [[ -n "${STORAGE_EMULATOR_HOST}" ]]; then
+        export CLOUDSDK_API_ENDPOINT_OVERRIDES_STORAGE="${STORAGE_EMULATOR_HOST%/}/storage/v1/"
+        export CLOUDSDK_AUTH_DISABLE_CREDENTIALS=true
+    elif [[ -n "${GOOGLE_APPLICATION_CREDENTIALS}" ]]; then
+        gcloud auth activate-service-account --key-file=${GOOGLE_APPLICATION_CREDENTIALS}
+    fi
+}
+
+# object_name returns the name of the backup object, under the given prefix if any.
+object_name () {
+    if [[ -n "$1" ]]; then
+        echo "${1%/}/${BACKUP_FILENAME}"
+    else
+        echo "${BACKUP_FILENAME}"
+    fi
+}
+
 import_argocd () {
     echo "importing argo-cd"
     pull_backup
@@ -134,6 +196,11 @@ pull_aws () {
 
 pull_azure () {
     echo "pulling argo-cd backup from azure"
+    if [[ -n "${AZURE_STORAGE_ACCOUNT}" ]]; then
+        azure_storage_args
+        az storage blob download "${AZURE_STORAGE_ARGS[@]}" --container-name ${AZURE_STORAGE_CONTAINER} --file ${BACKUP_ENCRYPT_LOCATION} --name $(object_name ${AZURE_STORAGE_PREFIX})
+        return
+    fi
     BACKUP_STORAGE_ACCOUNT=`cat /secrets/azure.storage.account`
     BACKUP_SERVICE_ID=`cat /secrets/azure.service.id`
     BACKUP_CERT_PATH="/secrets/azure.service.cert"
@@ -145,6 +212,11 @@ pull_azure () {
 
 pull_gcp () {
     echo "pulling argo-cd backup from gcp"
+    if [[ -n "${GCS_BUCKET}" ]]; then
+        gcs_login
+        gcloud storage cp gs://${GCS_BUCKET}/$(object_name ${GCS_PREFIX}) ${BACKUP_ENCRYPT_LOCATION}
+        return
+    fi
     BACKUP_BUCKET_KEY="/secrets/gcp.key.file"
     BACKUP_PROJECT_ID=`cat /secrets/gcp.project.id`
     BACKUP_BUCKET_NAME=`cat /secrets/gcp.bucket.name`
@@ -164,7 +236,7 @@ load_backup () {
 }
 
 usage () {
-    echo "usage: ${BACKUP_SCRIPT} export|import"
+    echo "usage: ${BACKUP_SCRIPT} export|import BACKEND [--include-projects|--exclude-projects|--include-namespaces|--exclude-namespaces|--include-kinds|--exclude-kinds VALUES]"
 }
 
 case  ${BACKUP_ACTION} in
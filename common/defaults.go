@@ -14,6 +14,8 @@
 
 package common
 
+import "time"
+
 // app-controller
 const (
 	// ArgoCDApplicationControllerComponent is the name of the application controller control plane component
@@ -135,6 +137,9 @@ const (
 	// ArgoCDDefaultRedisSentinelPort is the default listen port for Redis sentinel.
 	ArgoCDDefaultRedisSentinelPort = 26379
 
+	// ArgoCDDefaultRedisSentinelMaster is the default name of the Redis master monitored by the sentinels.
+	ArgoCDDefaultRedisSentinelMaster = "master"
+
 	//ArgoCDDefaultRedisSuffix is the default suffix to use for Redis resources.
 	ArgoCDDefaultRedisSuffix = "redis"
 
@@ -222,6 +227,9 @@ const (
 
 	// ArgoCDDefaultExportLocalCapicity is the default capacity to use for local export.
 	ArgoCDDefaultExportLocalCapicity = "2Gi"
+
+	// ArgoCDDefaultExportMaxAge is the default time after the last successful export before the stale export alert fires.
+	ArgoCDDefaultExportMaxAge = time.Hour * 24
 )
 
 // General ArgoCD defaults
@@ -311,6 +319,18 @@ const (
 	// ArgoCDDefaultRSAKeySize is the default RSA key size when not specified.
 	ArgoCDDefaultRSAKeySize = 2048
 
+	// ArgoCDDefaultECDSAKeySize is the default ECDSA curve size when not specified.
+	ArgoCDDefaultECDSAKeySize = 256
+
+	// ArgoCDKeyAlgorithmRSA is the RSA algorithm for the private keys generated by the operator.
+	ArgoCDKeyAlgorithmRSA = "RSA"
+
+	// ArgoCDKeyAlgorithmECDSA is the ECDSA algorithm for the private keys generated by the operator.
+	ArgoCDKeyAlgorithmECDSA = "ECDSA"
+
+	// ArgoCDKeyAlgorithmEd25519 is the Ed25519 algorithm for the private keys generated by the operator.
+	ArgoCDKeyAlgorithmEd25519 = "Ed25519"
+
 	// ArgoCDDefaultSSHKnownHosts is the default SSH Known hosts data.
 	ArgoCDDefaultSSHKnownHosts = `[ssh.github.com]:443 ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTYAAAAIbmlzdHAyNTYAAABBBEmKSENjQEezOmxkZMy7opKgwFB9nkt5YRrYMjNuG5N87uRgg6CLrbo5wAdT/y6v0mKV0U2w0WZ2YB/++Tpockg=
 [ssh.github.com]:443 ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl
@@ -36,6 +36,12 @@ const (
 	// ArgoCDKeyBackupKey is the "backup key" key for ConfigMaps.
 	ArgoCDKeyBackupKey = "backup.key"
 
+	// ArgoCDKeyAzureStorageAccountKey is the key for the Azure storage account key in the export credentials Secret.
+	ArgoCDKeyAzureStorageAccountKey = "azure.storage.account.key"
+
+	// ArgoCDKeyGCPCredentials is the key for the GCP service account key file in the export credentials Secret.
+	ArgoCDKeyGCPCredentials = "gcp.credentials.json"
+
 	// ArgoCDKeyConfigManagementPlugins is the configuration key for config management plugins.
 	ArgoCDKeyConfigManagementPlugins = "configManagementPlugins"
 
@@ -108,6 +114,12 @@ const (
 	// ArgoCDKeySSHKnownHosts is the resource ssh_known_hosts key for labels.
 	ArgoCDKeySSHKnownHosts = "ssh_known_hosts"
 
+	// ArgoCDKeyStagedCACert is the key for a new CA certificate that is trusted but not yet used to sign certificates.
+	ArgoCDKeyStagedCACert = "staged.crt"
+
+	// ArgoCDKeyStagedCAKey is the key for the private key of the staged CA certificate.
+	ArgoCDKeyStagedCAKey = "staged.key"
+
 	// ArgoCDKeyStatusBadgeEnabled is the configuration key for enabling the status badge.
 	ArgoCDKeyStatusBadgeEnabled = "statusbadge.enabled"
 
@@ -126,6 +138,16 @@ const (
 
 	// ArgoCDDexSecretKey is used to reference Dex secret from Argo CD secret into Argo CD configmap
 	ArgoCDDexSecretKey = "oidc.dex.clientSecret"
+
+	// ArgoCDKeycloakSecretKey is used to reference the Keycloak client secret from Argo CD secret into Argo CD configmap
+	ArgoCDKeycloakSecretKey = "oidc.keycloak.clientSecret"
+
+	// ArgoCDOIDCClientSecretKey is the key in the Argo CD secret holding the client secret of the oidc SSO provider.
+	ArgoCDOIDCClientSecretKey = "oidc.clientSecret"
+
+	// ArgoCDDexConnectorSecretKeyPrefix is the prefix of the keys in the Argo CD secret holding the secrets referenced by
+	// the typed Dex connectors.
+	ArgoCDDexConnectorSecretKeyPrefix = "dex.connector."
 )
 
 // openshift.io keys
@@ -138,6 +160,18 @@ const (
 	ServiceBetaOpenshiftKeyCertSecret = "service.beta.openshift.io/serving-cert-secret-name"
 )
 
+// workload identity keys
+const (
+	// AzureWorkloadIdentityKeyClientID is the ServiceAccount annotation holding the client ID of the federated Azure managed identity.
+	AzureWorkloadIdentityKeyClientID = "azure.workload.identity/client-id"
+
+	// AzureWorkloadIdentityKeyUse is the Pod label that opts a Pod in to Azure AD Workload Identity.
+	AzureWorkloadIdentityKeyUse = "azure.workload.identity/use"
+
+	// GKEWorkloadIdentityKeyServiceAccount is the ServiceAccount annotation holding the email of the federated GCP service account.
+	GKEWorkloadIdentityKeyServiceAccount = "iam.gke.io/gcp-service-account"
+)
+
 // kubernetes.io keys
 const (
 	// AppK8sKeyName is the resource name key for labels.
@@ -196,6 +230,9 @@ const (
 	// ArgoCDDeletionFinalizer is a finalizer to implement pre-delete hooks
 	ArgoprojKeyFinalizer = "argoproj.io/finalizer"
 
+	// ArgoCDExportKeyObservedPhase is the annotation on export Jobs that records the last phase reported in Events and metrics.
+	ArgoCDExportKeyObservedPhase = "argocdexports.argoproj.io/observed-phase"
+
 	// ArgoCDArgoprojKeySecretType is needed for cluster secrets
 	ArgoCDArgoprojKeySecretType = "argocd.argoproj.io/secret-type"
 
@@ -204,4 +241,19 @@ const (
 
 	// ArgoCDArgoprojKeyManagedByClusterArgoCD is needed to identify namespace mentioned as sourceNamespace on ArgoCD
 	ArgoCDArgoprojKeyManagedByClusterArgoCD = "argocd.argoproj.io/managed-by-cluster-argocd"
+
+	// ArgoCDArgoprojKeyApplicationSetManagedByClusterArgoCD is needed to identify namespace mentioned as ApplicationSet sourceNamespace on ArgoCD
+	ArgoCDArgoprojKeyApplicationSetManagedByClusterArgoCD = "argocd.argoproj.io/applicationset-managed-by-cluster-argocd"
+
+	// ArgoCDArgoprojKeyDefaultProjectLocked is the annotation marking the default AppProject as locked down by the operator
+	ArgoCDArgoprojKeyDefaultProjectLocked = "argocd.argoproj.io/default-project-locked"
+
+	// ArgoCDArgoprojKeyManagedGPGKeys is the annotation listing the key IDs of the argocd-gpg-keys-cm ConfigMap managed by the operator
+	ArgoCDArgoprojKeyManagedGPGKeys = "argocd.argoproj.io/managed-gpg-keys"
+
+	// ArgoCDArgoprojKeyManagedNotificationsKeys is the annotation listing the keys of the notifications ConfigMap and Secret written by the operator
+	ArgoCDArgoprojKeyManagedNotificationsKeys = "argocd.argoproj.io/managed-notifications-keys"
+
+	// ArgoCDArgoprojKeyNotificationsManagedByClusterArgoCD is needed to identify namespace mentioned as notifications selfServiceNamespace on ArgoCD
+	ArgoCDArgoprojKeyNotificationsManagedByClusterArgoCD = "argocd.argoproj.io/notifications-managed-by-cluster-argocd"
 )
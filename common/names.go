@@ -11,6 +11,9 @@ const (
 	// ArgoCDGPGKeysConfigMapName is the upstream hard-coded ArgoCD gpg-keys ConfigMap name.
 	ArgoCDGPGKeysConfigMapName = "argocd-gpg-keys-cm"
 
+	// ArgoCDDefaultProjectName is the upstream hard-coded name of the default AppProject.
+	ArgoCDDefaultProjectName = "default"
+
 	// ArgoCDExportName is the export name for labels.
 	ArgoCDExportName = "argocd.export"
 
@@ -41,6 +44,9 @@ const (
 	// ArgoCDServerTLSSecretName is the name of the TLS secret for the argocd-server
 	ArgoCDServerTLSSecretName = "argocd-server-tls"
 
+	// ArgoCDAppSetWebhookTLSSecretName is the name of the TLS secret for the applicationset-controller webhook
+	ArgoCDAppSetWebhookTLSSecretName = "argocd-applicationset-controller-webhook-tls"
+
 	// ArgoCDOperatorName is the name of the operator that manages Argo CD instances and workloads
 	ArgoCDOperatorName = "argocd-operator"
 )
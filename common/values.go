@@ -18,9 +18,42 @@ import "time"
 
 // values
 const (
+	// ArgoCDConditionRBACPolicyValid is the condition type reporting whether the RBAC policy is valid.
+	ArgoCDConditionRBACPolicyValid = "RBACPolicyValid"
+
+	// ArgoCDConditionNotificationsConfigValid is the condition type reporting whether the typed notifications config is valid.
+	ArgoCDConditionNotificationsConfigValid = "NotificationsConfigValid"
+
+	// ArgoCDConditionCertificateProfileValid is the condition type reporting whether the certificate profile is valid.
+	ArgoCDConditionCertificateProfileValid = "CertificateProfileValid"
+
+	// ArgoCDConditionMutualTLSReady is the condition type reporting whether mutual TLS between the components is in effect.
+	ArgoCDConditionMutualTLSReady = "MutualTLSReady"
+
+	// ArgoCDConditionCertificatesReady is the condition type reporting whether cert-manager has issued all the TLS certificates.
+	ArgoCDConditionCertificatesReady = "CertificatesReady"
+
 	// ArgoCDDuration365Days is a duration representing 365 days.
 	ArgoCDDuration365Days = time.Hour * 24 * 365
 
+	// ArgoCDDuration30Days is a duration representing 30 days.
+	ArgoCDDuration30Days = time.Hour * 24 * 30
+
+	// ArgoCDExportKindApplications is the export kind for Applications.
+	ArgoCDExportKindApplications = "applications"
+
+	// ArgoCDExportKindApplicationSets is the export kind for ApplicationSets.
+	ArgoCDExportKindApplicationSets = "applicationsets"
+
+	// ArgoCDExportKindClusters is the export kind for cluster Secrets.
+	ArgoCDExportKindClusters = "clusters"
+
+	// ArgoCDExportKindRepositories is the export kind for repository and repository credential Secrets.
+	ArgoCDExportKindRepositories = "repositories"
+
+	// ArgoCDExportKindSettings is the export kind for the Argo CD ConfigMaps and the argocd-secret Secret.
+	ArgoCDExportKindSettings = "settings"
+
 	// ArgoCDExportStorageBackendAWS is the value for the AWS storage backend.
 	ArgoCDExportStorageBackendAWS = "aws"
 
@@ -72,6 +105,12 @@ const (
 	VolumeMountPathGPGKeyring    = "/app/config/gpg/keys"
 	VolumeTmp                    = "tmp"
 	VolumeMountPathTmp           = "/tmp"
+
+	// Mount paths of the mutual TLS client certificates, and of the CA Redis verifies them with.
+	VolumeMountPathServerClientTLS     = "/app/config/server/tls/redis-client"
+	VolumeMountPathControllerClientTLS = "/app/config/controller/tls/redis-client"
+	VolumeMountPathRepoServerClientTLS = "/app/config/reposerver/tls/redis-client"
+	VolumeMountPathRedisClientCA       = "/app/config/redis/ca"
 )
 
 // API group versions and resource kinds
@@ -91,5 +130,6 @@ const (
 
 // Commnds
 const (
-	LogLevel = "--loglevel"
+	LogLevel  = "--loglevel"
+	LogFormat = "--logformat"
 )
@@ -3,6 +3,7 @@ package applicationset
 import (
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/pkg/networking"
 	"github.com/argoproj-labs/argocd-operator/pkg/util"
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -11,10 +12,11 @@ import (
 )
 
 type ApplicationSetReconciler struct {
-	Client   client.Client
-	Scheme   *runtime.Scheme
-	Instance *argoproj.ArgoCD
-	Logger   logr.Logger
+	Client           client.Client
+	Scheme           *runtime.Scheme
+	Instance         *argoproj.ArgoCD
+	Logger           logr.Logger
+	SourceNamespaces map[string]string
 }
 
 var (
@@ -44,6 +46,11 @@ func (asr *ApplicationSetReconciler) Reconcile() error {
 		return err
 	}
 
+	if err := asr.reconcileSourceNamespaces(); err != nil {
+		asr.Logger.Info("reconciling applicationSet source namespaces")
+		return err
+	}
+
 	if asr.Instance.Spec.ApplicationSet.WebhookServer.Route.Enabled {
 		if err := asr.reconcileWebhookRoute(); err != nil {
 			asr.Logger.Info("reconciling applicationSet webhook route")
@@ -56,6 +63,32 @@ func (asr *ApplicationSetReconciler) Reconcile() error {
 		}
 	}
 
+	if asr.Instance.Spec.ApplicationSet.WebhookServer.Ingress.Enabled {
+		if err := asr.reconcileWebhookIngress(); err != nil {
+			asr.Logger.Info("reconciling applicationSet webhook ingress")
+			return err
+		}
+	} else {
+		if err := asr.deleteWebhookIngress(AppSetWebhookIngressName, asr.Instance.Namespace); err != nil {
+			asr.Logger.Error(err, "deleting applicationSet webhook ingress: failed to delete webhook ingress")
+			return err
+		}
+	}
+
+	if networking.IsGatewayRouteKindAvailable(networking.HTTPRouteKind) {
+		if asr.Instance.Spec.ApplicationSet.WebhookServer.Gateway.Enabled {
+			if err := asr.reconcileWebhookGatewayRoute(); err != nil {
+				asr.Logger.Info("reconciling applicationSet webhook gateway route")
+				return err
+			}
+		} else {
+			if err := asr.deleteWebhookGatewayRoutes(asr.Instance.Namespace); err != nil {
+				asr.Logger.Error(err, "deleting applicationSet webhook gateway route: failed to delete webhook gateway route")
+				return err
+			}
+		}
+	}
+
 	if err := asr.reconcileService(); err != nil {
 		asr.Logger.Info("reconciling applicationSet service")
 		return err
@@ -66,6 +99,11 @@ func (asr *ApplicationSetReconciler) Reconcile() error {
 		return err
 	}
 
+	if err := asr.reconcileServiceMonitor(); err != nil {
+		asr.Logger.Info("reconciling applicationSet serviceMonitor")
+		return err
+	}
+
 	return nil
 }
 
@@ -78,6 +116,11 @@ func (asr *ApplicationSetReconciler) DeleteResources() error {
 		deletionError = err
 	}
 
+	if err := asr.deleteServiceMonitor(getServiceMonitorName(), asr.Instance.Namespace); err != nil {
+		asr.Logger.Error(err, "DeleteResources: failed to delete serviceMonitor")
+		deletionError = err
+	}
+
 	if err := asr.deleteService(resourceName, asr.Instance.Namespace); err != nil {
 		asr.Logger.Error(err, "DeleteResources: failed to delete service")
 		deletionError = err
@@ -88,6 +131,23 @@ func (asr *ApplicationSetReconciler) DeleteResources() error {
 		deletionError = err
 	}
 
+	if err := asr.deleteWebhookIngress(AppSetWebhookIngressName, asr.Instance.Namespace); err != nil {
+		asr.Logger.Error(err, "DeleteResources: failed to delete webhook ingress")
+		deletionError = err
+	}
+
+	if networking.IsGatewayRouteKindAvailable(networking.HTTPRouteKind) {
+		if err := asr.deleteWebhookGatewayRoutes(asr.Instance.Namespace); err != nil {
+			asr.Logger.Error(err, "DeleteResources: failed to delete webhook gateway route")
+			deletionError = err
+		}
+	}
+
+	if err := asr.deleteSourceNamespaceResources(nil); err != nil {
+		asr.Logger.Error(err, "DeleteResources: failed to delete source namespace resources")
+		deletionError = err
+	}
+
 	if err := asr.deleteRoleBinding(resourceName, asr.Instance.Namespace); err != nil {
 		asr.Logger.Error(err, "DeleteResources: failed to delete roleBinding")
 		deletionError = err
@@ -9,6 +9,12 @@ const (
 	AppSetWebhookRouteName     = "applicationset-controller-webhook"
 
 	// Commands
-	EntryPointSh     = "entrypoint.sh"
-	ArgoCDRepoServer = "--argocd-repo-server"
+	EntryPointSh         = "entrypoint.sh"
+	ArgoCDRepoServer     = "--argocd-repo-server"
+	AppSetNamespaces     = "--applicationset-namespaces"
+	AllowedSCMProviders  = "--allowed-scm-providers"
+	ProgressiveSyncs     = "--enable-progressive-syncs"
+	Policy               = "--policy"
+	DryRun               = "--dry-run"
+	ConcurrentReconciles = "--concurrent-reconciliations"
 )
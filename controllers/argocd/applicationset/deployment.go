@@ -1,6 +1,8 @@
 package applicationset
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/argoproj-labs/argocd-operator/common"
@@ -185,11 +187,37 @@ func (asr *ApplicationSetReconciler) getArgoApplicationSetCommand() []string {
 	cmd = append(cmd, common.LogLevel)
 	cmd = append(cmd, util.GetLogLevel(asr.Instance.Spec.ApplicationSet.LogLevel))
 
-	// ApplicationSet command arguments provided by the user
-	extraArgs := asr.Instance.Spec.ApplicationSet.ExtraCommandArgs
-	err := util.IsMergable(extraArgs, cmd)
-	if err != nil {
-		return cmd
+	cmd = append(cmd, common.LogFormat)
+	cmd = append(cmd, util.GetLogFormat(asr.Instance.Spec.ApplicationSet.LogFormat))
+
+	if asr.Instance.Spec.ApplicationSet.EnableProgressiveSyncs {
+		cmd = append(cmd, ProgressiveSyncs)
+	}
+
+	if len(asr.Instance.Spec.ApplicationSet.Policy) > 0 {
+		cmd = append(cmd, Policy, asr.Instance.Spec.ApplicationSet.Policy)
+	}
+
+	if asr.Instance.Spec.ApplicationSet.DryRun {
+		cmd = append(cmd, DryRun)
+	}
+
+	if asr.Instance.Spec.ApplicationSet.ConcurrentReconciles != nil {
+		cmd = append(cmd, ConcurrentReconciles, fmt.Sprint(*asr.Instance.Spec.ApplicationSet.ConcurrentReconciles))
+	}
+
+	if namespaces := asr.getSourceNamespaces(); len(namespaces) > 0 {
+		cmd = append(cmd, AppSetNamespaces, strings.Join(namespaces, ","))
+	}
+
+	if len(asr.Instance.Spec.ApplicationSet.SCMProviders) > 0 {
+		cmd = append(cmd, AllowedSCMProviders, strings.Join(asr.Instance.Spec.ApplicationSet.SCMProviders, ","))
+	}
+
+	// ApplicationSet command arguments provided by the user, without the ones managed by the operator
+	extraArgs, conflictingArgs := filterExtraArgs(asr.Instance.Spec.ApplicationSet.ExtraCommandArgs, cmd)
+	if len(conflictingArgs) > 0 {
+		asr.Logger.V(0).Info("getArgoApplicationSetCommand: ignoring extra command arguments already managed by the operator", "args", conflictingArgs)
 	}
 
 	cmd = append(cmd, extraArgs...)
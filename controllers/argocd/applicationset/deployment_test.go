@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/controllers/argocd/argocdcommon"
 	"github.com/stretchr/testify/assert"
 
@@ -92,3 +93,83 @@ func TestApplicationSetReconciler_DeleteDeployment(t *testing.T) {
 		})
 	}
 }
+
+func TestApplicationSetReconciler_getArgoApplicationSetCommand(t *testing.T) {
+	resourceName = argocdcommon.TestArgoCDName
+	asr := makeTestApplicationSetReconciler(t, false)
+
+	cmd := asr.getArgoApplicationSetCommand()
+	assert.Contains(t, cmd, common.LogFormat)
+	for _, arg := range []string{ProgressiveSyncs, Policy, DryRun, ConcurrentReconciles} {
+		assert.NotContains(t, cmd, arg)
+	}
+
+	concurrentReconciles := int32(20)
+	asr.Instance.Spec.ApplicationSet.LogFormat = "json"
+	asr.Instance.Spec.ApplicationSet.EnableProgressiveSyncs = true
+	asr.Instance.Spec.ApplicationSet.Policy = "create-only"
+	asr.Instance.Spec.ApplicationSet.DryRun = true
+	asr.Instance.Spec.ApplicationSet.ConcurrentReconciles = &concurrentReconciles
+	asr.Instance.Spec.ApplicationSet.ExtraCommandArgs = []string{
+		"--policy", "sync",
+		"--dry-run",
+		"--logformat=text",
+		"--debug",
+	}
+
+	cmd = asr.getArgoApplicationSetCommand()
+	assert.Equal(t, []string{
+		EntryPointSh, AppSetController,
+		ArgoCDRepoServer, cmd[3],
+		common.LogLevel, "info",
+		common.LogFormat, "json",
+		ProgressiveSyncs,
+		Policy, "create-only",
+		DryRun,
+		ConcurrentReconciles, "20",
+		"--debug",
+	}, cmd)
+}
+
+func TestFilterExtraArgs(t *testing.T) {
+	cmd := []string{"--policy", "sync", "--dry-run"}
+
+	tests := []struct {
+		name        string
+		extraArgs   []string
+		wantArgs    []string
+		wantRemoved []string
+	}{
+		{
+			name:        "no conflicting arguments",
+			extraArgs:   []string{"--debug", "--foo", "bar"},
+			wantArgs:    []string{"--debug", "--foo", "bar"},
+			wantRemoved: []string{},
+		},
+		{
+			name:        "conflicting flag with separate value",
+			extraArgs:   []string{"--policy", "create-only", "--debug"},
+			wantArgs:    []string{"--debug"},
+			wantRemoved: []string{"--policy"},
+		},
+		{
+			name:        "conflicting flag with inline value",
+			extraArgs:   []string{"--policy=create-only", "--foo", "bar"},
+			wantArgs:    []string{"--foo", "bar"},
+			wantRemoved: []string{"--policy"},
+		},
+		{
+			name:        "conflicting boolean flag",
+			extraArgs:   []string{"--dry-run", "--debug"},
+			wantArgs:    []string{"--debug"},
+			wantRemoved: []string{"--dry-run"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, removed := filterExtraArgs(tt.extraArgs, cmd)
+			assert.Equal(t, tt.wantArgs, args)
+			assert.Equal(t, tt.wantRemoved, removed)
+		})
+	}
+}
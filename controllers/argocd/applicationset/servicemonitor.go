@@ -0,0 +1,127 @@
+package applicationset
+
+import (
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocd/argocdcommon"
+	"github.com/argoproj-labs/argocd-operator/pkg/cluster"
+	"github.com/argoproj-labs/argocd-operator/pkg/monitoring"
+	"github.com/argoproj-labs/argocd-operator/pkg/mutation"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// reconcileServiceMonitor ensures that the ServiceMonitor scraping the metrics port of the ApplicationSet controller
+// Service is present when Prometheus is enabled, and removed otherwise.
+func (asr *ApplicationSetReconciler) reconcileServiceMonitor() error {
+
+	if !monitoring.IsPrometheusAPIAvailable() {
+		asr.Logger.V(1).Info("reconcileServiceMonitor: prometheus API unavailable, skipping service monitor reconciliation")
+		return nil
+	}
+
+	asr.Logger.Info("reconciling serviceMonitor")
+
+	labels := util.MergeMaps(resourceLabels, map[string]string{
+		common.ArgoCDKeyRelease: "prometheus-operator",
+	})
+
+	serviceMonitorRequest := monitoring.ServiceMonitorRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        getServiceMonitorName(),
+			Namespace:   asr.Instance.Namespace,
+			Labels:      labels,
+			Annotations: asr.Instance.Annotations,
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					common.AppK8sKeyName: resourceName,
+				},
+			},
+			Endpoints: []monitoringv1.Endpoint{
+				{
+					Port: common.ArgoCDMetrics,
+				},
+			},
+		},
+		Client:    asr.Client,
+		Mutations: []mutation.MutateFunc{mutation.ApplyReconcilerMutation},
+	}
+
+	desiredServiceMonitor, err := monitoring.RequestServiceMonitor(serviceMonitorRequest)
+	if err != nil {
+		asr.Logger.Error(err, "reconcileServiceMonitor: failed to request serviceMonitor", "name", desiredServiceMonitor.Name, "namespace", desiredServiceMonitor.Namespace)
+		asr.Logger.V(1).Info("reconcileServiceMonitor: one or more mutations could not be applied")
+		return err
+	}
+
+	namespace, err := cluster.GetNamespace(asr.Instance.Namespace, asr.Client)
+	if err != nil {
+		asr.Logger.Error(err, "reconcileServiceMonitor: failed to retrieve namespace", "name", asr.Instance.Namespace)
+		return err
+	}
+	if namespace.DeletionTimestamp != nil || !asr.Instance.Spec.Prometheus.Enabled {
+		return asr.deleteServiceMonitor(desiredServiceMonitor.Name, desiredServiceMonitor.Namespace)
+	}
+
+	existingServiceMonitor, err := monitoring.GetServiceMonitor(desiredServiceMonitor.Name, desiredServiceMonitor.Namespace, asr.Client)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			asr.Logger.Error(err, "reconcileServiceMonitor: failed to retrieve serviceMonitor", "name", desiredServiceMonitor.Name, "namespace", desiredServiceMonitor.Namespace)
+			return err
+		}
+
+		if err = controllerutil.SetControllerReference(asr.Instance, desiredServiceMonitor, asr.Scheme); err != nil {
+			asr.Logger.Error(err, "reconcileServiceMonitor: failed to set owner reference for serviceMonitor", "name", desiredServiceMonitor.Name, "namespace", desiredServiceMonitor.Namespace)
+		}
+
+		if err = monitoring.CreateServiceMonitor(desiredServiceMonitor, asr.Client); err != nil {
+			asr.Logger.Error(err, "reconcileServiceMonitor: failed to create serviceMonitor", "name", desiredServiceMonitor.Name, "namespace", desiredServiceMonitor.Namespace)
+			return err
+		}
+		asr.Logger.V(0).Info("reconcileServiceMonitor: serviceMonitor created", "name", desiredServiceMonitor.Name, "namespace", desiredServiceMonitor.Namespace)
+		return nil
+	}
+
+	serviceMonitorChanged := false
+	fieldsToCompare := []struct {
+		existing, desired interface{}
+	}{
+		{&existingServiceMonitor.Spec, &desiredServiceMonitor.Spec},
+		{&existingServiceMonitor.Labels, &desiredServiceMonitor.Labels},
+	}
+
+	for _, field := range fieldsToCompare {
+		argocdcommon.UpdateIfChanged(field.existing, field.desired, nil, &serviceMonitorChanged)
+	}
+
+	if serviceMonitorChanged {
+		if err = monitoring.UpdateServiceMonitor(existingServiceMonitor, asr.Client); err != nil {
+			asr.Logger.Error(err, "reconcileServiceMonitor: failed to update serviceMonitor", "name", existingServiceMonitor.Name, "namespace", existingServiceMonitor.Namespace)
+			return err
+		}
+		asr.Logger.V(0).Info("reconcileServiceMonitor: serviceMonitor updated", "name", existingServiceMonitor.Name, "namespace", existingServiceMonitor.Namespace)
+	}
+
+	return nil
+}
+
+func (asr *ApplicationSetReconciler) deleteServiceMonitor(name, namespace string) error {
+	if !monitoring.IsPrometheusAPIAvailable() {
+		return nil
+	}
+	if err := monitoring.DeleteServiceMonitor(name, namespace, asr.Client); err != nil {
+		asr.Logger.Error(err, "DeleteServiceMonitor: failed to delete serviceMonitor", "name", name, "namespace", namespace)
+		return err
+	}
+	asr.Logger.V(0).Info("DeleteServiceMonitor: serviceMonitor deleted", "name", name, "namespace", namespace)
+	return nil
+}
+
+func getServiceMonitorName() string {
+	return util.NameWithSuffix(resourceName, common.ArgoCDMetrics)
+}
@@ -0,0 +1,32 @@
+package applicationset
+
+import (
+	"github.com/argoproj-labs/argocd-operator/controllers/argocd/argocdcommon"
+)
+
+// reconcileSourceNamespaces ensures that the ApplicationSet controller can manage ApplicationSets in each of its
+// source namespaces, and removes the Roles and RoleBindings of the namespaces no longer listed.
+func (asr *ApplicationSetReconciler) reconcileSourceNamespaces() error {
+
+	asr.Logger.Info("reconciling source namespaces")
+
+	return argocdcommon.ReconcileNamespacePermissions(asr.getSourceNamespacePermissionsRequest(), asr.getSourceNamespaces())
+}
+
+// deleteSourceNamespaceResources removes the Roles and RoleBindings of the ApplicationSet controller from the
+// namespaces other than the namespace of the ArgoCD and the given ones.
+func (asr *ApplicationSetReconciler) deleteSourceNamespaceResources(keep []string) error {
+	return argocdcommon.DeleteNamespacePermissions(asr.getSourceNamespacePermissionsRequest(), keep)
+}
+
+func (asr *ApplicationSetReconciler) getSourceNamespacePermissionsRequest() argocdcommon.NamespacePermissionsRequest {
+	return argocdcommon.NamespacePermissionsRequest{
+		Instance:  asr.Instance,
+		Name:      resourceName,
+		Component: AppSetControllerComponent,
+		Labels:    resourceLabels,
+		Rules:     getPolicyRules(),
+		Client:    asr.Client,
+		Logger:    asr.Logger,
+	}
+}
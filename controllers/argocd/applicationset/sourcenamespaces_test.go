@@ -0,0 +1,93 @@
+package applicationset
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argoproj-labs/argocd-operator/controllers/argocd/argocdcommon"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestApplicationSetReconciler_getArgoApplicationSetCommand_sourceNamespaces(t *testing.T) {
+	resourceName = argocdcommon.TestArgoCDName
+
+	tests := []struct {
+		name             string
+		sourceNamespaces map[string]string
+		scmProviders     []string
+		want             map[string]string
+		notWant          []string
+	}{
+		{
+			name:    "no source namespaces or scm providers",
+			notWant: []string{AppSetNamespaces, AllowedSCMProviders},
+		},
+		{
+			name:             "source namespaces and scm providers",
+			sourceNamespaces: map[string]string{"team-b": "", "team-a": "", argocdcommon.TestNamespace: ""},
+			scmProviders:     []string{"https://github.com/", "https://gitlab.example.com/"},
+			want: map[string]string{
+				AppSetNamespaces:    "team-a,team-b",
+				AllowedSCMProviders: "https://github.com/,https://gitlab.example.com/",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asr := makeTestApplicationSetReconciler(t, false)
+			asr.SourceNamespaces = tt.sourceNamespaces
+			asr.Instance.Spec.ApplicationSet.SCMProviders = tt.scmProviders
+
+			cmd := asr.getArgoApplicationSetCommand()
+			for i, arg := range cmd {
+				if value, ok := tt.want[arg]; ok {
+					assert.Equal(t, value, cmd[i+1])
+					delete(tt.want, arg)
+				}
+			}
+			assert.Empty(t, tt.want)
+			for _, arg := range tt.notWant {
+				assert.NotContains(t, cmd, arg)
+			}
+		})
+	}
+}
+
+func TestApplicationSetReconciler_reconcileSourceNamespaces(t *testing.T) {
+	resourceName = argocdcommon.TestArgoCDName
+	resourceLabels = testExpectedLabels
+	ns := argocdcommon.MakeTestNamespace()
+	teamA := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}
+	teamB := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}}
+
+	asr := makeTestApplicationSetReconciler(t, false, ns, teamA, teamB)
+	asr.SourceNamespaces = map[string]string{"team-a": "", "team-b": "", "missing": ""}
+
+	assert.NoError(t, asr.reconcileSourceNamespaces())
+	for _, namespace := range []string{"team-a", "team-b"} {
+		role := &rbacv1.Role{}
+		assert.NoError(t, asr.Client.Get(context.TODO(), types.NamespacedName{Name: resourceName, Namespace: namespace}, role))
+		assert.Equal(t, getPolicyRules(), role.Rules)
+
+		roleBinding := &rbacv1.RoleBinding{}
+		assert.NoError(t, asr.Client.Get(context.TODO(), types.NamespacedName{Name: resourceName, Namespace: namespace}, roleBinding))
+		assert.Equal(t, resourceName, roleBinding.RoleRef.Name)
+		assert.Equal(t, argocdcommon.TestNamespace, roleBinding.Subjects[0].Namespace)
+	}
+
+	// dropping a namespace removes its role and roleBinding
+	asr.SourceNamespaces = map[string]string{"team-a": ""}
+	assert.NoError(t, asr.reconcileSourceNamespaces())
+
+	assert.NoError(t, asr.Client.Get(context.TODO(), types.NamespacedName{Name: resourceName, Namespace: "team-a"}, &rbacv1.Role{}))
+	err := asr.Client.Get(context.TODO(), types.NamespacedName{Name: resourceName, Namespace: "team-b"}, &rbacv1.Role{})
+	assert.True(t, errors.IsNotFound(err))
+	err = asr.Client.Get(context.TODO(), types.NamespacedName{Name: resourceName, Namespace: "team-b"}, &rbacv1.RoleBinding{})
+	assert.True(t, errors.IsNotFound(err))
+}
@@ -1,6 +1,11 @@
 package applicationset
 
 import (
+	"sort"
+	"strings"
+
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -23,3 +28,43 @@ func (asr *ApplicationSetReconciler) getSCMRootCAConfigMapName() string {
 	}
 	return ""
 }
+
+// getSourceNamespaces returns the sorted namespaces, other than the namespace of the ArgoCD, the ApplicationSet
+// controller is allowed to source ApplicationSets from.
+func (asr *ApplicationSetReconciler) getSourceNamespaces() []string {
+	namespaces := []string{}
+	for namespace := range asr.SourceNamespaces {
+		if namespace != asr.Instance.Namespace {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// filterExtraArgs returns the given extra arguments without the flags, and their values, already part of the given
+// command, along with the flags it removed.
+func filterExtraArgs(extraArgs []string, cmd []string) ([]string, []string) {
+	filtered := []string{}
+	removed := []string{}
+	for i := 0; i < len(extraArgs); i++ {
+		arg := extraArgs[i]
+		if !strings.HasPrefix(arg, "--") {
+			filtered = append(filtered, arg)
+			continue
+		}
+
+		flag := strings.SplitN(arg, "=", 2)[0]
+		if !util.ContainsString(cmd, flag) {
+			filtered = append(filtered, arg)
+			continue
+		}
+
+		removed = append(removed, flag)
+		// skip the value of the flag, if given as a separate argument
+		if flag == arg && i+1 < len(extraArgs) && !strings.HasPrefix(extraArgs[i+1], "--") {
+			i++
+		}
+	}
+	return filtered, removed
+}
@@ -0,0 +1,208 @@
+package applicationset
+
+import (
+	"fmt"
+
+	"github.com/argoproj-labs/argocd-operator/pkg/cluster"
+	"github.com/argoproj-labs/argocd-operator/pkg/mutation"
+	"github.com/argoproj-labs/argocd-operator/pkg/networking"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// AppSetWebhookGatewayRouteName is the name of the HTTPRoute exposing the webhook server through a Gateway.
+	AppSetWebhookGatewayRouteName = AppSetWebhookRouteName
+
+	// AppSetWebhookGatewayRedirectRouteName is the name of the HTTPRoute redirecting webhook requests to the HTTPS listener.
+	AppSetWebhookGatewayRedirectRouteName = AppSetWebhookRouteName + "-redirect"
+
+	appSetWebhookPath = "/api/webhook"
+	appSetWebhookPort = 7000
+)
+
+func (asr *ApplicationSetReconciler) reconcileWebhookGatewayRoute() error {
+	asr.Logger.Info("reconciling webhook gateway routes")
+
+	gateway := asr.Instance.Spec.ApplicationSet.WebhookServer.Gateway
+	if len(gateway.ParentRefs) <= 0 {
+		err := fmt.Errorf(".spec.applicationSet.webhookServer.gateway.parentRefs must reference at least one Gateway")
+		asr.Logger.Error(err, "reconcileWebhookGatewayRoute: invalid gateway configuration")
+		return err
+	}
+	for _, parentRef := range gateway.ParentRefs {
+		if len(parentRef.Name) <= 0 {
+			err := fmt.Errorf(".spec.applicationSet.webhookServer.gateway.parentRefs must not contain a Gateway without a name")
+			asr.Logger.Error(err, "reconcileWebhookGatewayRoute: invalid gateway configuration")
+			return err
+		}
+	}
+
+	namespace, err := cluster.GetNamespace(asr.Instance.Namespace, asr.Client)
+	if err != nil {
+		asr.Logger.Error(err, "reconcileWebhookGatewayRoute: failed to retrieve namespace", "name", asr.Instance.Namespace)
+		return err
+	}
+	if namespace.DeletionTimestamp != nil {
+		return asr.deleteWebhookGatewayRoutes(asr.Instance.Namespace)
+	}
+
+	desiredRoutes, err := asr.getDesiredWebhookGatewayRoutes()
+	if err != nil {
+		asr.Logger.Error(err, "reconcileWebhookGatewayRoute: failed to request gateway routes")
+		return err
+	}
+
+	for _, desiredRoute := range desiredRoutes {
+		existingRoute, err := networking.GetGatewayRoute(networking.HTTPRouteKind, desiredRoute.GetName(), desiredRoute.GetNamespace(), asr.Client)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				asr.Logger.Error(err, "reconcileWebhookGatewayRoute: failed to retrieve gateway route", "name", desiredRoute.GetName(), "namespace", desiredRoute.GetNamespace())
+				return err
+			}
+
+			if err = controllerutil.SetControllerReference(asr.Instance, desiredRoute, asr.Scheme); err != nil {
+				asr.Logger.Error(err, "reconcileWebhookGatewayRoute: failed to set owner reference for gateway route", "name", desiredRoute.GetName(), "namespace", desiredRoute.GetNamespace())
+			}
+
+			if err = networking.CreateGatewayRoute(desiredRoute, asr.Client); err != nil {
+				asr.Logger.Error(err, "reconcileWebhookGatewayRoute: failed to create gateway route", "name", desiredRoute.GetName(), "namespace", desiredRoute.GetNamespace())
+				return err
+			}
+			asr.Logger.V(0).Info("reconcileWebhookGatewayRoute: gateway route created", "name", desiredRoute.GetName(), "namespace", desiredRoute.GetNamespace())
+			continue
+		}
+
+		if !networking.GatewayRouteNeedsUpdate(existingRoute, desiredRoute) {
+			continue
+		}
+
+		existingRoute.Object["spec"] = desiredRoute.Object["spec"]
+		existingRoute.SetLabels(desiredRoute.GetLabels())
+		existingRoute.SetAnnotations(desiredRoute.GetAnnotations())
+		if err = networking.UpdateGatewayRoute(existingRoute, asr.Client); err != nil {
+			asr.Logger.Error(err, "reconcileWebhookGatewayRoute: failed to update gateway route", "name", existingRoute.GetName(), "namespace", existingRoute.GetNamespace())
+			return err
+		}
+		asr.Logger.V(0).Info("reconcileWebhookGatewayRoute: gateway route updated", "name", existingRoute.GetName(), "namespace", existingRoute.GetNamespace())
+	}
+
+	// Remove the redirect route once HTTPS redirection is turned off.
+	if len(desiredRoutes) < 2 {
+		if err := networking.DeleteGatewayRoute(networking.HTTPRouteKind, AppSetWebhookGatewayRedirectRouteName, asr.Instance.Namespace, asr.Client); err != nil {
+			asr.Logger.Error(err, "reconcileWebhookGatewayRoute: failed to delete gateway route", "name", AppSetWebhookGatewayRedirectRouteName, "namespace", asr.Instance.Namespace)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (asr *ApplicationSetReconciler) deleteWebhookGatewayRoutes(namespace string) error {
+	for _, name := range []string{AppSetWebhookGatewayRouteName, AppSetWebhookGatewayRedirectRouteName} {
+		if err := networking.DeleteGatewayRoute(networking.HTTPRouteKind, name, namespace, asr.Client); err != nil {
+			asr.Logger.Error(err, "DeleteGatewayRoute: failed to delete gateway route", "name", name, "namespace", namespace)
+			return err
+		}
+		asr.Logger.V(0).Info("DeleteGatewayRoute: gateway route deleted", "name", name, "namespace", namespace)
+	}
+	return nil
+}
+
+func (asr *ApplicationSetReconciler) getWebhookGatewayParentRefs(sectionName string) []networking.GatewayParentReference {
+	gateway := asr.Instance.Spec.ApplicationSet.WebhookServer.Gateway
+	refs := make([]networking.GatewayParentReference, 0, len(gateway.ParentRefs))
+	for _, parentRef := range gateway.ParentRefs {
+		ref := networking.GatewayParentReference{
+			Name:        parentRef.Name,
+			Namespace:   parentRef.Namespace,
+			SectionName: parentRef.SectionName,
+			Port:        parentRef.Port,
+		}
+		if len(sectionName) > 0 {
+			ref.SectionName = sectionName
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+func (asr *ApplicationSetReconciler) getWebhookGatewayHostnames() []string {
+	webhookServer := asr.Instance.Spec.ApplicationSet.WebhookServer
+	if len(webhookServer.Gateway.Hostnames) > 0 {
+		return webhookServer.Gateway.Hostnames
+	}
+	if len(webhookServer.Host) > 0 {
+		return []string{webhookServer.Host}
+	}
+	return nil
+}
+
+func (asr *ApplicationSetReconciler) getWebhookGatewayRouteObjectMeta(name string) metav1.ObjectMeta {
+	gateway := asr.Instance.Spec.ApplicationSet.WebhookServer.Gateway
+
+	labels := map[string]string{}
+	for key, val := range resourceLabels {
+		labels[key] = val
+	}
+	// Allow override of the Labels for the HTTPRoute.
+	for key, val := range gateway.Labels {
+		labels[key] = val
+	}
+
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   asr.Instance.Namespace,
+		Labels:      labels,
+		Annotations: gateway.Annotations,
+	}
+}
+
+func (asr *ApplicationSetReconciler) getDesiredWebhookGatewayRoutes() ([]*unstructured.Unstructured, error) {
+	gateway := asr.Instance.Spec.ApplicationSet.WebhookServer.Gateway
+
+	path := gateway.Path
+	if len(path) <= 0 {
+		path = appSetWebhookPath
+	}
+
+	sectionName := ""
+	if gateway.TLS != nil {
+		sectionName = gateway.TLS.SectionName
+	}
+
+	desiredRoute, err := networking.RequestHTTPRoute(networking.HTTPRouteRequest{
+		ObjectMeta: asr.getWebhookGatewayRouteObjectMeta(AppSetWebhookGatewayRouteName),
+		ParentRefs: asr.getWebhookGatewayParentRefs(sectionName),
+		Hostnames:  asr.getWebhookGatewayHostnames(),
+		Path:       path,
+		Backend:    networking.GatewayBackendReference{Name: resourceName, Port: appSetWebhookPort},
+		Client:     asr.Client,
+		Mutations:  []mutation.MutateFunc{mutation.ApplyReconcilerMutation},
+	})
+	if err != nil {
+		return nil, err
+	}
+	routes := []*unstructured.Unstructured{desiredRoute}
+
+	if gateway.TLS != nil && len(gateway.TLS.RedirectSectionName) > 0 {
+		redirectRoute, err := networking.RequestHTTPRoute(networking.HTTPRouteRequest{
+			ObjectMeta:    asr.getWebhookGatewayRouteObjectMeta(AppSetWebhookGatewayRedirectRouteName),
+			ParentRefs:    asr.getWebhookGatewayParentRefs(gateway.TLS.RedirectSectionName),
+			Hostnames:     asr.getWebhookGatewayHostnames(),
+			Path:          path,
+			HTTPSRedirect: true,
+			Client:        asr.Client,
+			Mutations:     []mutation.MutateFunc{mutation.ApplyReconcilerMutation},
+		})
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, redirectRoute)
+	}
+
+	return routes, nil
+}
@@ -0,0 +1,67 @@
+package applicationset
+
+import (
+	"testing"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocd/argocdcommon"
+	"github.com/argoproj-labs/argocd-operator/pkg/networking"
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestApplicationSetReconciler_reconcileWebhookGatewayRoute(t *testing.T) {
+	resourceName = argocdcommon.TestArgoCDName
+	resourceLabels = testExpectedLabels
+	ns := argocdcommon.MakeTestNamespace()
+	asr := makeTestApplicationSetReconciler(t, false, ns)
+	asr.Instance.Spec.ApplicationSet.WebhookServer.Gateway = argoproj.ArgoCDGatewaySpec{
+		Enabled:    true,
+		ParentRefs: []argoproj.ArgoCDGatewayParentReference{{Name: "gateway"}},
+		TLS: &argoproj.ArgoCDGatewayTLSSpec{
+			SectionName:         "https",
+			RedirectSectionName: "http",
+		},
+	}
+
+	assert.NoError(t, asr.reconcileWebhookGatewayRoute())
+
+	route, err := networking.GetGatewayRoute(networking.HTTPRouteKind, AppSetWebhookGatewayRouteName, argocdcommon.TestNamespace, asr.Client)
+	assert.NoError(t, err)
+	assert.Equal(t, testExpectedLabels, route.GetLabels())
+
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"matches": []interface{}{
+				map[string]interface{}{"path": map[string]interface{}{"type": "PathPrefix", "value": "/api/webhook"}},
+			},
+			"backendRefs": []interface{}{
+				map[string]interface{}{"name": argocdcommon.TestArgoCDName, "port": int64(7000)},
+			},
+		},
+	}, rules)
+
+	_, err = networking.GetGatewayRoute(networking.HTTPRouteKind, AppSetWebhookGatewayRedirectRouteName, argocdcommon.TestNamespace, asr.Client)
+	assert.NoError(t, err)
+
+	// turning off the redirect removes the redirect route
+	asr.Instance.Spec.ApplicationSet.WebhookServer.Gateway.TLS = nil
+	assert.NoError(t, asr.reconcileWebhookGatewayRoute())
+	_, err = networking.GetGatewayRoute(networking.HTTPRouteKind, AppSetWebhookGatewayRedirectRouteName, argocdcommon.TestNamespace, asr.Client)
+	assert.True(t, errors.IsNotFound(err))
+
+	assert.NoError(t, asr.deleteWebhookGatewayRoutes(argocdcommon.TestNamespace))
+	_, err = networking.GetGatewayRoute(networking.HTTPRouteKind, AppSetWebhookGatewayRouteName, argocdcommon.TestNamespace, asr.Client)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestApplicationSetReconciler_reconcileWebhookGatewayRoute_invalidSpec(t *testing.T) {
+	ns := argocdcommon.MakeTestNamespace()
+	asr := makeTestApplicationSetReconciler(t, false, ns)
+	asr.Instance.Spec.ApplicationSet.WebhookServer.Gateway = argoproj.ArgoCDGatewaySpec{Enabled: true}
+
+	assert.Error(t, asr.reconcileWebhookGatewayRoute())
+}
@@ -0,0 +1,177 @@
+package applicationset
+
+import (
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocd/argocdcommon"
+	"github.com/argoproj-labs/argocd-operator/pkg/certmanager"
+	"github.com/argoproj-labs/argocd-operator/pkg/cluster"
+	"github.com/argoproj-labs/argocd-operator/pkg/mutation"
+	"github.com/argoproj-labs/argocd-operator/pkg/networking"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// AppSetWebhookIngressName is the name of the Ingress exposing the webhook server.
+	AppSetWebhookIngressName = AppSetWebhookRouteName
+)
+
+func (asr *ApplicationSetReconciler) reconcileWebhookIngress() error {
+	asr.Logger.Info("reconciling webhook ingress")
+
+	ingressRequest := networking.IngressRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        AppSetWebhookIngressName,
+			Namespace:   asr.Instance.Namespace,
+			Labels:      resourceLabels,
+			Annotations: asr.getWebhookIngressAnnotations(),
+		},
+		Spec:      asr.getWebhookIngressSpec(),
+		Client:    asr.Client,
+		Mutations: []mutation.MutateFunc{mutation.ApplyReconcilerMutation},
+	}
+
+	desiredIngress, err := networking.RequestIngress(ingressRequest)
+	if err != nil {
+		asr.Logger.Error(err, "reconcileWebhookIngress: failed to request ingress", "name", desiredIngress.Name, "namespace", desiredIngress.Namespace)
+		asr.Logger.V(1).Info("reconcileWebhookIngress: one or more mutations could not be applied")
+		return err
+	}
+
+	namespace, err := cluster.GetNamespace(asr.Instance.Namespace, asr.Client)
+	if err != nil {
+		asr.Logger.Error(err, "reconcileWebhookIngress: failed to retrieve namespace", "name", asr.Instance.Namespace)
+		return err
+	}
+	if namespace.DeletionTimestamp != nil {
+		if err := asr.deleteWebhookIngress(desiredIngress.Name, desiredIngress.Namespace); err != nil {
+			asr.Logger.Error(err, "reconcileWebhookIngress: failed to delete ingress", "name", desiredIngress.Name, "namespace", desiredIngress.Namespace)
+		}
+		return err
+	}
+
+	existingIngress, err := networking.GetIngress(desiredIngress.Name, desiredIngress.Namespace, asr.Client)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			asr.Logger.Error(err, "reconcileWebhookIngress: failed to retrieve ingress", "name", desiredIngress.Name, "namespace", desiredIngress.Namespace)
+			return err
+		}
+
+		if err = controllerutil.SetControllerReference(asr.Instance, desiredIngress, asr.Scheme); err != nil {
+			asr.Logger.Error(err, "reconcileWebhookIngress: failed to set owner reference for ingress", "name", desiredIngress.Name, "namespace", desiredIngress.Namespace)
+		}
+
+		if err = networking.CreateIngress(desiredIngress, asr.Client); err != nil {
+			asr.Logger.Error(err, "reconcileWebhookIngress: failed to create ingress", "name", desiredIngress.Name, "namespace", desiredIngress.Namespace)
+			return err
+		}
+		asr.Logger.V(0).Info("reconcileWebhookIngress: ingress created", "name", desiredIngress.Name, "namespace", desiredIngress.Namespace)
+		return nil
+	}
+
+	ingressChanged := false
+	fieldsToCompare := []struct {
+		existing, desired interface{}
+	}{
+		{&existingIngress.Annotations, &desiredIngress.Annotations},
+		{&existingIngress.Labels, &desiredIngress.Labels},
+		{&existingIngress.Spec.IngressClassName, &desiredIngress.Spec.IngressClassName},
+		{&existingIngress.Spec.Rules, &desiredIngress.Spec.Rules},
+		{&existingIngress.Spec.TLS, &desiredIngress.Spec.TLS},
+	}
+
+	for _, field := range fieldsToCompare {
+		argocdcommon.UpdateIfChanged(field.existing, field.desired, nil, &ingressChanged)
+	}
+
+	if ingressChanged {
+		if err = networking.UpdateIngress(existingIngress, asr.Client); err != nil {
+			asr.Logger.Error(err, "reconcileWebhookIngress: failed to update ingress", "name", existingIngress.Name, "namespace", existingIngress.Namespace)
+			return err
+		}
+		asr.Logger.V(0).Info("reconcileWebhookIngress: ingress updated", "name", existingIngress.Name, "namespace", existingIngress.Namespace)
+	}
+
+	return nil
+}
+
+func (asr *ApplicationSetReconciler) deleteWebhookIngress(name, namespace string) error {
+	if err := networking.DeleteIngress(name, namespace, asr.Client); err != nil {
+		asr.Logger.Error(err, "DeleteIngress: failed to delete ingress", "name", name, "namespace", namespace)
+		return err
+	}
+	asr.Logger.V(0).Info("DeleteIngress: ingress deleted", "name", name, "namespace", namespace)
+	return nil
+}
+
+// getWebhookIngressAnnotations returns the annotations of the webhook Ingress, defaulting to the ones forcing the
+// ingress-nginx controller to redirect to HTTPS.
+func (asr *ApplicationSetReconciler) getWebhookIngressAnnotations() map[string]string {
+	ingress := asr.Instance.Spec.ApplicationSet.WebhookServer.Ingress
+	if len(ingress.Annotations) > 0 {
+		return ingress.Annotations
+	}
+	return map[string]string{
+		common.NginxIngressK8sKeyForceSSLRedirect: "true",
+		common.NginxIngressK8sKeyBackendProtocol:  "HTTP",
+	}
+}
+
+func (asr *ApplicationSetReconciler) getWebhookIngressSpec() networkingv1.IngressSpec {
+	webhookServer := asr.Instance.Spec.ApplicationSet.WebhookServer
+
+	path := webhookServer.Ingress.Path
+	if len(path) <= 0 {
+		path = appSetWebhookPath
+	}
+	pathType := networkingv1.PathTypeImplementationSpecific
+
+	return networkingv1.IngressSpec{
+		IngressClassName: webhookServer.Ingress.IngressClassName,
+		Rules: []networkingv1.IngressRule{
+			{
+				Host: webhookServer.Host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{
+							{
+								Path:     path,
+								PathType: &pathType,
+								Backend: networkingv1.IngressBackend{
+									Service: &networkingv1.IngressServiceBackend{
+										Name: resourceName,
+										Port: networkingv1.ServiceBackendPort{
+											Name: common.Webhook,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		TLS: asr.getWebhookIngressTLS(),
+	}
+}
+
+// getWebhookIngressTLS returns the TLS options of the webhook Ingress. When the TLS certificates of the ArgoCD are
+// issued by cert-manager and none are set, the webhook certificate issued for the webhook host is used.
+func (asr *ApplicationSetReconciler) getWebhookIngressTLS() []networkingv1.IngressTLS {
+	webhookServer := asr.Instance.Spec.ApplicationSet.WebhookServer
+	if len(webhookServer.Ingress.TLS) > 0 {
+		return webhookServer.Ingress.TLS
+	}
+	if asr.Instance.Spec.TLS.IssuerRef == nil || !certmanager.IsCertManagerAPIAvailable() || len(webhookServer.Host) <= 0 {
+		return nil
+	}
+	return []networkingv1.IngressTLS{
+		{
+			Hosts:      []string{webhookServer.Host},
+			SecretName: common.ArgoCDAppSetWebhookTLSSecretName,
+		},
+	}
+}
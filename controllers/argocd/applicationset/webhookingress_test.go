@@ -0,0 +1,137 @@
+package applicationset
+
+import (
+	"context"
+	"testing"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocd/argocdcommon"
+	"github.com/argoproj-labs/argocd-operator/pkg/certmanager"
+	"github.com/stretchr/testify/assert"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestApplicationSetReconciler_reconcileWebhookIngress(t *testing.T) {
+	resourceName = argocdcommon.TestArgoCDName
+	resourceLabels = testExpectedLabels
+	ns := argocdcommon.MakeTestNamespace()
+
+	outdatedIngress := &networkingv1.Ingress{}
+	outdatedIngress.Name = AppSetWebhookIngressName
+	outdatedIngress.Namespace = argocdcommon.TestNamespace
+	outdatedIngress.Labels = argocdcommon.TestKVP
+
+	tests := []struct {
+		name        string
+		setupClient func() *ApplicationSetReconciler
+		wantErr     bool
+	}{
+		{
+			name: "create a webhook ingress",
+			setupClient: func() *ApplicationSetReconciler {
+				return makeTestApplicationSetReconciler(t, false, ns)
+			},
+			wantErr: false,
+		},
+		{
+			name: "update a webhook ingress",
+			setupClient: func() *ApplicationSetReconciler {
+				return makeTestApplicationSetReconciler(t, false, outdatedIngress, ns)
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asr := tt.setupClient()
+			webhookServer := &asr.Instance.Spec.ApplicationSet.WebhookServer
+			webhookServer.Host = "webhook.example.com"
+			webhookServer.Ingress.Enabled = true
+			ingressClassName := "nginx"
+			webhookServer.Ingress.IngressClassName = &ingressClassName
+			webhookServer.Ingress.Annotations = argocdcommon.TestKVP
+			webhookServer.Ingress.TLS = []networkingv1.IngressTLS{
+				{Hosts: []string{"webhook.example.com"}, SecretName: "webhook-tls"},
+			}
+
+			err := asr.reconcileWebhookIngress()
+			if (err != nil) != tt.wantErr {
+				if tt.wantErr {
+					t.Errorf("Expected error but did not get one")
+				} else {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			}
+
+			ingress := &networkingv1.Ingress{}
+			err = asr.Client.Get(context.TODO(), types.NamespacedName{Name: AppSetWebhookIngressName, Namespace: argocdcommon.TestNamespace}, ingress)
+			if err != nil {
+				t.Fatalf("Could not get updated Ingress: %v", err)
+			}
+			assert.Equal(t, testExpectedLabels, ingress.Labels)
+			assert.Equal(t, argocdcommon.TestKVP, ingress.Annotations)
+			assert.Equal(t, "nginx", *ingress.Spec.IngressClassName)
+			assert.Equal(t, webhookServer.Ingress.TLS, ingress.Spec.TLS)
+			assert.Equal(t, "webhook.example.com", ingress.Spec.Rules[0].Host)
+
+			path := ingress.Spec.Rules[0].HTTP.Paths[0]
+			assert.Equal(t, appSetWebhookPath, path.Path)
+			assert.Equal(t, resourceName, path.Backend.Service.Name)
+			assert.Equal(t, common.Webhook, path.Backend.Service.Port.Name)
+		})
+	}
+}
+
+func TestApplicationSetReconciler_getWebhookIngressTLS(t *testing.T) {
+	ns := argocdcommon.MakeTestNamespace()
+	asr := makeTestApplicationSetReconciler(t, false, ns)
+	asr.Instance.Spec.ApplicationSet.WebhookServer.Host = "webhook.example.com"
+
+	// without cert-manager there is no default
+	assert.Nil(t, asr.getWebhookIngressTLS())
+
+	certmanager.SetCertManagerAPIFound(true)
+	defer certmanager.SetCertManagerAPIFound(false)
+	asr.Instance.Spec.TLS.IssuerRef = &argoproj.ArgoCDIssuerReference{Name: "my-issuer"}
+
+	// the certificate issued for the webhook is used by default
+	assert.Equal(t, []networkingv1.IngressTLS{
+		{Hosts: []string{"webhook.example.com"}, SecretName: common.ArgoCDAppSetWebhookTLSSecretName},
+	}, asr.getWebhookIngressTLS())
+
+	// explicit TLS options take precedence
+	tls := []networkingv1.IngressTLS{{Hosts: []string{"webhook.example.com"}, SecretName: "webhook-tls"}}
+	asr.Instance.Spec.ApplicationSet.WebhookServer.Ingress.TLS = tls
+	assert.Equal(t, tls, asr.getWebhookIngressTLS())
+}
+
+func TestApplicationSetReconciler_getWebhookIngressAnnotations(t *testing.T) {
+	asr := makeTestApplicationSetReconciler(t, false)
+	assert.Equal(t, map[string]string{
+		common.NginxIngressK8sKeyForceSSLRedirect: "true",
+		common.NginxIngressK8sKeyBackendProtocol:  "HTTP",
+	}, asr.getWebhookIngressAnnotations())
+
+	asr.Instance.Spec.ApplicationSet.WebhookServer.Ingress.Annotations = argocdcommon.TestKVP
+	assert.Equal(t, argocdcommon.TestKVP, asr.getWebhookIngressAnnotations())
+}
+
+func TestApplicationSetReconciler_deleteWebhookIngress(t *testing.T) {
+	ns := argocdcommon.MakeTestNamespace()
+	existingIngress := &networkingv1.Ingress{}
+	existingIngress.Name = AppSetWebhookIngressName
+	existingIngress.Namespace = argocdcommon.TestNamespace
+
+	asr := makeTestApplicationSetReconciler(t, false, existingIngress, ns)
+	assert.NoError(t, asr.deleteWebhookIngress(AppSetWebhookIngressName, argocdcommon.TestNamespace))
+
+	err := asr.Client.Get(context.TODO(), types.NamespacedName{Name: AppSetWebhookIngressName, Namespace: argocdcommon.TestNamespace}, &networkingv1.Ingress{})
+	assert.True(t, errors.IsNotFound(err))
+
+	// deleting a missing ingress is not an error
+	assert.NoError(t, asr.deleteWebhookIngress(AppSetWebhookIngressName, argocdcommon.TestNamespace))
+}
@@ -123,8 +123,8 @@ func (asr *ApplicationSetReconciler) getWebhookRouteSpec() routev1.RouteSpec {
 	}
 
 	// Allow override of the WildcardPolicy for the Route
-	if asr.Instance.Spec.Server.Route.WildcardPolicy != nil && len(*asr.Instance.Spec.Server.Route.WildcardPolicy) > 0 {
-		routeSpec.WildcardPolicy = *asr.Instance.Spec.Server.Route.WildcardPolicy
+	if route := asr.Instance.Spec.ApplicationSet.WebhookServer.Route; route.WildcardPolicy != nil && len(*route.WildcardPolicy) > 0 {
+		routeSpec.WildcardPolicy = *route.WildcardPolicy
 	}
 
 	return routeSpec
@@ -151,18 +151,16 @@ func (asr *ApplicationSetReconciler) getDesiredWebhookRoute() *routev1.Route {
 		Spec: asr.getWebhookRouteSpec(),
 	}
 
+	route := asr.Instance.Spec.ApplicationSet.WebhookServer.Route
+
 	// Allow override of the Annotations for the Route.
-	if len(asr.Instance.Spec.ApplicationSet.WebhookServer.Route.Annotations) > 0 {
-		desiredWebhook.ObjectMeta.Annotations = asr.Instance.Spec.Server.Route.Annotations
+	if len(route.Annotations) > 0 {
+		desiredWebhook.ObjectMeta.Annotations = route.Annotations
 	}
 
 	// Allow override of the Labels for the Route.
-	if len(asr.Instance.Spec.ApplicationSet.WebhookServer.Route.Labels) > 0 {
-		labels := desiredWebhook.ObjectMeta.Labels
-		for key, val := range asr.Instance.Spec.Server.Route.Labels {
-			labels[key] = val
-		}
-		desiredWebhook.ObjectMeta.Labels = labels
+	if len(route.Labels) > 0 {
+		desiredWebhook.ObjectMeta.Labels = util.MergeMaps(resourceLabels, route.Labels)
 	}
 
 	return desiredWebhook
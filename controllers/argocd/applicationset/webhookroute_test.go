@@ -135,3 +135,18 @@ func TestApplicationSetReconciler_deleteWebhookRoute(t *testing.T) {
 		})
 	}
 }
+
+func TestApplicationSetReconciler_getDesiredWebhookRoute_overrides(t *testing.T) {
+	resourceLabels = testExpectedLabels
+	asr := makeTestApplicationSetReconciler(t, true)
+	asr.Instance.Spec.Server.Route.Annotations = map[string]string{"server": "annotation"}
+	asr.Instance.Spec.Server.Route.Labels = map[string]string{"server": "label"}
+	asr.Instance.Spec.ApplicationSet.WebhookServer.Route.Annotations = argocdcommon.TestKVP
+	asr.Instance.Spec.ApplicationSet.WebhookServer.Route.Labels = argocdcommon.TestKVP
+
+	route := asr.getDesiredWebhookRoute()
+	assert.Equal(t, argocdcommon.TestKVP, route.Annotations)
+	assert.Equal(t, argocdcommon.TestVal, route.Labels[argocdcommon.TestKey])
+	assert.NotContains(t, route.Labels, "server")
+	assert.NotContains(t, resourceLabels, argocdcommon.TestKey)
+}
@@ -0,0 +1,165 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+)
+
+// appProjectGVK is the GroupVersionKind of the Argo CD AppProject.
+var appProjectGVK = schema.GroupVersionKind{
+	Group:   "argoproj.io",
+	Version: "v1alpha1",
+	Kind:    "AppProject",
+}
+
+// newAppProject returns a new, empty AppProject with the given name for the given ArgoCD.
+func newAppProject(name string, cr *argoproj.ArgoCD) *unstructured.Unstructured {
+	project := &unstructured.Unstructured{}
+	project.SetGroupVersionKind(appProjectGVK)
+	project.SetName(name)
+	project.SetNamespace(cr.Namespace)
+	return project
+}
+
+// getDefaultProjectBaseSpec returns the spec of the default project as created by Argo CD, which permits everything.
+func getDefaultProjectBaseSpec() map[string]interface{} {
+	return map[string]interface{}{
+		"sourceRepos": []interface{}{"*"},
+		"destinations": []interface{}{
+			map[string]interface{}{"server": "*", "namespace": "*"},
+		},
+		"clusterResourceWhitelist": []interface{}{
+			map[string]interface{}{"group": "*", "kind": "*"},
+		},
+	}
+}
+
+// getDefaultProjectSpec returns the fields of the default project spec that are managed for the given ArgoCD. Fields
+// that are not returned are left as is.
+func getDefaultProjectSpec(cr *argoproj.ArgoCD) map[string]interface{} {
+	spec := map[string]interface{}{}
+	if cr.Spec.DefaultProject == nil {
+		return spec
+	}
+
+	if cr.Spec.DefaultProject.Locked {
+		spec["sourceRepos"] = []interface{}{}
+		spec["destinations"] = []interface{}{}
+		spec["clusterResourceWhitelist"] = []interface{}{}
+		return spec
+	}
+
+	if cr.Spec.DefaultProject.SourceRepos != nil {
+		repos := make([]interface{}, 0, len(cr.Spec.DefaultProject.SourceRepos))
+		for _, repo := range cr.Spec.DefaultProject.SourceRepos {
+			repos = append(repos, repo)
+		}
+		spec["sourceRepos"] = repos
+	}
+
+	if cr.Spec.DefaultProject.Destinations != nil {
+		destinations := make([]interface{}, 0, len(cr.Spec.DefaultProject.Destinations))
+		for _, dest := range cr.Spec.DefaultProject.Destinations {
+			destination := map[string]interface{}{}
+			setIfNotEmpty(destination, "server", dest.Server)
+			setIfNotEmpty(destination, "name", dest.Name)
+			setIfNotEmpty(destination, "namespace", dest.Namespace)
+			destinations = append(destinations, destination)
+		}
+		spec["destinations"] = destinations
+	}
+
+	if cr.Spec.DefaultProject.ClusterResourceWhitelist != nil {
+		spec["clusterResourceWhitelist"] = getGroupKinds(cr.Spec.DefaultProject.ClusterResourceWhitelist)
+	}
+
+	if cr.Spec.DefaultProject.ClusterResourceBlacklist != nil {
+		spec["clusterResourceBlacklist"] = getGroupKinds(cr.Spec.DefaultProject.ClusterResourceBlacklist)
+	}
+	return spec
+}
+
+// getGroupKinds returns the given group kinds as unstructured values.
+func getGroupKinds(groupKinds []metav1.GroupKind) []interface{} {
+	values := make([]interface{}, 0, len(groupKinds))
+	for _, gk := range groupKinds {
+		values = append(values, map[string]interface{}{"group": gk.Group, "kind": gk.Kind})
+	}
+	return values
+}
+
+// reconcileDefaultProject will ensure that the default AppProject matches the restrictions configured for the given
+// ArgoCD. Only the configured fields are merged into the project, fields added by users are preserved. A project
+// locked down by the operator is annotated, so that the fields cleared by the lock are restored once it is unlocked.
+func (r *ArgoCDReconciler) reconcileDefaultProject(cr *argoproj.ArgoCD) error {
+	locked := cr.Spec.DefaultProject != nil && cr.Spec.DefaultProject.Locked
+	desired := getDefaultProjectSpec(cr)
+	project := newAppProject(common.ArgoCDDefaultProjectName, cr)
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: project.GetName(), Namespace: project.GetNamespace()}, project); err != nil {
+		if cr.Spec.DefaultProject == nil {
+			return nil // Default project not managed, leave it to Argo CD.
+		}
+		if !errors.IsNotFound(err) {
+			return err
+		}
+
+		spec := getDefaultProjectBaseSpec()
+		for key, val := range desired {
+			spec[key] = val
+		}
+		project.Object["spec"] = spec
+		if locked {
+			project.SetAnnotations(map[string]string{common.ArgoCDArgoprojKeyDefaultProjectLocked: "true"})
+		}
+		log.Info(fmt.Sprintf("creating AppProject %s for ArgoCD %s in namespace %s", project.GetName(), cr.Name, cr.Namespace))
+		return r.Client.Create(context.TODO(), project)
+	}
+
+	annotations := project.GetAnnotations()
+	wasLocked := annotations[common.ArgoCDArgoprojKeyDefaultProjectLocked] == "true"
+	if cr.Spec.DefaultProject == nil && !wasLocked {
+		return nil // Default project not managed, leave it to Argo CD.
+	}
+	if wasLocked && !locked {
+		// Restore the fields cleared by the lock that are not configured otherwise.
+		for key, val := range getDefaultProjectBaseSpec() {
+			if _, ok := desired[key]; !ok {
+				desired[key] = val
+			}
+		}
+	}
+
+	spec, ok := project.Object["spec"].(map[string]interface{})
+	if !ok {
+		spec = map[string]interface{}{}
+	}
+	if wasLocked == locked && util.IsUnstructuredSubset(desired, spec) {
+		return nil // AppProject found with nothing changed, move along...
+	}
+
+	for key, val := range desired {
+		spec[key] = val
+	}
+	project.Object["spec"] = spec
+	if locked {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[common.ArgoCDArgoprojKeyDefaultProjectLocked] = "true"
+	} else {
+		delete(annotations, common.ArgoCDArgoprojKeyDefaultProjectLocked)
+	}
+	project.SetAnnotations(annotations)
+	log.Info(fmt.Sprintf("updating AppProject %s for ArgoCD %s in namespace %s", project.GetName(), cr.Name, cr.Namespace))
+	return r.Client.Update(context.TODO(), project)
+}
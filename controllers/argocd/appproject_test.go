@@ -0,0 +1,147 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func getTestDefaultProject(t *testing.T, r *ArgoCDReconciler, cr *argoproj.ArgoCD) map[string]interface{} {
+	project := newAppProject(common.ArgoCDDefaultProjectName, cr)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: project.GetName(), Namespace: cr.Namespace}, project))
+	spec, _ := project.Object["spec"].(map[string]interface{})
+	return spec
+}
+
+func TestReconcileDefaultProject_notManaged(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileDefaultProject(a))
+
+	project := newAppProject(common.ArgoCDDefaultProjectName, a)
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: project.GetName(), Namespace: a.Namespace}, project)
+	assert.Error(t, err)
+}
+
+func TestReconcileDefaultProject_create(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.DefaultProject = &argoproj.ArgoCDDefaultProjectSpec{
+			SourceRepos: []string{"https://github.com/example/*"},
+			ClusterResourceBlacklist: []metav1.GroupKind{
+				{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileDefaultProject(a))
+
+	spec := getTestDefaultProject(t, r, a)
+	assert.Equal(t, []interface{}{"https://github.com/example/*"}, spec["sourceRepos"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"server": "*", "namespace": "*"}}, spec["destinations"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"group": "*", "kind": "*"}}, spec["clusterResourceWhitelist"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"group": "rbac.authorization.k8s.io", "kind": "ClusterRoleBinding"}}, spec["clusterResourceBlacklist"])
+}
+
+func TestReconcileDefaultProject_mergePreservesUserFields(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.DefaultProject = &argoproj.ArgoCDDefaultProjectSpec{
+			Destinations: []argoproj.ArgoCDProjectDestination{
+				{Server: "https://kubernetes.default.svc", Namespace: "team-*"},
+			},
+		}
+	})
+
+	existing := newAppProject(common.ArgoCDDefaultProjectName, a)
+	existing.Object["spec"] = map[string]interface{}{
+		"description": "managed by the platform team",
+		"sourceRepos": []interface{}{"*"},
+		"destinations": []interface{}{
+			map[string]interface{}{"server": "*", "namespace": "*"},
+		},
+		"roles": []interface{}{
+			map[string]interface{}{"name": "ci", "policies": []interface{}{"p, proj:default:ci, applications, sync, default/*, allow"}},
+		},
+	}
+	r := makeTestReconciler(t, a, existing)
+
+	assert.NoError(t, r.reconcileDefaultProject(a))
+
+	spec := getTestDefaultProject(t, r, a)
+	assert.Equal(t, []interface{}{map[string]interface{}{"server": "https://kubernetes.default.svc", "namespace": "team-*"}}, spec["destinations"])
+	assert.Equal(t, "managed by the platform team", spec["description"])
+	assert.Equal(t, []interface{}{"*"}, spec["sourceRepos"])
+	assert.Len(t, spec["roles"], 1)
+}
+
+func TestReconcileDefaultProject_locked(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.DefaultProject = &argoproj.ArgoCDDefaultProjectSpec{
+			Locked:      true,
+			SourceRepos: []string{"*"},
+		}
+	})
+
+	existing := newAppProject(common.ArgoCDDefaultProjectName, a)
+	existing.Object["spec"] = getDefaultProjectBaseSpec()
+	r := makeTestReconciler(t, a, existing)
+
+	assert.NoError(t, r.reconcileDefaultProject(a))
+
+	spec := getTestDefaultProject(t, r, a)
+	assert.Empty(t, spec["sourceRepos"])
+	assert.Empty(t, spec["destinations"])
+	assert.Empty(t, spec["clusterResourceWhitelist"])
+
+	// nothing to update once the project is locked down
+	project := newAppProject(common.ArgoCDDefaultProjectName, a)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: project.GetName(), Namespace: a.Namespace}, project))
+	assert.NoError(t, r.reconcileDefaultProject(a))
+	updated := &unstructured.Unstructured{}
+	updated.SetGroupVersionKind(appProjectGVK)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: project.GetName(), Namespace: a.Namespace}, updated))
+	assert.Equal(t, project.GetResourceVersion(), updated.GetResourceVersion())
+}
+
+func TestReconcileDefaultProject_unlocked(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.DefaultProject = &argoproj.ArgoCDDefaultProjectSpec{Locked: true}
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, r.reconcileDefaultProject(a))
+	assert.Empty(t, getTestDefaultProject(t, r, a)["sourceRepos"])
+
+	// unlocking restores the fields cleared by the lock, except the configured ones
+	a.Spec.DefaultProject = &argoproj.ArgoCDDefaultProjectSpec{SourceRepos: []string{"https://github.com/example/*"}}
+	assert.NoError(t, r.reconcileDefaultProject(a))
+
+	spec := getTestDefaultProject(t, r, a)
+	assert.Equal(t, []interface{}{"https://github.com/example/*"}, spec["sourceRepos"])
+	assert.Equal(t, getDefaultProjectBaseSpec()["destinations"], spec["destinations"])
+	assert.Equal(t, getDefaultProjectBaseSpec()["clusterResourceWhitelist"], spec["clusterResourceWhitelist"])
+
+	project := newAppProject(common.ArgoCDDefaultProjectName, a)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: project.GetName(), Namespace: a.Namespace}, project))
+	assert.NotContains(t, project.GetAnnotations(), common.ArgoCDArgoprojKeyDefaultProjectLocked)
+
+	// a project locked down by the operator is also restored when it is no longer managed
+	a.Spec.DefaultProject = &argoproj.ArgoCDDefaultProjectSpec{Locked: true}
+	assert.NoError(t, r.reconcileDefaultProject(a))
+	a.Spec.DefaultProject = nil
+	assert.NoError(t, r.reconcileDefaultProject(a))
+	assert.Equal(t, getDefaultProjectBaseSpec()["sourceRepos"], getTestDefaultProject(t, r, a)["sourceRepos"])
+}
@@ -33,10 +33,13 @@ import (
 	"github.com/argoproj-labs/argocd-operator/controllers/argocd/server"
 	"github.com/argoproj-labs/argocd-operator/controllers/argocd/sso"
 	"github.com/argoproj-labs/argocd-operator/pkg/cluster"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -55,8 +58,10 @@ type ArgoCDReconciler struct {
 	ClusterScoped bool
 	Logger        logr.Logger
 
-	ResourceManagedNamespaces map[string]string
-	AppManagedNamespaces      map[string]string
+	ResourceManagedNamespaces      map[string]string
+	AppManagedNamespaces           map[string]string
+	AppsetManagedNamespaces        map[string]string
+	NotificationsManagedNamespaces map[string]string
 
 	SecretController        *secret.SecretReconciler
 	ConfigMapController     *configmap.ConfigMapReconciler
@@ -85,7 +90,9 @@ var ActiveInstanceMap = make(map[string]string)
 //+kubebuilder:rbac:groups=argoproj.io,resources=argocds;argocds/finalizers;argocds/status,verbs=*
 //+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=*
 //+kubebuilder:rbac:groups=batch,resources=cronjobs;jobs,verbs=*
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=*
 //+kubebuilder:rbac:groups=config.openshift.io,resources=clusterversions,verbs=get;list;watch
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;grpcroutes,verbs=*
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=*
 //+kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheuses;prometheusrules;servicemonitors,verbs=*
 //+kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=*
@@ -157,16 +164,30 @@ func (r *ArgoCDReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		// Argo CD instance marked for deletion; remove entry from activeInstances map and decrement active instance count
 		// by phase as well as total
 		delete(ActiveInstanceMap, r.Instance.Namespace)
+		deleteManagedClusterProbes(r.Instance, nil)
 		ActiveInstancesByPhase.WithLabelValues(newPhase).Dec()
 		ActiveInstancesTotal.Dec()
 		ActiveInstanceReconciliationCount.DeleteLabelValues(r.Instance.Namespace)
 		ReconcileTime.DeletePartialMatch(prometheus.Labels{"namespace": r.Instance.Namespace})
+		CertificateExpiry.DeletePartialMatch(prometheus.Labels{"namespace": r.Instance.Namespace, "instance": r.Instance.Name})
 
 		if r.Instance.IsDeletionFinalizerPresent() {
 			if err := r.deleteClusterResources(r.Instance); err != nil {
 				return reconcile.Result{}, fmt.Errorf("failed to delete ClusterResources: %w", err)
 			}
 
+			// An unreachable Keycloak must not block the deletion of the instance, the realm is left behind instead.
+			if isExternalKeycloak(r.Instance) {
+				if err := r.deleteExternalKeycloakRealm(r.Instance); err != nil {
+					r.Logger.Error(err, "failed to delete keycloak realm, leaving it in place")
+					message := fmt.Sprintf("Failed to delete the Argo CD realm or client from the external Keycloak, remove it manually: %v", err)
+					typeMeta := metav1.TypeMeta{Kind: "ArgoCD", APIVersion: argoproj.GroupVersion.String()}
+					if err := util.CreateEvent(r.Client, corev1.EventTypeWarning, "Deleting", message, "KeycloakRealmDeletionFailed", r.Instance.ObjectMeta, typeMeta); err != nil {
+						r.Logger.Error(err, "failed to create keycloak realm deletion event")
+					}
+				}
+			}
+
 			if isRemoveManagedByLabelOnArgoCDDeletion() {
 				if err := r.removeManagedByLabelFromNamespaces(r.Instance.Namespace); err != nil {
 					return reconcile.Result{}, fmt.Errorf("failed to remove label from namespace[%v], error: %w", r.Instance.Namespace, err)
@@ -204,6 +225,14 @@ func (r *ArgoCDReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		return reconcile.Result{}, err
 	}
 
+	if err = r.setAppsetManagedNamespaces(); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err = r.setNotificationsManagedNamespaces(); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// if err := r.reconcileResources(r.Instance); err != nil {
 	// 	// Error reconciling ArgoCD sub-resources - requeue the request.
 	// 	return reconcile.Result{}, err
@@ -215,14 +244,22 @@ func (r *ArgoCDReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		return reconcile.Result{}, err
 	}
 
-	// Return and don't requeue
-	return reconcile.Result{}, nil
+	// Requeue to report the connection checks of the managed clusters, which run in the background
+	return reconcile.Result{RequeueAfter: getManagedClusterRequeueAfter(r.Instance)}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ArgoCDReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	bldr := ctrl.NewControllerManagedBy(mgr)
-	r.setResourceWatches(bldr, r.clusterResourceMapper, r.tlsSecretMapper, r.namespaceResourceMapper, r.clusterSecretResourceMapper, r.applicationSetSCMTLSConfigMapMapper)
+	r.setResourceWatches(bldr, resourceMappers{
+		clusterResource:               r.clusterResourceMapper,
+		tlsSecret:                     r.tlsSecretMapper,
+		namespaceResource:             r.namespaceResourceMapper,
+		clusterSecretResource:         r.clusterSecretResourceMapper,
+		applicationSetSCMTLSConfigMap: r.applicationSetSCMTLSConfigMapMapper,
+		referencedSecret:              r.referencedSecretMapper,
+		gpgKeyConfigMap:               r.gpgKeyConfigMapMapper,
+	})
 	return bldr.Complete(r)
 }
 
@@ -324,9 +361,10 @@ func (r *ArgoCDReconciler) InitializeControllerReconcilers() {
 	}
 
 	r.NotificationsController = &notifications.NotificationsReconciler{
-		Client:   r.Client,
-		Scheme:   r.Scheme,
-		Instance: r.Instance,
+		Client:                r.Client,
+		Scheme:                r.Scheme,
+		Instance:              r.Instance,
+		SelfServiceNamespaces: r.NotificationsManagedNamespaces,
 	}
 
 	r.AppController = &appcontroller.AppControllerReconciler{
@@ -339,9 +377,10 @@ func (r *ArgoCDReconciler) InitializeControllerReconcilers() {
 	}
 
 	r.AppsetController = &applicationset.ApplicationSetReconciler{
-		Client:   r.Client,
-		Scheme:   r.Scheme,
-		Instance: r.Instance,
+		Client:           r.Client,
+		Scheme:           r.Scheme,
+		Instance:         r.Instance,
+		SourceNamespaces: r.AppsetManagedNamespaces,
 	}
 
 	r.SSOController = &sso.SSOReconciler{
@@ -477,3 +516,183 @@ func (r *ArgoCDReconciler) setAppManagedNamespaces() error {
 	r.AppManagedNamespaces = allowedSourceNamespaces
 	return nil
 }
+
+// setAppsetManagedNamespaces sets a list of namespaces that a cluster-scoped Argo CD
+// instance is allowed to source ApplicationSets from
+func (r *ArgoCDReconciler) setAppsetManagedNamespaces() error {
+	r.AppsetManagedNamespaces = make(map[string]string)
+
+	if !r.ClusterScoped {
+		r.Logger.V(1).Info("setAppsetManagedNamespaces: instance is not cluster scoped, skip processing namespaces for applicationset management")
+		return nil
+	}
+
+	r.Logger.Info("processing namespaces for applicationset management")
+
+	// Get list of existing namespaces currently carrying the ArgoCDApplicationSetManagedBy label and convert to a map
+	listOptions := []client.ListOption{
+		client.MatchingLabels{
+			common.ArgoCDArgoprojKeyApplicationSetManagedByClusterArgoCD: r.Instance.Namespace,
+		},
+	}
+
+	existingManagedNamespaces, err := cluster.ListNamespaces(r.Client, listOptions)
+	if err != nil {
+		r.Logger.Error(err, "setAppsetManagedNamespaces: failed to list namespaces")
+		return err
+	}
+	existingManagedNsMap := make(map[string]string)
+	for _, ns := range existingManagedNamespaces.Items {
+		existingManagedNsMap[ns.Name] = ""
+	}
+
+	// Get list of desired namespaces that should be carrying the ArgoCDApplicationSetManagedBy label and convert to a map
+	desiredManagedNsMap := make(map[string]string)
+	if r.Instance.Spec.ApplicationSet != nil {
+		for _, ns := range r.Instance.Spec.ApplicationSet.SourceNamespaces {
+			if ns != r.Instance.Namespace {
+				desiredManagedNsMap[ns] = ""
+			}
+		}
+	}
+
+	// check if any of the desired namespaces are missing the label. If yes, add ArgoCDArgoprojKeyApplicationSetManagedByClusterArgoCD to it
+	for desiredNs := range desiredManagedNsMap {
+		if _, ok := existingManagedNsMap[desiredNs]; ok {
+			r.AppsetManagedNamespaces[desiredNs] = ""
+			continue
+		}
+
+		ns, err := cluster.GetNamespace(desiredNs, r.Client)
+		if err != nil {
+			r.Logger.Error(err, "setAppsetManagedNamespaces: failed to retrieve namespace", "name", desiredNs)
+			continue
+		}
+
+		if len(ns.Labels) == 0 {
+			ns.Labels = make(map[string]string)
+		}
+		// check if desired namespace is already being managed by a different cluster scoped Argo CD instance. If yes, skip it
+		if val, ok := ns.Labels[common.ArgoCDArgoprojKeyApplicationSetManagedByClusterArgoCD]; ok && val != r.Instance.Namespace {
+			r.Logger.V(1).Info("setAppsetManagedNamespaces: skipping namespace as it is already managed by a different instance", "namespace", ns.Name, "managing-instance-namespace", val)
+			continue
+		}
+		ns.Labels[common.ArgoCDArgoprojKeyApplicationSetManagedByClusterArgoCD] = r.Instance.Namespace
+		if err = cluster.UpdateNamespace(ns, r.Client); err != nil {
+			r.Logger.Error(err, "setAppsetManagedNamespaces: failed to update namespace", "namespace", ns.Name)
+			continue
+		}
+		r.Logger.V(1).Info("setAppsetManagedNamespaces: labeled namespace", "namespace", ns.Name)
+		r.AppsetManagedNamespaces[desiredNs] = ""
+	}
+
+	// check if any of the exisiting namespaces are carrying the label when they should not be. If yes, remove it
+	for existingNs := range existingManagedNsMap {
+		if _, ok := desiredManagedNsMap[existingNs]; ok {
+			continue
+		}
+		ns, err := cluster.GetNamespace(existingNs, r.Client)
+		if err != nil {
+			r.Logger.Error(err, "setAppsetManagedNamespaces: failed to retrieve namespace", "name", existingNs)
+			continue
+		}
+		delete(ns.Labels, common.ArgoCDArgoprojKeyApplicationSetManagedByClusterArgoCD)
+		if err = cluster.UpdateNamespace(ns, r.Client); err != nil {
+			r.Logger.Error(err, "setAppsetManagedNamespaces: failed to update namespace", "namespace", ns.Name)
+			continue
+		}
+		r.Logger.V(1).Info("setAppsetManagedNamespaces: unlabeled namespace", "namespace", ns.Name)
+	}
+
+	return nil
+}
+
+// setNotificationsManagedNamespaces sets a list of self-service namespaces that the notifications controller of a
+// cluster-scoped Argo CD instance is allowed to watch
+func (r *ArgoCDReconciler) setNotificationsManagedNamespaces() error {
+	r.NotificationsManagedNamespaces = make(map[string]string)
+
+	if !r.ClusterScoped {
+		r.Logger.V(1).Info("setNotificationsManagedNamespaces: instance is not cluster scoped, skip processing namespaces for notifications management")
+		return nil
+	}
+
+	r.Logger.Info("processing namespaces for notifications management")
+
+	// Get list of existing namespaces currently carrying the ArgoCDNotificationsManagedBy label and convert to a map
+	listOptions := []client.ListOption{
+		client.MatchingLabels{
+			common.ArgoCDArgoprojKeyNotificationsManagedByClusterArgoCD: r.Instance.Namespace,
+		},
+	}
+
+	existingManagedNamespaces, err := cluster.ListNamespaces(r.Client, listOptions)
+	if err != nil {
+		r.Logger.Error(err, "setNotificationsManagedNamespaces: failed to list namespaces")
+		return err
+	}
+	existingManagedNsMap := make(map[string]string)
+	for _, ns := range existingManagedNamespaces.Items {
+		existingManagedNsMap[ns.Name] = ""
+	}
+
+	// Get list of desired namespaces that should be carrying the ArgoCDNotificationsManagedBy label and convert to a map
+	desiredManagedNsMap := make(map[string]string)
+	if r.Instance.Spec.Notifications.Enabled {
+		for _, ns := range r.Instance.Spec.Notifications.SelfServiceNamespaces {
+			if ns != r.Instance.Namespace {
+				desiredManagedNsMap[ns] = ""
+			}
+		}
+	}
+
+	// check if any of the desired namespaces are missing the label. If yes, add ArgoCDArgoprojKeyNotificationsManagedByClusterArgoCD to it
+	for desiredNs := range desiredManagedNsMap {
+		if _, ok := existingManagedNsMap[desiredNs]; ok {
+			r.NotificationsManagedNamespaces[desiredNs] = ""
+			continue
+		}
+
+		ns, err := cluster.GetNamespace(desiredNs, r.Client)
+		if err != nil {
+			r.Logger.Error(err, "setNotificationsManagedNamespaces: failed to retrieve namespace", "name", desiredNs)
+			continue
+		}
+
+		if len(ns.Labels) == 0 {
+			ns.Labels = make(map[string]string)
+		}
+		// check if desired namespace is already being managed by a different cluster scoped Argo CD instance. If yes, skip it
+		if val, ok := ns.Labels[common.ArgoCDArgoprojKeyNotificationsManagedByClusterArgoCD]; ok && val != r.Instance.Namespace {
+			r.Logger.V(1).Info("setNotificationsManagedNamespaces: skipping namespace as it is already managed by a different instance", "namespace", ns.Name, "managing-instance-namespace", val)
+			continue
+		}
+		ns.Labels[common.ArgoCDArgoprojKeyNotificationsManagedByClusterArgoCD] = r.Instance.Namespace
+		if err = cluster.UpdateNamespace(ns, r.Client); err != nil {
+			r.Logger.Error(err, "setNotificationsManagedNamespaces: failed to update namespace", "namespace", ns.Name)
+			continue
+		}
+		r.Logger.V(1).Info("setNotificationsManagedNamespaces: labeled namespace", "namespace", ns.Name)
+		r.NotificationsManagedNamespaces[desiredNs] = ""
+	}
+
+	// check if any of the exisiting namespaces are carrying the label when they should not be. If yes, remove it
+	for existingNs := range existingManagedNsMap {
+		if _, ok := desiredManagedNsMap[existingNs]; ok {
+			continue
+		}
+		ns, err := cluster.GetNamespace(existingNs, r.Client)
+		if err != nil {
+			r.Logger.Error(err, "setNotificationsManagedNamespaces: failed to retrieve namespace", "name", existingNs)
+			continue
+		}
+		delete(ns.Labels, common.ArgoCDArgoprojKeyNotificationsManagedByClusterArgoCD)
+		if err = cluster.UpdateNamespace(ns, r.Client); err != nil {
+			r.Logger.Error(err, "setNotificationsManagedNamespaces: failed to update namespace", "namespace", ns.Name)
+			continue
+		}
+		r.Logger.V(1).Info("setNotificationsManagedNamespaces: unlabeled namespace", "namespace", ns.Name)
+	}
+
+	return nil
+}
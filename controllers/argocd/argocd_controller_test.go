@@ -17,6 +17,8 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"sort"
 	"testing"
 	"time"
@@ -194,6 +196,57 @@ func TestArgoCDReconciler_Reconcile_RemoveManagedByLabelOnArgocdDeletion(t *test
 	}
 }
 
+func TestArgoCDReconciler_CleanUp_externalKeycloakUnavailable(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	a := makeTestArgoCD(deletedAt(time.Now()), addFinalizer(common.ArgoprojKeyFinalizer), func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeKeycloak,
+			Keycloak: &argoproj.ArgoCDKeycloakSpec{
+				External: &argoproj.ArgoCDKeycloakExternalSpec{
+					URL:               server.URL,
+					CredentialsSecret: "keycloak-admin",
+				},
+			},
+		}
+	})
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "keycloak-admin", Namespace: a.Namespace},
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte("admin"),
+			corev1.BasicAuthPasswordKey: []byte("admin"),
+		},
+	}
+
+	resources := []runtime.Object{a, credentials}
+	resources = append(resources, clusterResources(a)...)
+	r := makeTestReconciler(t, resources...)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      a.Name,
+			Namespace: a.Namespace,
+		},
+	}
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	// the finalizer is removed even though the realm could not be deleted, which is reported in an event
+	assert.True(t, apierrors.IsNotFound(r.Client.Get(context.TODO(), req.NamespacedName, &argoproj.ArgoCD{})))
+
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(a.Namespace)))
+	if assert.Len(t, events.Items, 1) {
+		assert.Equal(t, corev1.EventTypeWarning, events.Items[0].Type)
+		assert.Equal(t, "KeycloakRealmDeletionFailed", events.Items[0].Reason)
+	}
+}
+
 func TestArgoCDReconciler_CleanUp(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD(deletedAt(time.Now()), addFinalizer(common.ArgoprojKeyFinalizer))
@@ -409,3 +462,132 @@ func TestSetAppManagedNamespaces(t *testing.T) {
 	assert.Equal(t, expectedLabelledNsList, labelledNs)
 
 }
+
+func TestSetAppsetManagedNamespaces(t *testing.T) {
+	r := makeTestReconciler(t,
+		makeTestNs(func(n *corev1.Namespace) {
+			n.Name = "instance-1"
+		}),
+		makeTestNs(func(n *corev1.Namespace) {
+			n.Name = "test-ns-1"
+		}),
+		makeTestNs(func(n *corev1.Namespace) {
+			n.Name = "test-ns-2"
+			n.Labels[common.ArgoCDArgoprojKeyApplicationSetManagedByClusterArgoCD] = "instance-2"
+		}),
+		makeTestNs(func(n *corev1.Namespace) {
+			n.Name = "test-ns-3"
+		}),
+	)
+
+	r.Instance = makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+		ac.Namespace = "instance-1"
+		ac.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+			SourceNamespaces: []string{"test-ns-1", "test-ns-2", "instance-1"},
+		}
+	})
+
+	listOptions := []client.ListOption{
+		client.MatchingLabels{
+			common.ArgoCDArgoprojKeyApplicationSetManagedByClusterArgoCD: "instance-1",
+		},
+	}
+	labelledNamespaces := func() []string {
+		existingManagedNamespaces, _ := cluster.ListNamespaces(r.Client, listOptions)
+		labelledNs := []string{}
+		for _, n := range existingManagedNamespaces.Items {
+			labelledNs = append(labelledNs, n.Name)
+		}
+		sort.Strings(labelledNs)
+		return labelledNs
+	}
+
+	// test with namespace scoped instance
+	r.ClusterScoped = false
+	assert.NoError(t, r.setAppsetManagedNamespaces())
+	assert.Equal(t, map[string]string{}, r.AppsetManagedNamespaces)
+	assert.Equal(t, []string{}, labelledNamespaces())
+
+	// change instance to clusterscoped
+	r.ClusterScoped = true
+	assert.NoError(t, r.setAppsetManagedNamespaces())
+	assert.Equal(t, map[string]string{"test-ns-1": ""}, r.AppsetManagedNamespaces)
+	assert.Equal(t, []string{"test-ns-1"}, labelledNamespaces())
+
+	// update source namespace list
+	r.Instance.Spec.ApplicationSet.SourceNamespaces = []string{"test-ns-3"}
+	assert.NoError(t, r.setAppsetManagedNamespaces())
+	assert.Equal(t, map[string]string{"test-ns-3": ""}, r.AppsetManagedNamespaces)
+	assert.Equal(t, []string{"test-ns-3"}, labelledNamespaces())
+
+	// disable the applicationset controller
+	r.Instance.Spec.ApplicationSet = nil
+	assert.NoError(t, r.setAppsetManagedNamespaces())
+	assert.Equal(t, map[string]string{}, r.AppsetManagedNamespaces)
+	assert.Equal(t, []string{}, labelledNamespaces())
+}
+
+func TestSetNotificationsManagedNamespaces(t *testing.T) {
+	r := makeTestReconciler(t,
+		makeTestNs(func(n *corev1.Namespace) {
+			n.Name = "instance-1"
+		}),
+		makeTestNs(func(n *corev1.Namespace) {
+			n.Name = "test-ns-1"
+		}),
+		makeTestNs(func(n *corev1.Namespace) {
+			n.Name = "test-ns-2"
+			n.Labels[common.ArgoCDArgoprojKeyNotificationsManagedByClusterArgoCD] = "instance-2"
+		}),
+		makeTestNs(func(n *corev1.Namespace) {
+			n.Name = "test-ns-3"
+		}),
+	)
+
+	r.Instance = makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+		ac.Namespace = "instance-1"
+		ac.Spec.Notifications = argoproj.ArgoCDNotifications{
+			Enabled:               true,
+			SelfServiceNamespaces: []string{"test-ns-1", "test-ns-2", "instance-1"},
+		}
+	})
+
+	listOptions := []client.ListOption{
+		client.MatchingLabels{
+			common.ArgoCDArgoprojKeyNotificationsManagedByClusterArgoCD: "instance-1",
+		},
+	}
+	labelledNamespaces := func() []string {
+		existingManagedNamespaces, _ := cluster.ListNamespaces(r.Client, listOptions)
+		labelledNs := []string{}
+		for _, n := range existingManagedNamespaces.Items {
+			labelledNs = append(labelledNs, n.Name)
+		}
+		sort.Strings(labelledNs)
+		return labelledNs
+	}
+
+	// test with namespace scoped instance
+	r.ClusterScoped = false
+	assert.NoError(t, r.setNotificationsManagedNamespaces())
+	assert.Equal(t, map[string]string{}, r.NotificationsManagedNamespaces)
+	assert.Equal(t, []string{}, labelledNamespaces())
+
+	// change instance to clusterscoped
+	r.ClusterScoped = true
+	assert.NoError(t, r.setNotificationsManagedNamespaces())
+	assert.Equal(t, map[string]string{"test-ns-1": ""}, r.NotificationsManagedNamespaces)
+	assert.Equal(t, []string{"test-ns-1"}, labelledNamespaces())
+
+	// update self-service namespace list
+	r.Instance.Spec.Notifications.SelfServiceNamespaces = []string{"test-ns-3"}
+	assert.NoError(t, r.setNotificationsManagedNamespaces())
+	assert.Equal(t, map[string]string{"test-ns-3": ""}, r.NotificationsManagedNamespaces)
+	assert.Equal(t, []string{"test-ns-3"}, labelledNamespaces())
+
+	// disable the notifications controller
+	r.Instance.Spec.Notifications.Enabled = false
+	assert.NoError(t, r.setNotificationsManagedNamespaces())
+	assert.Equal(t, map[string]string{}, r.NotificationsManagedNamespaces)
+	assert.Equal(t, []string{}, labelledNamespaces())
+}
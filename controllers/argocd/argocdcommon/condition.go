@@ -0,0 +1,31 @@
+package argocdcommon
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cntrlClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+// SetStatusCondition records the given condition on the ArgoCD. The status is only updated when the condition changed.
+func SetStatusCondition(cr *argoproj.ArgoCD, condition metav1.Condition, client cntrlClient.Client) error {
+	current := meta.FindStatusCondition(cr.Status.Conditions, condition.Type)
+	if current != nil && current.Status == condition.Status && current.Reason == condition.Reason &&
+		current.Message == condition.Message && current.ObservedGeneration == condition.ObservedGeneration {
+		return nil
+	}
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	return client.Status().Update(context.TODO(), cr)
+}
+
+// RemoveStatusCondition removes the condition of the given type from the ArgoCD, if present.
+func RemoveStatusCondition(cr *argoproj.ArgoCD, conditionType string, client cntrlClient.Client) error {
+	if meta.FindStatusCondition(cr.Status.Conditions, conditionType) == nil {
+		return nil
+	}
+	meta.RemoveStatusCondition(&cr.Status.Conditions, conditionType)
+	return client.Status().Update(context.TODO(), cr)
+}
@@ -0,0 +1,213 @@
+package argocdcommon
+
+import (
+	"reflect"
+
+	"github.com/go-logr/logr"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cntrlClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/pkg/cluster"
+	"github.com/argoproj-labs/argocd-operator/pkg/mutation"
+	"github.com/argoproj-labs/argocd-operator/pkg/permissions"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+)
+
+// NamespacePermissionsRequest describes the Role and RoleBinding granting the ServiceAccount of an Argo CD component
+// access to namespaces other than the namespace of the ArgoCD.
+type NamespacePermissionsRequest struct {
+	Instance *argoproj.ArgoCD
+	// Name is the name of the Role, the RoleBinding and the ServiceAccount they bind to.
+	Name string
+	// Component is the value of the component label of the Role and RoleBinding.
+	Component string
+	Labels    map[string]string
+	Rules     []rbacv1.PolicyRule
+	Client    cntrlClient.Client
+	Logger    logr.Logger
+}
+
+// ReconcileNamespacePermissions ensures that the Role and RoleBinding of the request are present in each of the given
+// namespaces, and removes them from the namespaces no longer listed. Owner references cannot cross namespaces, so
+// these are tracked by their labels.
+func ReconcileNamespacePermissions(req NamespacePermissionsRequest, namespaces []string) error {
+	keep := []string{}
+	for _, name := range namespaces {
+		namespace, err := cluster.GetNamespace(name, req.Client)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				req.Logger.Info("ReconcileNamespacePermissions: namespace not found, skipping", "name", name)
+				continue
+			}
+			req.Logger.Error(err, "ReconcileNamespacePermissions: failed to retrieve namespace", "name", name)
+			return err
+		}
+		if namespace.DeletionTimestamp != nil {
+			continue
+		}
+		keep = append(keep, name)
+
+		if err := reconcileNamespaceRole(req, name); err != nil {
+			return err
+		}
+		if err := reconcileNamespaceRoleBinding(req, name); err != nil {
+			return err
+		}
+	}
+
+	return DeleteNamespacePermissions(req, keep)
+}
+
+func reconcileNamespaceRole(req NamespacePermissionsRequest, namespace string) error {
+	roleRequest := permissions.RoleRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        req.Name,
+			Namespace:   namespace,
+			Labels:      req.Labels,
+			Annotations: common.DefaultAnnotations(req.Instance.Name, req.Instance.Namespace),
+		},
+		Rules:     req.Rules,
+		Client:    req.Client,
+		Mutations: []mutation.MutateFunc{mutation.ApplyReconcilerMutation},
+	}
+
+	desiredRole, err := permissions.RequestRole(roleRequest)
+	if err != nil {
+		req.Logger.Error(err, "reconcileNamespaceRole: failed to request role", "name", desiredRole.Name, "namespace", desiredRole.Namespace)
+		req.Logger.V(1).Info("reconcileNamespaceRole: one or more mutations could not be applied")
+		return err
+	}
+
+	existingRole, err := permissions.GetRole(desiredRole.Name, desiredRole.Namespace, req.Client)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			req.Logger.Error(err, "reconcileNamespaceRole: failed to retrieve role", "name", desiredRole.Name, "namespace", desiredRole.Namespace)
+			return err
+		}
+
+		if err = permissions.CreateRole(desiredRole, req.Client); err != nil {
+			req.Logger.Error(err, "reconcileNamespaceRole: failed to create role", "name", desiredRole.Name, "namespace", desiredRole.Namespace)
+			return err
+		}
+		req.Logger.V(0).Info("reconcileNamespaceRole: role created", "name", desiredRole.Name, "namespace", desiredRole.Namespace)
+		return nil
+	}
+
+	if !reflect.DeepEqual(existingRole.Rules, desiredRole.Rules) {
+		existingRole.Rules = desiredRole.Rules
+		if err = permissions.UpdateRole(existingRole, req.Client); err != nil {
+			req.Logger.Error(err, "reconcileNamespaceRole: failed to update role", "name", existingRole.Name, "namespace", existingRole.Namespace)
+			return err
+		}
+		req.Logger.V(0).Info("reconcileNamespaceRole: role updated", "name", existingRole.Name, "namespace", existingRole.Namespace)
+	}
+	return nil
+}
+
+func reconcileNamespaceRoleBinding(req NamespacePermissionsRequest, namespace string) error {
+	roleBindingRequest := permissions.RoleBindingRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        req.Name,
+			Namespace:   namespace,
+			Labels:      req.Labels,
+			Annotations: common.DefaultAnnotations(req.Instance.Name, req.Instance.Namespace),
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     common.RoleKind,
+			Name:     req.Name,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      req.Name,
+				Namespace: req.Instance.Namespace,
+			},
+		},
+	}
+
+	desiredRoleBinding := permissions.RequestRoleBinding(roleBindingRequest)
+
+	existingRoleBinding, err := permissions.GetRoleBinding(desiredRoleBinding.Name, desiredRoleBinding.Namespace, req.Client)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			req.Logger.Error(err, "reconcileNamespaceRoleBinding: failed to retrieve roleBinding", "name", desiredRoleBinding.Name, "namespace", desiredRoleBinding.Namespace)
+			return err
+		}
+
+		if err = permissions.CreateRoleBinding(desiredRoleBinding, req.Client); err != nil {
+			req.Logger.Error(err, "reconcileNamespaceRoleBinding: failed to create roleBinding", "name", desiredRoleBinding.Name, "namespace", desiredRoleBinding.Namespace)
+			return err
+		}
+		req.Logger.V(0).Info("reconcileNamespaceRoleBinding: roleBinding created", "name", desiredRoleBinding.Name, "namespace", desiredRoleBinding.Namespace)
+		return nil
+	}
+
+	// the role reference of a RoleBinding cannot be changed, recreate it
+	if !reflect.DeepEqual(existingRoleBinding.RoleRef, desiredRoleBinding.RoleRef) {
+		if err = permissions.DeleteRoleBinding(existingRoleBinding.Name, existingRoleBinding.Namespace, req.Client); err != nil {
+			req.Logger.Error(err, "reconcileNamespaceRoleBinding: failed to delete roleBinding", "name", existingRoleBinding.Name, "namespace", existingRoleBinding.Namespace)
+			return err
+		}
+		return permissions.CreateRoleBinding(desiredRoleBinding, req.Client)
+	}
+
+	if !reflect.DeepEqual(existingRoleBinding.Subjects, desiredRoleBinding.Subjects) {
+		existingRoleBinding.Subjects = desiredRoleBinding.Subjects
+		if err = permissions.UpdateRoleBinding(existingRoleBinding, req.Client); err != nil {
+			req.Logger.Error(err, "reconcileNamespaceRoleBinding: failed to update roleBinding", "name", existingRoleBinding.Name, "namespace", existingRoleBinding.Namespace)
+			return err
+		}
+		req.Logger.V(0).Info("reconcileNamespaceRoleBinding: roleBinding updated", "name", existingRoleBinding.Name, "namespace", existingRoleBinding.Namespace)
+	}
+	return nil
+}
+
+// DeleteNamespacePermissions removes the Roles and RoleBindings of the request from the namespaces other than the
+// namespace of the ArgoCD and the given ones.
+func DeleteNamespacePermissions(req NamespacePermissionsRequest, keep []string) error {
+	keep = append([]string{req.Instance.Namespace}, keep...)
+	listOptions := []cntrlClient.ListOption{
+		cntrlClient.MatchingLabels{
+			common.AppK8sKeyName:      req.Name,
+			common.AppK8sKeyComponent: req.Component,
+		},
+	}
+
+	roleBindings, err := permissions.ListRoleBindings("", req.Client, listOptions)
+	if err != nil {
+		req.Logger.Error(err, "DeleteNamespacePermissions: failed to list roleBindings")
+		return err
+	}
+	for _, roleBinding := range roleBindings.Items {
+		if util.ContainsString(keep, roleBinding.Namespace) {
+			continue
+		}
+		if err := permissions.DeleteRoleBinding(roleBinding.Name, roleBinding.Namespace, req.Client); err != nil {
+			req.Logger.Error(err, "DeleteNamespacePermissions: failed to delete roleBinding", "name", roleBinding.Name, "namespace", roleBinding.Namespace)
+			return err
+		}
+		req.Logger.V(0).Info("DeleteNamespacePermissions: roleBinding deleted", "name", roleBinding.Name, "namespace", roleBinding.Namespace)
+	}
+
+	roles, err := permissions.ListRoles("", req.Client, listOptions)
+	if err != nil {
+		req.Logger.Error(err, "DeleteNamespacePermissions: failed to list roles")
+		return err
+	}
+	for _, role := range roles.Items {
+		if util.ContainsString(keep, role.Namespace) {
+			continue
+		}
+		if err := permissions.DeleteRole(role.Name, role.Namespace, req.Client); err != nil {
+			req.Logger.Error(err, "DeleteNamespacePermissions: failed to delete role", "name", role.Name, "namespace", role.Namespace)
+			return err
+		}
+		req.Logger.V(0).Info("DeleteNamespacePermissions: role deleted", "name", role.Name, "namespace", role.Namespace)
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocd/argocdcommon"
+	"github.com/argoproj-labs/argocd-operator/pkg/certmanager"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+)
+
+// tlsCertificate describes the cert-manager Certificate of an ArgoCD component.
+type tlsCertificate struct {
+	// name is the name of both the Certificate and the Secret it is stored in.
+	name      string
+	component string
+	enabled   bool
+	// ingressOnly is set for Certificates that are only used by an Ingress, the workloads do not wait for them.
+	ingressOnly bool
+	services    []string
+	hosts       []string
+}
+
+// getCertificateDNSNames returns the DNS names a component can be reached at through its Services and external hosts.
+func getCertificateDNSNames(cr *argoproj.ArgoCD, certificate tlsCertificate) []string {
+	dnsNames := []string{}
+	for _, svc := range certificate.services {
+		dnsNames = append(dnsNames,
+			svc,
+			fmt.Sprintf("%s.%s", svc, cr.Namespace),
+			fmt.Sprintf("%s.%s.svc", svc, cr.Namespace),
+			fmt.Sprintf("%s.%s.svc.cluster.local", svc, cr.Namespace),
+		)
+	}
+	for _, host := range certificate.hosts {
+		if len(host) > 0 {
+			dnsNames = append(dnsNames, host)
+		}
+	}
+	return dnsNames
+}
+
+// getTLSCertificates returns the cert-manager Certificates for all the ArgoCD components that serve TLS.
+func getTLSCertificates(cr *argoproj.ArgoCD) []tlsCertificate {
+	redisServices := []string{util.NameWithSuffix(cr.Name, common.ArgoCDDefaultRedisSuffix)}
+	if cr.Spec.HA.Enabled {
+		redisServices = append(redisServices,
+			util.NameWithSuffix(cr.Name, "redis-ha"),
+			util.NameWithSuffix(cr.Name, "redis-ha-haproxy"),
+		)
+	}
+
+	webhookHost := ""
+	if cr.Spec.ApplicationSet != nil {
+		webhookHost = cr.Spec.ApplicationSet.WebhookServer.Host
+	}
+
+	return []tlsCertificate{
+		{
+			name:      common.ArgoCDServerTLSSecretName,
+			component: "server",
+			enabled:   true,
+			services:  []string{util.NameWithSuffix(cr.Name, "server")},
+			hosts:     []string{cr.Spec.Server.Host, cr.Spec.Server.GRPC.Host},
+		},
+		{
+			name:      common.ArgoCDRepoServerTLSSecretName,
+			component: "repo-server",
+			enabled:   true,
+			services:  []string{util.NameWithSuffix(cr.Name, "repo-server")},
+		},
+		{
+			name:      common.ArgoCDRedisServerTLSSecretName,
+			component: "redis",
+			enabled:   true,
+			services:  redisServices,
+		},
+		{
+			// Used as the TLS secret of the webhook Ingress, the webhook server itself only serves plain HTTP.
+			name:        common.ArgoCDAppSetWebhookTLSSecretName,
+			component:   common.ApplicationSetServiceNameSuffix,
+			enabled:     cr.Spec.ApplicationSet != nil && cr.Spec.ApplicationSet.WebhookServer.Ingress.Enabled,
+			ingressOnly: true,
+			services:    []string{util.NameWithSuffix(cr.Name, common.ApplicationSetServiceNameSuffix)},
+			hosts:       []string{webhookHost},
+		},
+	}
+}
+
+// getCertificateIssuerRef returns the cert-manager issuer configured for the given ArgoCD.
+func getCertificateIssuerRef(cr *argoproj.ArgoCD) certmanager.IssuerReference {
+	issuerRef := certmanager.IssuerReference{
+		Name:  cr.Spec.TLS.IssuerRef.Name,
+		Kind:  cr.Spec.TLS.IssuerRef.Kind,
+		Group: cr.Spec.TLS.IssuerRef.Group,
+	}
+	if len(issuerRef.Kind) <= 0 {
+		issuerRef.Kind = certmanager.IssuerKind
+	}
+	if len(issuerRef.Group) <= 0 {
+		issuerRef.Group = certmanager.CertManagerAPIGroup
+	}
+	return issuerRef
+}
+
+// usesCertManager returns true if the TLS certificates of the given ArgoCD are issued by cert-manager.
+func usesCertManager(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.TLS.IssuerRef != nil && certmanager.IsCertManagerAPIAvailable()
+}
+
+// reconcileCertificate will ensure that the cert-manager Certificate of a component is present when enabled, and removed
+// otherwise. It returns true if the Certificate has been issued by cert-manager. A Certificate with the same name that
+// is not controlled by the ArgoCD, such as one created by a user, is left untouched.
+func (r *ArgoCDReconciler) reconcileCertificate(cr *argoproj.ArgoCD, certificate tlsCertificate) (bool, error) {
+	existing, err := certmanager.GetCertificate(certificate.name, cr.Namespace, r.Client)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return false, err
+		}
+		existing = nil
+	}
+	if existing != nil && !metav1.IsControlledBy(existing, cr) {
+		log.Info(fmt.Sprintf("certificate %s in namespace %s is not controlled by ArgoCD %s, leaving it untouched", certificate.name, cr.Namespace, cr.Name))
+		return !certificate.enabled || certmanager.IsCertificateReady(existing), nil
+	}
+
+	if !certificate.enabled {
+		if existing == nil {
+			return true, nil
+		}
+		log.Info(fmt.Sprintf("deleting certificate %s for ArgoCD %s in namespace %s", certificate.name, cr.Name, cr.Namespace))
+		return true, certmanager.DeleteCertificate(certificate.name, cr.Namespace, r.Client)
+	}
+
+	desired, err := certmanager.RequestCertificate(certmanager.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      certificate.name,
+			Namespace: cr.Namespace,
+			Labels:    common.DefaultLabels(certificate.name, cr.Name, certificate.component),
+		},
+		IssuerRef:  getCertificateIssuerRef(cr),
+		CommonName: util.NameWithSuffix(cr.Name, certificate.component),
+		DNSNames:   getCertificateDNSNames(cr, certificate),
+		SecretName: certificate.name,
+		// The annotation allows the TLS secret watch to map renewed certificates back to this ArgoCD.
+		SecretAnnotations: map[string]string{
+			common.ArgoCDArgoprojKeyName: cr.Name,
+		},
+		SecretLabels: common.DefaultLabels(certificate.name, cr.Name, certificate.component),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if existing == nil {
+		if err := controllerutil.SetControllerReference(cr, desired, r.Scheme); err != nil {
+			return false, err
+		}
+		log.Info(fmt.Sprintf("creating certificate %s for ArgoCD %s in namespace %s", certificate.name, cr.Name, cr.Namespace))
+		return false, certmanager.CreateCertificate(desired, r.Client)
+	}
+
+	if certmanager.CertificateNeedsUpdate(existing, desired) {
+		existing.Object["spec"] = desired.Object["spec"]
+		existing.SetLabels(desired.GetLabels())
+		existing.SetAnnotations(desired.GetAnnotations())
+		log.Info(fmt.Sprintf("updating certificate %s for ArgoCD %s in namespace %s", certificate.name, cr.Name, cr.Namespace))
+		if err := certmanager.UpdateCertificate(existing, r.Client); err != nil {
+			return false, err
+		}
+	}
+	return certmanager.IsCertificateReady(existing), nil
+}
+
+// reconcileCertificates will ensure that the cert-manager Certificates of all ArgoCD components are present when an
+// issuer is configured, and that the ones it requested are removed otherwise. The outcome is reported in the
+// CertificatesReady condition, and true is returned once all the Certificates used by the workloads have been issued.
+func (r *ArgoCDReconciler) reconcileCertificates(cr *argoproj.ArgoCD) (bool, error) {
+	if !certmanager.IsCertManagerAPIAvailable() {
+		if cr.Spec.TLS.IssuerRef != nil {
+			log.Info(fmt.Sprintf("ignoring .spec.tls.issuerRef of ArgoCD %s in namespace %s, the cert-manager API is not available", cr.Name, cr.Namespace))
+		}
+		return true, argocdcommon.RemoveStatusCondition(cr, common.ArgoCDConditionCertificatesReady, r.Client)
+	}
+
+	if cr.Spec.TLS.IssuerRef == nil {
+		for _, certificate := range getTLSCertificates(cr) {
+			certificate.enabled = false
+			if _, err := r.reconcileCertificate(cr, certificate); err != nil {
+				return false, err
+			}
+		}
+		return true, argocdcommon.RemoveStatusCondition(cr, common.ArgoCDConditionCertificatesReady, r.Client)
+	}
+
+	workloadsReady := true
+	pending := []string{}
+	for _, certificate := range getTLSCertificates(cr) {
+		ready, err := r.reconcileCertificate(cr, certificate)
+		if err != nil {
+			return false, err
+		}
+		if !ready {
+			log.Info(fmt.Sprintf("waiting for certificate %s of ArgoCD %s in namespace %s to become ready", certificate.name, cr.Name, cr.Namespace))
+			pending = append(pending, certificate.name)
+			workloadsReady = workloadsReady && certificate.ingressOnly
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               common.ArgoCDConditionCertificatesReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cr.Generation,
+		Reason:             "Issued",
+		Message:            "all certificates have been issued",
+	}
+	if len(pending) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Pending"
+		condition.Message = fmt.Sprintf("waiting for certificates to be issued: %s", strings.Join(pending, ", "))
+	}
+	return workloadsReady, argocdcommon.SetStatusCondition(cr, condition, r.Client)
+}
@@ -0,0 +1,173 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/pkg/certmanager"
+	"github.com/argoproj-labs/argocd-operator/pkg/networking"
+)
+
+func withIssuerRef(issuerRef *argoproj.ArgoCDIssuerReference) func(*argoproj.ArgoCD) {
+	return func(a *argoproj.ArgoCD) {
+		a.Spec.TLS.IssuerRef = issuerRef
+	}
+}
+
+func setCertificateReady(t *testing.T, r *ArgoCDReconciler, name string) {
+	t.Helper()
+	certificate, err := certmanager.GetCertificate(name, testNamespace, r.Client)
+	assert.NoError(t, err)
+	assert.NoError(t, unstructured.SetNestedSlice(certificate.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True"},
+	}, "status", "conditions"))
+	assert.NoError(t, r.Client.Update(context.TODO(), certificate))
+}
+
+func TestReconcileCertificates(t *testing.T) {
+	certmanager.SetCertManagerAPIFound(true)
+	defer certmanager.SetCertManagerAPIFound(false)
+
+	argoCD := makeArgoCD(withIssuerRef(&argoproj.ArgoCDIssuerReference{Name: "my-issuer", Kind: certmanager.ClusterIssuerKind}), func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Host = "argocd.example.com"
+	})
+	r := makeReconciler(t, argoCD, argoCD)
+
+	ready, err := r.reconcileCertificates(argoCD)
+	assert.NoError(t, err)
+	assert.False(t, ready)
+
+	condition := meta.FindStatusCondition(argoCD.Status.Conditions, common.ArgoCDConditionCertificatesReady)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Contains(t, condition.Message, common.ArgoCDServerTLSSecretName)
+
+	server, err := certmanager.GetCertificate(common.ArgoCDServerTLSSecretName, testNamespace, r.Client)
+	assert.NoError(t, err)
+	assert.Equal(t, testArgoCDName, server.GetOwnerReferences()[0].Name)
+
+	issuerRef, _, _ := unstructured.NestedStringMap(server.Object, "spec", "issuerRef")
+	assert.Equal(t, map[string]string{"name": "my-issuer", "kind": certmanager.ClusterIssuerKind, "group": certmanager.CertManagerAPIGroup}, issuerRef)
+
+	dnsNames, _, _ := unstructured.NestedStringSlice(server.Object, "spec", "dnsNames")
+	assert.Contains(t, dnsNames, testArgoCDName+"-server."+testNamespace+".svc")
+	assert.Contains(t, dnsNames, "argocd.example.com")
+
+	// the renewed secret must map back to this ArgoCD through the tls secret watch
+	secretAnnotations, _, _ := unstructured.NestedStringMap(server.Object, "spec", "secretTemplate", "annotations")
+	assert.Equal(t, testArgoCDName, secretAnnotations[common.ArgoCDArgoprojKeyName])
+
+	// the webhook certificate is only requested when the ApplicationSet webhook Ingress is enabled
+	_, err = certmanager.GetCertificate(common.ArgoCDAppSetWebhookTLSSecretName, testNamespace, r.Client)
+	assert.True(t, errors.IsNotFound(err))
+
+	for _, name := range []string{common.ArgoCDServerTLSSecretName, common.ArgoCDRepoServerTLSSecretName, common.ArgoCDRedisServerTLSSecretName} {
+		setCertificateReady(t, r, name)
+	}
+	ready, err = r.reconcileCertificates(argoCD)
+	assert.NoError(t, err)
+	assert.True(t, ready)
+	assert.True(t, meta.IsStatusConditionTrue(argoCD.Status.Conditions, common.ArgoCDConditionCertificatesReady))
+
+	// removing the issuer removes the certificates
+	argoCD.Spec.TLS.IssuerRef = nil
+	ready, err = r.reconcileCertificates(argoCD)
+	assert.NoError(t, err)
+	assert.True(t, ready)
+	assert.Nil(t, meta.FindStatusCondition(argoCD.Status.Conditions, common.ArgoCDConditionCertificatesReady))
+	_, err = certmanager.GetCertificate(common.ArgoCDServerTLSSecretName, testNamespace, r.Client)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReconcileCertificates_notControlled(t *testing.T) {
+	certmanager.SetCertManagerAPIFound(true)
+	defer certmanager.SetCertManagerAPIFound(false)
+
+	argoCD := makeArgoCD()
+	userCertificate, err := certmanager.RequestCertificate(certmanager.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDServerTLSSecretName, Namespace: testNamespace},
+		IssuerRef:  certmanager.IssuerReference{Name: "user-issuer", Kind: certmanager.IssuerKind, Group: certmanager.CertManagerAPIGroup},
+		DNSNames:   []string{"argocd.example.com"},
+		SecretName: common.ArgoCDServerTLSSecretName,
+	})
+	assert.NoError(t, err)
+	r := makeReconciler(t, argoCD, argoCD, userCertificate)
+
+	// without an issuer, a Certificate created by a user is not deleted
+	ready, err := r.reconcileCertificates(argoCD)
+	assert.NoError(t, err)
+	assert.True(t, ready)
+	_, err = certmanager.GetCertificate(common.ArgoCDServerTLSSecretName, testNamespace, r.Client)
+	assert.NoError(t, err)
+
+	// with an issuer, it is not updated either
+	argoCD.Spec.TLS.IssuerRef = &argoproj.ArgoCDIssuerReference{Name: "my-issuer"}
+	_, err = r.reconcileCertificates(argoCD)
+	assert.NoError(t, err)
+	server, err := certmanager.GetCertificate(common.ArgoCDServerTLSSecretName, testNamespace, r.Client)
+	assert.NoError(t, err)
+	issuerName, _, _ := unstructured.NestedString(server.Object, "spec", "issuerRef", "name")
+	assert.Equal(t, "user-issuer", issuerName)
+	assert.Empty(t, server.GetOwnerReferences())
+}
+
+func TestReconcileCertificates_webhookCertificateDoesNotGateWorkloads(t *testing.T) {
+	certmanager.SetCertManagerAPIFound(true)
+	defer certmanager.SetCertManagerAPIFound(false)
+
+	argoCD := makeArgoCD(withIssuerRef(&argoproj.ArgoCDIssuerReference{Name: "my-issuer"}), func(a *argoproj.ArgoCD) {
+		a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+		a.Spec.ApplicationSet.WebhookServer.Ingress.Enabled = true
+	})
+	r := makeReconciler(t, argoCD, argoCD)
+
+	_, err := r.reconcileCertificates(argoCD)
+	assert.NoError(t, err)
+	for _, name := range []string{common.ArgoCDServerTLSSecretName, common.ArgoCDRepoServerTLSSecretName, common.ArgoCDRedisServerTLSSecretName} {
+		setCertificateReady(t, r, name)
+	}
+
+	// the workloads are rolled out while the webhook certificate is still pending, which is reported in the condition
+	ready, err := r.reconcileCertificates(argoCD)
+	assert.NoError(t, err)
+	assert.True(t, ready)
+	condition := meta.FindStatusCondition(argoCD.Status.Conditions, common.ArgoCDConditionCertificatesReady)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Contains(t, condition.Message, common.ArgoCDAppSetWebhookTLSSecretName)
+}
+
+func TestReconcileCertificates_certManagerAPIUnavailable(t *testing.T) {
+	argoCD := makeArgoCD(withIssuerRef(&argoproj.ArgoCDIssuerReference{Name: "my-issuer"}))
+	r := makeReconciler(t, argoCD, argoCD)
+
+	ready, err := r.reconcileCertificates(argoCD)
+	assert.NoError(t, err)
+	assert.True(t, ready)
+}
+
+func TestReconcileRepoService_certManagerOverridesAutoTLS(t *testing.T) {
+	networking.SetRouteAPIFound(true)
+	defer networking.SetRouteAPIFound(false)
+	certmanager.SetCertManagerAPIFound(true)
+	defer certmanager.SetCertManagerAPIFound(false)
+
+	argoCD := makeArgoCD(withIssuerRef(&argoproj.ArgoCDIssuerReference{Name: "my-issuer"}), func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.AutoTLS = "openshift"
+	})
+	r := makeReconciler(t, argoCD, argoCD)
+
+	assert.NoError(t, r.reconcileRepoService(argoCD))
+
+	svc := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: testArgoCDName + "-repo-server", Namespace: testNamespace}, svc))
+	assert.NotContains(t, svc.Annotations, common.ServiceBetaOpenshiftKeyCertSecret)
+}
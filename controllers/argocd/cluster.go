@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/pkg/certmanager"
 	"github.com/argoproj-labs/argocd-operator/pkg/cluster"
 	"github.com/argoproj-labs/argocd-operator/pkg/monitoring"
 	"github.com/argoproj-labs/argocd-operator/pkg/networking"
@@ -11,7 +12,7 @@ import (
 	"github.com/argoproj-labs/argocd-operator/pkg/workloads"
 )
 
-// InspectCluster will verify the availability of extra features on the cluster, such as Prometheus and OpenShift Routes.
+// InspectCluster will verify the availability of extra features on the cluster, such as Prometheus, OpenShift Routes, the Gateway API and cert-manager.
 func InspectCluster() error {
 	var inspectError error
 
@@ -23,6 +24,14 @@ func InspectCluster() error {
 		inspectError = err
 	}
 
+	if err := networking.VerifyGatewayAPI(); err != nil {
+		inspectError = err
+	}
+
+	if err := certmanager.VerifyCertManagerAPI(); err != nil {
+		inspectError = err
+	}
+
 	if err := workloads.VerifyTemplateAPI(); err != nil {
 		inspectError = err
 	}
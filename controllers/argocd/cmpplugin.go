@@ -0,0 +1,221 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/pkg/cluster"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+)
+
+// cmpPluginConfig represents the plugin.yaml of a Config Management Plugin.
+type cmpPluginConfig struct {
+	APIVersion string                                    `json:"apiVersion"`
+	Kind       string                                    `json:"kind"`
+	Metadata   cmpPluginConfigMetadata                   `json:"metadata"`
+	Spec       argoproj.ArgoCDConfigManagementPluginSpec `json:"spec"`
+}
+
+// cmpPluginConfigMetadata represents the metadata of a plugin.yaml.
+type cmpPluginConfigMetadata struct {
+	Name string `json:"name"`
+}
+
+// getCMPPluginConfigMapName returns the name of the ConfigMap holding the plugin.yaml of the plugins of the given ArgoCD.
+func getCMPPluginConfigMapName(cr *argoproj.ArgoCD) string {
+	return util.NameWithSuffix(cr.Name, "cmp-plugins")
+}
+
+// getCMPPluginConfigKey returns the key of the plugin.yaml of the given plugin in the plugin ConfigMap.
+func getCMPPluginConfigKey(plugin argoproj.ArgoCDConfigManagementPlugin) string {
+	return fmt.Sprintf("%s.yaml", plugin.Name)
+}
+
+// getCMPPluginConfig returns the plugin.yaml of the given plugin.
+func getCMPPluginConfig(plugin argoproj.ArgoCDConfigManagementPlugin) (string, error) {
+	config, err := yaml.Marshal(cmpPluginConfig{
+		APIVersion: "argoproj.io/v1alpha1",
+		Kind:       "ConfigManagementPlugin",
+		Metadata:   cmpPluginConfigMetadata{Name: plugin.Name},
+		Spec:       plugin.Spec,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(config), nil
+}
+
+// validateCMPPlugins ensures that the plugins of the given ArgoCD have unique names, an image and a generate command.
+func validateCMPPlugins(cr *argoproj.ArgoCD) error {
+	names := map[string]bool{}
+	for _, plugin := range cr.Spec.Repo.Plugins {
+		if len(plugin.Name) <= 0 {
+			return fmt.Errorf(".spec.repo.plugins must not contain a plugin without a name")
+		}
+		if names[plugin.Name] {
+			return fmt.Errorf(".spec.repo.plugins must not contain more than one plugin named %s", plugin.Name)
+		}
+		names[plugin.Name] = true
+		if len(plugin.Image) <= 0 {
+			return fmt.Errorf("plugin %s must have an image", plugin.Name)
+		}
+		if len(plugin.Spec.Generate.Command) <= 0 {
+			return fmt.Errorf("plugin %s must have a generate command", plugin.Name)
+		}
+	}
+	return nil
+}
+
+// getCMPPluginSecurityContext returns the security context of a plugin sidecar. Outside of OpenShift, the sidecar
+// must run as the same user as the repo server to share the plugin socket.
+func getCMPPluginSecurityContext() *corev1.SecurityContext {
+	securityContext := &corev1.SecurityContext{
+		AllowPrivilegeEscalation: util.BoolPtr(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{
+				"ALL",
+			},
+		},
+		RunAsNonRoot: util.BoolPtr(true),
+	}
+	if !cluster.IsVersionAPIAvailable() {
+		securityContext.RunAsUser = util.Int64Ptr(999)
+	}
+	return securityContext
+}
+
+// getCMPPluginContainers returns the sidecar containers of the repo server for the plugins of the given ArgoCD.
+func getCMPPluginContainers(cr *argoproj.ArgoCD) []corev1.Container {
+	containers := make([]corev1.Container, 0, len(cr.Spec.Repo.Plugins))
+	for _, plugin := range cr.Spec.Repo.Plugins {
+		container := corev1.Container{
+			Name:            plugin.Name,
+			Image:           plugin.Image,
+			ImagePullPolicy: corev1.PullAlways,
+			Command:         []string{"/var/run/argocd/argocd-cmp-server"},
+			Env:             util.EnvMerge(plugin.Env, util.ProxyEnvVars(), false),
+			SecurityContext: getCMPPluginSecurityContext(),
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      "var-files",
+					MountPath: "/var/run/argocd",
+				},
+				{
+					Name:      "plugins",
+					MountPath: "/home/argocd/cmp-server/plugins",
+				},
+				{
+					Name:      fmt.Sprintf("cmp-%s-config", plugin.Name),
+					MountPath: "/home/argocd/cmp-server/config",
+				},
+				{
+					Name:      fmt.Sprintf("cmp-%s-tmp", plugin.Name),
+					MountPath: "/tmp",
+				},
+			},
+		}
+		if plugin.Resources != nil {
+			container.Resources = *plugin.Resources
+		}
+		containers = append(containers, container)
+	}
+	return containers
+}
+
+// getCMPPluginVolumes returns the volumes of the repo server for the plugins of the given ArgoCD.
+func getCMPPluginVolumes(cr *argoproj.ArgoCD) []corev1.Volume {
+	volumes := make([]corev1.Volume, 0, 2*len(cr.Spec.Repo.Plugins))
+	for _, plugin := range cr.Spec.Repo.Plugins {
+		volumes = append(volumes, corev1.Volume{
+			Name: fmt.Sprintf("cmp-%s-config", plugin.Name),
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: getCMPPluginConfigMapName(cr),
+					},
+					Items: []corev1.KeyToPath{
+						{
+							Key:  getCMPPluginConfigKey(plugin),
+							Path: "plugin.yaml",
+						},
+					},
+				},
+			},
+		}, corev1.Volume{
+			Name: fmt.Sprintf("cmp-%s-tmp", plugin.Name),
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+	return volumes
+}
+
+// reconcileCMPPluginConfigMap will ensure that the ConfigMap holding the plugin.yaml of each plugin of the given ArgoCD
+// is present, or removed when there are no plugins. The repo server is rolled out when a plugin.yaml changes, as the
+// plugin sidecars only read it on startup.
+func (r *ArgoCDReconciler) reconcileCMPPluginConfigMap(cr *argoproj.ArgoCD) error {
+	if err := validateCMPPlugins(cr); err != nil {
+		return err
+	}
+
+	cm := newConfigMapWithName(getCMPPluginConfigMapName(cr), cr)
+	exists := util.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm)
+	if len(cr.Spec.Repo.Plugins) == 0 {
+		if exists {
+			return r.Client.Delete(context.TODO(), cm)
+		}
+		return nil // No plugins, nothing to do.
+	}
+
+	data := map[string]string{}
+	for _, plugin := range cr.Spec.Repo.Plugins {
+		config, err := getCMPPluginConfig(plugin)
+		if err != nil {
+			return err
+		}
+		data[getCMPPluginConfigKey(plugin)] = config
+	}
+
+	if !exists {
+		cm.Data = data
+		if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+			return err
+		}
+		return r.Client.Create(context.TODO(), cm)
+	}
+
+	if reflect.DeepEqual(cm.Data, data) {
+		return nil // ConfigMap found with nothing changed, move along...
+	}
+	cm.Data = data
+	if err := r.Client.Update(context.TODO(), cm); err != nil {
+		return err
+	}
+
+	deploy := newDeploymentWithSuffix("repo-server", "repo-server", cr)
+	if !util.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
+		return nil
+	}
+	return r.triggerRollout(deploy, "cmp.config.changed")
+}
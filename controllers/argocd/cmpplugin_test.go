@@ -0,0 +1,140 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestReconcileCMPPluginConfigMap(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.Plugins = []argoproj.ArgoCDConfigManagementPlugin{
+			{
+				Name:  "kustomize-envsubst",
+				Image: "quay.io/example/kustomize-envsubst:v1",
+				Spec: argoproj.ArgoCDConfigManagementPluginSpec{
+					Generate: argoproj.ArgoCDConfigManagementPluginCommand{
+						Command: []string{"sh", "-c"},
+						Args:    []string{"kustomize build . | envsubst"},
+					},
+					Discover: &argoproj.ArgoCDConfigManagementPluginDiscover{
+						FileName: "kustomization.yaml",
+					},
+				},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileCMPPluginConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cmp-plugins", Namespace: a.Namespace}, cm))
+	assert.Equal(t, `apiVersion: argoproj.io/v1alpha1
+kind: ConfigManagementPlugin
+metadata:
+  name: kustomize-envsubst
+spec:
+  discover:
+    fileName: kustomization.yaml
+  generate:
+    args:
+    - kustomize build . | envsubst
+    command:
+    - sh
+    - -c
+`, cm.Data["kustomize-envsubst.yaml"])
+
+	// the ConfigMap is removed along with the last plugin
+	a.Spec.Repo.Plugins = nil
+	assert.NoError(t, r.reconcileCMPPluginConfigMap(a))
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cmp-plugins", Namespace: a.Namespace}, cm))
+}
+
+func TestReconcileCMPPluginConfigMap_invalid(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.Plugins = []argoproj.ArgoCDConfigManagementPlugin{
+			{
+				Name:  "kustomize-envsubst",
+				Image: "quay.io/example/kustomize-envsubst:v1",
+				Spec: argoproj.ArgoCDConfigManagementPluginSpec{
+					Generate: argoproj.ArgoCDConfigManagementPluginCommand{
+						Command: []string{"sh", "-c"},
+						Args:    []string{"kustomize build . | envsubst"},
+					},
+				},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	a.Spec.Repo.Plugins = append(a.Spec.Repo.Plugins, a.Spec.Repo.Plugins[0])
+	assert.EqualError(t, r.reconcileCMPPluginConfigMap(a), ".spec.repo.plugins must not contain more than one plugin named kustomize-envsubst")
+
+	a.Spec.Repo.Plugins = []argoproj.ArgoCDConfigManagementPlugin{{Name: "no-generate", Image: "quay.io/example/plugin:v1"}}
+	assert.EqualError(t, r.reconcileCMPPluginConfigMap(a), "plugin no-generate must have a generate command")
+}
+
+func TestReconcileRepoDeployment_cmpPlugins(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.Plugins = []argoproj.ArgoCDConfigManagementPlugin{
+			{
+				Name:  "kustomize-envsubst",
+				Image: "quay.io/example/kustomize-envsubst:v1",
+				Spec: argoproj.ArgoCDConfigManagementPluginSpec{
+					Generate: argoproj.ArgoCDConfigManagementPluginCommand{
+						Command: []string{"sh", "-c"},
+						Args:    []string{"kustomize build . | envsubst"},
+					},
+				},
+				Resources: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("256Mi"),
+					},
+				},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}, deployment))
+
+	containers := deployment.Spec.Template.Spec.Containers
+	assert.Len(t, containers, 2)
+	sidecar := containers[1]
+	assert.Equal(t, "kustomize-envsubst", sidecar.Name)
+	assert.Equal(t, "quay.io/example/kustomize-envsubst:v1", sidecar.Image)
+	assert.Equal(t, []string{"/var/run/argocd/argocd-cmp-server"}, sidecar.Command)
+	assert.Equal(t, resource.MustParse("256Mi"), sidecar.Resources.Limits[corev1.ResourceMemory])
+	assert.True(t, *sidecar.SecurityContext.RunAsNonRoot)
+	assert.Equal(t, int64(999), *sidecar.SecurityContext.RunAsUser)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "var-files", MountPath: "/var/run/argocd"},
+		{Name: "plugins", MountPath: "/home/argocd/cmp-server/plugins"},
+		{Name: "cmp-kustomize-envsubst-config", MountPath: "/home/argocd/cmp-server/config"},
+		{Name: "cmp-kustomize-envsubst-tmp", MountPath: "/tmp"},
+	}, sidecar.VolumeMounts)
+
+	volumes := map[string]corev1.Volume{}
+	for _, volume := range deployment.Spec.Template.Spec.Volumes {
+		volumes[volume.Name] = volume
+	}
+	assert.Equal(t, "argocd-cmp-plugins", volumes["cmp-kustomize-envsubst-config"].ConfigMap.Name)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "kustomize-envsubst.yaml", Path: "plugin.yaml"}}, volumes["cmp-kustomize-envsubst-config"].ConfigMap.Items)
+	assert.NotNil(t, volumes["cmp-kustomize-envsubst-tmp"].EmptyDir)
+	assert.NotNil(t, volumes["plugins"].EmptyDir)
+}
@@ -131,6 +131,12 @@ func getRBACPolicy(cr *argoproj.ArgoCD) string {
 	if cr.Spec.RBAC.Policy != nil {
 		policy = *cr.Spec.RBAC.Policy
 	}
+	if rules := getRBACPolicyRules(cr); rules != "" {
+		if policy != "" {
+			policy = strings.TrimRight(policy, "\n") + "\n"
+		}
+		policy += rules
+	}
 	return policy
 }
 
@@ -336,6 +342,10 @@ func (r *ArgoCDReconciler) reconcileConfigMaps(cr *argoproj.ArgoCD, useTLSForRed
 		return err
 	}
 
+	if err := r.reconcileCMPPluginConfigMap(cr); err != nil {
+		return err
+	}
+
 	return r.reconcileGPGKeysConfigMap(cr)
 }
 
@@ -343,9 +353,6 @@ func (r *ArgoCDReconciler) reconcileConfigMaps(cr *argoproj.ArgoCD, useTLSForRed
 // This ConfigMap holds the CA Certificate data for client use.
 func (r *ArgoCDReconciler) reconcileCAConfigMap(cr *argoproj.ArgoCD) error {
 	cm := newConfigMapWithName(getCAConfigMapName(cr), cr)
-	if util.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
-		return nil // ConfigMap found, do nothing
-	}
 
 	caSecret := util.NewSecretWithSuffix(cr, common.ArgoCDCASuffix)
 	if !util.IsObjectFound(r.Client, cr.Namespace, caSecret.Name, caSecret) {
@@ -353,8 +360,26 @@ func (r *ArgoCDReconciler) reconcileCAConfigMap(cr *argoproj.ArgoCD) error {
 		return nil
 	}
 
+	// The CA bundle keeps a rotated CA until it expires, fall back to the CA certificate for older secrets.
+	caBundle := string(caSecret.Data[corev1.ServiceAccountRootCAKey])
+	if len(caBundle) <= 0 {
+		caBundle = string(caSecret.Data[corev1.TLSCertKey])
+	}
+
+	existing := newConfigMapWithName(getCAConfigMapName(cr), cr)
+	if util.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+		if existing.Data[corev1.TLSCertKey] == caBundle {
+			return nil // ConfigMap found and up to date, do nothing
+		}
+		if existing.Data == nil {
+			existing.Data = map[string]string{}
+		}
+		existing.Data[corev1.TLSCertKey] = caBundle
+		return r.Client.Update(context.TODO(), existing)
+	}
+
 	cm.Data = map[string]string{
-		corev1.TLSCertKey: string(caSecret.Data[corev1.TLSCertKey]),
+		corev1.TLSCertKey: caBundle,
 	}
 
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
@@ -385,6 +410,13 @@ func (r *ArgoCDReconciler) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 	}
 
 	cm.Data[common.ArgoCDKeyOIDCConfig] = getOIDCConfig(cr)
+	if UseOIDC(cr) {
+		oidcConfig, err := r.getOIDCProviderConfig(cr)
+		if err != nil {
+			return err
+		}
+		cm.Data[common.ArgoCDKeyOIDCConfig] = oidcConfig
+	}
 
 	if c := getResourceHealthChecks(cr); c != nil {
 		for k, v := range c {
@@ -417,16 +449,9 @@ func (r *ArgoCDReconciler) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 
 	// create dex config if dex is enabled through `.spec.sso`
 	if UseDex(cr) {
-		dexConfig := getDexConfig(cr)
-
-		// If no dexConfig expressed but openShiftOAuth is requested through `.spec.sso.dex`, use default
-		// openshift dex config
-		if dexConfig == "" && (cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil && cr.Spec.SSO.Dex.OpenShiftOAuth) {
-			cfg, err := r.getOpenShiftDexConfig(cr)
-			if err != nil {
-				return err
-			}
-			dexConfig = cfg
+		dexConfig, err := r.getDesiredDexConfig(cr)
+		if err != nil {
+			return err
 		}
 		cm.Data[common.ArgoCDKeyDexConfig] = dexConfig
 	}
@@ -558,6 +583,10 @@ func (r *ArgoCDReconciler) reconcileGrafanaDashboards(cr *argoproj.ArgoCD) error
 
 // reconcileRBAC will ensure that the ArgoCD RBAC ConfigMap is present.
 func (r *ArgoCDReconciler) reconcileRBAC(cr *argoproj.ArgoCD) error {
+	if err := r.reconcileRBACPolicyValidation(cr); err != nil {
+		return err
+	}
+
 	cm := newConfigMapWithName(common.ArgoCDRBACConfigMapName, cr)
 	if util.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
 		return r.reconcileRBACConfigMap(cm, cr)
@@ -569,8 +598,8 @@ func (r *ArgoCDReconciler) reconcileRBAC(cr *argoproj.ArgoCD) error {
 func (r *ArgoCDReconciler) reconcileRBACConfigMap(cm *corev1.ConfigMap, cr *argoproj.ArgoCD) error {
 	changed := false
 	// Policy CSV
-	if cr.Spec.RBAC.Policy != nil && cm.Data[common.ArgoCDKeyRBACPolicyCSV] != *cr.Spec.RBAC.Policy {
-		cm.Data[common.ArgoCDKeyRBACPolicyCSV] = *cr.Spec.RBAC.Policy
+	if (cr.Spec.RBAC.Policy != nil || hasRBACPolicyRules(cr)) && cm.Data[common.ArgoCDKeyRBACPolicyCSV] != getRBACPolicy(cr) {
+		cm.Data[common.ArgoCDKeyRBACPolicyCSV] = getRBACPolicy(cr)
 		changed = true
 	}
 
@@ -718,14 +747,64 @@ func (r *ArgoCDReconciler) reconcileTLSCerts(cr *argoproj.ArgoCD) error {
 	return r.Client.Create(context.TODO(), cm)
 }
 
-// reconcileGPGKeysConfigMap creates a gpg-keys config map
+// reconcileGPGKeysConfigMap creates a gpg-keys config map. The keys of the GPG keys set on the ArgoCD are added to
+// the config map and the status of each key is reported. The key IDs added by the operator are tracked in an
+// annotation, so that only those are removed once dropped from the ArgoCD, and keys added by hand are left alone.
 func (r *ArgoCDReconciler) reconcileGPGKeysConfigMap(cr *argoproj.ArgoCD) error {
 	cm := newConfigMapWithName(common.ArgoCDGPGKeysConfigMapName, cr)
-	if util.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
-		return nil
+	found := util.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm)
+
+	managed := getManagedGPGKeyIDs(cm)
+	lastKnown := map[string]string{}
+	for _, keyID := range managed {
+		if armored, ok := cm.Data[keyID]; ok {
+			lastKnown[keyID] = armored
+		}
 	}
-	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+
+	keys, statuses, err := r.getDesiredGPGKeys(cr, lastKnown)
+	if err != nil {
 		return err
 	}
-	return r.Client.Create(context.TODO(), cm)
+
+	data := map[string]string{}
+	for keyID, armored := range cm.Data {
+		data[keyID] = armored
+	}
+	for _, keyID := range managed {
+		if _, ok := keys[keyID]; !ok {
+			delete(data, keyID)
+		}
+	}
+	for keyID, armored := range keys {
+		data[keyID] = armored
+	}
+	if len(data) == 0 {
+		data = nil
+	}
+
+	changed := setManagedGPGKeyIDs(cm, keys)
+	if !reflect.DeepEqual(cm.Data, data) {
+		cm.Data = data
+		changed = true
+	}
+
+	if !found {
+		if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Client.Create(context.TODO(), cm); err != nil {
+			return err
+		}
+	} else if changed {
+		if err := r.Client.Update(context.TODO(), cm); err != nil {
+			return err
+		}
+	}
+
+	if !reflect.DeepEqual(cr.Status.GPGKeys, statuses) {
+		cr.Status.GPGKeys = statuses
+		return r.Client.Status().Update(context.TODO(), cr)
+	}
+	return nil
 }
@@ -7,6 +7,8 @@ import (
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocd/notifications"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -210,3 +212,60 @@ func (r *ArgoCDReconciler) applicationSetSCMTLSConfigMapMapper(o client.Object)
 
 	return result
 }
+
+// getReferencedSecretNames returns the names of the Secrets referenced from the spec of the given ArgoCD, for the
+// typed Dex connectors, the oidc SSO provider, repositories, managed clusters, GPG keys and notifications services.
+func getReferencedSecretNames(cr *argoproj.ArgoCD) []string {
+	names := make([]string, 0)
+	if UseDex(cr) {
+		for _, ref := range getDexConnectorSecretRefs(cr) {
+			names = append(names, ref.Name)
+		}
+	}
+	if UseOIDC(cr) && cr.Spec.SSO.OIDC != nil && cr.Spec.SSO.OIDC.ClientSecret != nil {
+		names = append(names, cr.Spec.SSO.OIDC.ClientSecret.Name)
+	}
+	names = append(names, getRepositoryCredentialsSecretNames(cr)...)
+	names = append(names, getManagedClusterSecretNames(cr)...)
+	names = append(names, getGPGKeySecretNames(cr)...)
+	names = append(names, notifications.GetNotificationsSecretNames(cr)...)
+	return names
+}
+
+// referencedSecretMapper maps a watch event on a Secret referenced from the spec of an ArgoCD back to the ArgoCD
+// object that we want to reconcile.
+func (r *ArgoCDReconciler) referencedSecretMapper(o client.Object) []reconcile.Request {
+	var result = []reconcile.Request{}
+
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), argocds, &client.ListOptions{Namespace: o.GetNamespace()}); err != nil {
+		return result
+	}
+
+	for i := range argocds.Items {
+		argocd := &argocds.Items[i]
+		if util.ContainsString(getReferencedSecretNames(argocd), o.GetName()) {
+			result = append(result, reconcile.Request{NamespacedName: client.ObjectKey{Name: argocd.Name, Namespace: argocd.Namespace}})
+		}
+	}
+	return result
+}
+
+// gpgKeyConfigMapMapper maps a watch event on a ConfigMap referenced by a GPG key back to the ArgoCD object that we
+// want to reconcile.
+func (r *ArgoCDReconciler) gpgKeyConfigMapMapper(o client.Object) []reconcile.Request {
+	var result = []reconcile.Request{}
+
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), argocds, &client.ListOptions{Namespace: o.GetNamespace()}); err != nil {
+		return result
+	}
+
+	for i := range argocds.Items {
+		argocd := &argocds.Items[i]
+		if util.ContainsString(getGPGKeyConfigMapNames(argocd), o.GetName()) {
+			result = append(result, reconcile.Request{NamespacedName: client.ObjectKey{Name: argocd.Name, Namespace: argocd.Namespace}})
+		}
+	}
+	return result
+}
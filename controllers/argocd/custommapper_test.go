@@ -592,3 +592,33 @@ func TestArgoCDReconciler_namespaceResourceMapper(t *testing.T) {
 		})
 	}
 }
+
+func TestArgoCDReconciler_referencedSecretMapper(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repositories = []argoproj.ArgoCDRepository{
+			{Name: "repo", URL: "https://git.example.com/repo.git", CredentialsSecret: &corev1.LocalObjectReference{Name: "repo-creds"}},
+		}
+		a.Spec.Clusters = []argoproj.ArgoCDClusterSpec{
+			{Name: "prod", Server: "https://prod.example.com", BearerTokenSecret: &corev1.LocalObjectReference{Name: "prod-token"}},
+		}
+		a.Spec.GPGKeys = []argoproj.ArgoCDGPGKey{
+			{Name: "signer", SecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "gpg-secret"}, Key: "key"}},
+			{Name: "other", ConfigMapRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "gpg-cm"}, Key: "key"}},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	want := []reconcile.Request{{NamespacedName: types.NamespacedName{Name: a.Name, Namespace: a.Namespace}}}
+	for _, name := range []string{"repo-creds", "prod-token", "gpg-secret"} {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: a.Namespace}}
+		assert.Equal(t, want, r.referencedSecretMapper(secret))
+	}
+
+	// Secrets that are not referenced, or live in another namespace, are ignored.
+	assert.Empty(t, r.referencedSecretMapper(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: a.Namespace}}))
+	assert.Empty(t, r.referencedSecretMapper(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "repo-creds", Namespace: "other"}}))
+
+	// ConfigMaps referenced by the GPG keys are mapped separately.
+	assert.Equal(t, want, r.gpgKeyConfigMapMapper(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "gpg-cm", Namespace: a.Namespace}}))
+	assert.Empty(t, r.gpgKeyConfigMapMapper(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "gpg-secret", Namespace: a.Namespace}}))
+}
@@ -41,15 +41,39 @@ import (
 
 // getArgoCDRepoServerReplicas will return the size value for the argocd-repo-server replica count if it
 // has been set in argocd CR. Otherwise, nil is returned if the replicas is not set in the argocd CR or
-// replicas value is < 0.
+// replicas value is < 0. If Autoscale is enabled, the value for replicas in the argocd CR will be ignored.
 func getArgoCDRepoServerReplicas(cr *argoproj.ArgoCD) *int32 {
-	if cr.Spec.Repo.Replicas != nil && *cr.Spec.Repo.Replicas >= 0 {
+	if !cr.Spec.Repo.Autoscale.Enabled && cr.Spec.Repo.Replicas != nil && *cr.Spec.Repo.Replicas >= 0 {
 		return cr.Spec.Repo.Replicas
 	}
 
 	return nil
 }
 
+// getArgoCDRepoServerTmpVolume will return the volume mounted at /tmp of the argocd-repo-server. An emptyDir without
+// size limit is returned if the volume is not set in the argocd CR.
+func getArgoCDRepoServerTmpVolume(cr *argoproj.ArgoCD) corev1.Volume {
+	volume := corev1.Volume{
+		Name: "tmp",
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+	if cr.Spec.Repo.TmpVolume == nil {
+		return volume
+	}
+	if cr.Spec.Repo.TmpVolume.VolumeClaimTemplate != nil {
+		volume.VolumeSource = corev1.VolumeSource{
+			Ephemeral: &corev1.EphemeralVolumeSource{
+				VolumeClaimTemplate: cr.Spec.Repo.TmpVolume.VolumeClaimTemplate,
+			},
+		}
+		return volume
+	}
+	volume.EmptyDir.SizeLimit = cr.Spec.Repo.TmpVolume.SizeLimit
+	return volume
+}
+
 // getArgoCDServerReplicas will return the size value for the argocd-server replica count if it
 // has been set in argocd CR. Otherwise, nil is returned if the replicas is not set in the argocd CR or
 // replicas value is < 0. If Autoscale is enabled, the value for replicas in the argocd CR will be ignored.
@@ -139,6 +163,10 @@ func getArgoImportContainerEnv(cr *argoprojv1alpha1.ArgoCDExport) []corev1.EnvVa
 				},
 			},
 		})
+	case common.ArgoCDExportStorageBackendAzure:
+		env = append(env, argocdexport.AzureStorageEnv(cr)...)
+	case common.ArgoCDExportStorageBackendGCP:
+		env = append(env, argocdexport.GCPStorageEnv(cr)...)
 	}
 
 	return env
@@ -160,7 +188,7 @@ func getArgoImportContainerImage(cr *argoprojv1alpha1.ArgoCDExport) string {
 }
 
 // getArgoImportVolumeMounts will return the VolumneMounts for the given ArgoCDExport.
-func getArgoImportVolumeMounts() []corev1.VolumeMount {
+func getArgoImportVolumeMounts(cr *argoprojv1alpha1.ArgoCDExport) []corev1.VolumeMount {
 	mounts := make([]corev1.VolumeMount, 0)
 
 	mounts = append(mounts, corev1.VolumeMount{
@@ -173,6 +201,10 @@ func getArgoImportVolumeMounts() []corev1.VolumeMount {
 		MountPath: "/secrets",
 	})
 
+	if _, mount := argocdexport.CredentialsVolume(cr); mount != nil {
+		mounts = append(mounts, *mount)
+	}
+
 	return mounts
 }
 
@@ -207,10 +239,14 @@ func getArgoImportVolumes(cr *argoprojv1alpha1.ArgoCDExport) []corev1.Volume {
 		},
 	})
 
+	if volume, _ := argocdexport.CredentialsVolume(cr); volume != nil {
+		volumes = append(volumes, *volume)
+	}
+
 	return volumes
 }
 
-func getArgoRedisArgs(useTLS bool) []string {
+func getArgoRedisArgs(cr *argoproj.ArgoCD, useTLS bool) []string {
 	args := make([]string, 0)
 
 	args = append(args, "--save", "")
@@ -222,7 +258,12 @@ func getArgoRedisArgs(useTLS bool) []string {
 
 		args = append(args, "--tls-cert-file", "/app/config/redis/tls/tls.crt")
 		args = append(args, "--tls-key-file", "/app/config/redis/tls/tls.key")
-		args = append(args, "--tls-auth-clients", "no")
+		if isRedisClientAuthRequired(cr, useTLS) {
+			args = append(args, "--tls-auth-clients", "yes")
+			args = append(args, "--tls-ca-cert-file", fmt.Sprintf("%s/%s", common.VolumeMountPathRedisClientCA, corev1.ServiceAccountRootCAKey))
+		} else {
+			args = append(args, "--tls-auth-clients", "no")
+		}
 	}
 
 	return args
@@ -235,15 +276,22 @@ func getArgoRepoCommand(cr *argoproj.ArgoCD, useTLSForRedis bool) []string {
 	cmd = append(cmd, "uid_entrypoint.sh")
 	cmd = append(cmd, "argocd-repo-server")
 
-	cmd = append(cmd, "--redis")
-	cmd = append(cmd, getRedisServerAddress(cr))
-
-	if useTLSForRedis {
-		cmd = append(cmd, "--redis-use-tls")
-		if isRedisTLSVerificationDisabled(cr) {
-			cmd = append(cmd, "--redis-insecure-skip-tls-verify")
-		} else {
-			cmd = append(cmd, "--redis-ca-certificate", "/app/config/reposerver/tls/redis/tls.crt")
+	if isRedisExternal(cr) {
+		cmd = append(cmd, getExternalRedisArgs(cr)...)
+	} else {
+		cmd = append(cmd, "--redis")
+		cmd = append(cmd, getRedisServerAddress(cr))
+
+		if useTLSForRedis {
+			cmd = append(cmd, "--redis-use-tls")
+			if isRedisTLSVerificationDisabled(cr) {
+				cmd = append(cmd, "--redis-insecure-skip-tls-verify")
+			} else {
+				cmd = append(cmd, "--redis-ca-certificate", "/app/config/reposerver/tls/redis/tls.crt")
+			}
+			if isRedisClientAuthRequired(cr, useTLSForRedis) {
+				cmd = append(cmd, getRedisClientCertificateArgs(common.VolumeMountPathRepoServerClientTLS)...)
+			}
 		}
 	}
 
@@ -297,15 +345,22 @@ func getArgoServerCommand(cr *argoproj.ArgoCD, useTLSForRedis bool) []string {
 	cmd = append(cmd, "--repo-server")
 	cmd = append(cmd, getRepoServerAddress(cr))
 
-	cmd = append(cmd, "--redis")
-	cmd = append(cmd, getRedisServerAddress(cr))
-
-	if useTLSForRedis {
-		cmd = append(cmd, "--redis-use-tls")
-		if isRedisTLSVerificationDisabled(cr) {
-			cmd = append(cmd, "--redis-insecure-skip-tls-verify")
-		} else {
-			cmd = append(cmd, "--redis-ca-certificate", "/app/config/server/tls/redis/tls.crt")
+	if isRedisExternal(cr) {
+		cmd = append(cmd, getExternalRedisArgs(cr)...)
+	} else {
+		cmd = append(cmd, "--redis")
+		cmd = append(cmd, getRedisServerAddress(cr))
+
+		if useTLSForRedis {
+			cmd = append(cmd, "--redis-use-tls")
+			if isRedisTLSVerificationDisabled(cr) {
+				cmd = append(cmd, "--redis-insecure-skip-tls-verify")
+			} else {
+				cmd = append(cmd, "--redis-ca-certificate", "/app/config/server/tls/redis/tls.crt")
+			}
+			if isRedisClientAuthRequired(cr, useTLSForRedis) {
+				cmd = append(cmd, getRedisClientCertificateArgs(common.VolumeMountPathServerClientTLS)...)
+			}
 		}
 	}
 
@@ -412,17 +467,21 @@ func (r *ArgoCDReconciler) reconcileDeployments(cr *argoproj.ArgoCD, useTLSForRe
 		log.Error(err, "error reconciling dex deployment")
 	}
 
-	err := r.reconcileRedisDeployment(cr, useTLSForRedis)
-	if err != nil {
-		return err
-	}
+	if isRedisExternal(cr) {
+		if err := r.deleteRedisDeployments(cr); err != nil {
+			return err
+		}
+	} else {
+		if err := r.reconcileRedisDeployment(cr, useTLSForRedis); err != nil {
+			return err
+		}
 
-	err = r.reconcileRedisHAProxyDeployment(cr)
-	if err != nil {
-		return err
+		if err := r.reconcileRedisHAProxyDeployment(cr); err != nil {
+			return err
+		}
 	}
 
-	err = r.reconcileRepoDeployment(cr, useTLSForRedis)
+	err := r.reconcileRepoDeployment(cr, useTLSForRedis)
 	if err != nil {
 		return err
 	}
@@ -579,7 +638,7 @@ func (r *ArgoCDReconciler) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS
 	openshift.AddSeccompProfileForOpenShift(cr, &deploy.Spec.Template.Spec, r.Client)
 
 	deploy.Spec.Template.Spec.Containers = []corev1.Container{{
-		Args:            getArgoRedisArgs(useTLS),
+		Args:            getArgoRedisArgs(cr, useTLS),
 		Image:           getRedisContainerImage(cr),
 		ImagePullPolicy: corev1.PullAlways,
 		Name:            "redis",
@@ -621,6 +680,24 @@ func (r *ArgoCDReconciler) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS
 		},
 	}
 
+	if isRedisClientAuthRequired(cr, useTLS) {
+		deploy.Spec.Template.Spec.Containers[0].VolumeMounts = append(deploy.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "argocd-redis-client-ca",
+			MountPath: common.VolumeMountPathRedisClientCA,
+		})
+		deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: "argocd-redis-client-ca",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: util.NewSecretWithSuffix(cr, common.ArgoCDCASuffix).Name,
+					Items: []corev1.KeyToPath{
+						{Key: corev1.ServiceAccountRootCAKey, Path: corev1.ServiceAccountRootCAKey},
+					},
+				},
+			},
+		})
+	}
+
 	if err := applyReconcilerHook(cr, deploy, ""); err != nil {
 		return err
 	}
@@ -646,6 +723,17 @@ func (r *ArgoCDReconciler) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS
 			changed = true
 		}
 
+		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Volumes, existing.Spec.Template.Spec.Volumes) {
+			existing.Spec.Template.Spec.Volumes = deploy.Spec.Template.Spec.Volumes
+			changed = true
+		}
+
+		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].VolumeMounts,
+			existing.Spec.Template.Spec.Containers[0].VolumeMounts) {
+			existing.Spec.Template.Spec.Containers[0].VolumeMounts = deploy.Spec.Template.Spec.Containers[0].VolumeMounts
+			changed = true
+		}
+
 		if !reflect.DeepEqual(existing.Spec.Template.Spec.Containers[0].Env,
 			deploy.Spec.Template.Spec.Containers[0].Env) {
 			existing.Spec.Template.Spec.Containers[0].Env = deploy.Spec.Template.Spec.Containers[0].Env
@@ -896,6 +984,7 @@ func (r *ArgoCDReconciler) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFo
 	repoEnv := cr.Spec.Repo.Env
 	// Environment specified in the CR take precedence over everything else
 	repoEnv = util.EnvMerge(repoEnv, util.ProxyEnvVars(), false)
+	repoEnv = util.EnvMerge(repoEnv, getExternalRedisEnv(cr), true)
 	if cr.Spec.Repo.ExecTimeout != nil {
 		repoEnv = util.EnvMerge(repoEnv, []corev1.EnvVar{{Name: "ARGOCD_EXEC_TIMEOUT", Value: fmt.Sprintf("%ds", *cr.Spec.Repo.ExecTimeout)}}, true)
 	}
@@ -965,6 +1054,14 @@ func (r *ArgoCDReconciler) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFo
 		},
 	}
 
+	if isMutualTLSEnabled(cr) {
+		repoServerVolumeMounts = append(repoServerVolumeMounts, getClientTLSVolumeMount("repo-server", common.VolumeMountPathRepoServerClientTLS))
+	}
+
+	if isExternalRedisCAConfigured(cr) {
+		repoServerVolumeMounts = append(repoServerVolumeMounts, getExternalRedisCAVolumeMount())
+	}
+
 	if cr.Spec.Repo.VolumeMounts != nil {
 		repoServerVolumeMounts = append(repoServerVolumeMounts, cr.Spec.Repo.VolumeMounts...)
 	}
@@ -1019,6 +1116,8 @@ func (r *ArgoCDReconciler) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFo
 		deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, cr.Spec.Repo.SidecarContainers...)
 	}
 
+	deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, getCMPPluginContainers(cr)...)
+
 	repoServerVolumes := []corev1.Volume{
 		{
 			Name: "ssh-known-hosts",
@@ -1056,12 +1155,7 @@ func (r *ArgoCDReconciler) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFo
 				EmptyDir: &corev1.EmptyDirVolumeSource{},
 			},
 		},
-		{
-			Name: "tmp",
-			VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{},
-			},
-		},
+		getArgoCDRepoServerTmpVolume(cr),
 		{
 			Name: "argocd-repo-server-tls",
 			VolumeSource: corev1.VolumeSource{
@@ -1094,6 +1188,16 @@ func (r *ArgoCDReconciler) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFo
 		},
 	}
 
+	if isMutualTLSEnabled(cr) {
+		repoServerVolumes = append(repoServerVolumes, getClientTLSVolume(cr, "repo-server"))
+	}
+
+	if isExternalRedisCAConfigured(cr) {
+		repoServerVolumes = append(repoServerVolumes, getExternalRedisCAVolume(cr))
+	}
+
+	repoServerVolumes = append(repoServerVolumes, getCMPPluginVolumes(cr)...)
+
 	if cr.Spec.Repo.Volumes != nil {
 		repoServerVolumes = append(repoServerVolumes, cr.Spec.Repo.Volumes...)
 	}
@@ -1154,8 +1258,10 @@ func (r *ArgoCDReconciler) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFo
 		}
 
 		if !reflect.DeepEqual(deploy.Spec.Replicas, existing.Spec.Replicas) {
-			existing.Spec.Replicas = deploy.Spec.Replicas
-			changed = true
+			if !cr.Spec.Repo.Autoscale.Enabled {
+				existing.Spec.Replicas = deploy.Spec.Replicas
+				changed = true
+			}
 		}
 
 		if deploy.Spec.Template.Spec.AutomountServiceAccountToken != existing.Spec.Template.Spec.AutomountServiceAccountToken {
@@ -1185,6 +1291,7 @@ func (r *ArgoCDReconciler) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLS
 	deploy := newDeploymentWithSuffix("server", "server", cr)
 	serverEnv := cr.Spec.Server.Env
 	serverEnv = util.EnvMerge(serverEnv, util.ProxyEnvVars(), false)
+	serverEnv = util.EnvMerge(serverEnv, getExternalRedisEnv(cr), true)
 	openshift.AddSeccompProfileForOpenShift(cr, &deploy.Spec.Template.Spec, r.Client)
 	deploy.Spec.Template.Spec.Containers = []corev1.Container{{
 		Command:         getArgoServerCommand(cr, useTLSForRedis),
@@ -1289,6 +1396,18 @@ func (r *ArgoCDReconciler) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLS
 		},
 	}
 
+	if isMutualTLSEnabled(cr) {
+		deploy.Spec.Template.Spec.Containers[0].VolumeMounts = append(deploy.Spec.Template.Spec.Containers[0].VolumeMounts,
+			getClientTLSVolumeMount("server", common.VolumeMountPathServerClientTLS))
+		deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, getClientTLSVolume(cr, "server"))
+	}
+
+	if isExternalRedisCAConfigured(cr) {
+		deploy.Spec.Template.Spec.Containers[0].VolumeMounts = append(deploy.Spec.Template.Spec.Containers[0].VolumeMounts,
+			getExternalRedisCAVolumeMount())
+		deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, getExternalRedisCAVolume(cr))
+	}
+
 	if replicas := getArgoCDServerReplicas(cr); replicas != nil {
 		deploy.Spec.Replicas = replicas
 	}
@@ -247,6 +247,86 @@ func TestArgoCDReconciler_reconcileRepoDeployment_volumes(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Contains(t, deployment.Spec.Template.Spec.Volumes, customVolume)
 	})
+
+	t.Run("create tmp volume with size limit", func(t *testing.T) {
+		logf.SetLogger(ZapLogger(true))
+		sizeLimit := resourcev1.MustParse("10Gi")
+		a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+			a.Spec.Repo.TmpVolume = &argoproj.ArgoCDRepoWorkingVolumeSpec{SizeLimit: &sizeLimit}
+		})
+		r := makeTestReconciler(t, a)
+
+		err := r.reconcileRepoDeployment(a, false)
+		assert.NoError(t, err)
+		deployment := &appsv1.Deployment{}
+		err = r.Client.Get(context.TODO(), types.NamespacedName{
+			Name:      "argocd-repo-server",
+			Namespace: testNamespace,
+		}, deployment)
+		assert.NoError(t, err)
+		assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: "tmp",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: &sizeLimit},
+			},
+		})
+	})
+
+	t.Run("create ephemeral tmp volume", func(t *testing.T) {
+		logf.SetLogger(ZapLogger(true))
+		template := &corev1.PersistentVolumeClaimTemplate{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resourcev1.MustParse("20Gi"),
+					},
+				},
+			},
+		}
+		a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+			a.Spec.Repo.TmpVolume = &argoproj.ArgoCDRepoWorkingVolumeSpec{VolumeClaimTemplate: template}
+		})
+		r := makeTestReconciler(t, a)
+
+		err := r.reconcileRepoDeployment(a, false)
+		assert.NoError(t, err)
+		deployment := &appsv1.Deployment{}
+		err = r.Client.Get(context.TODO(), types.NamespacedName{
+			Name:      "argocd-repo-server",
+			Namespace: testNamespace,
+		}, deployment)
+		assert.NoError(t, err)
+		assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: "tmp",
+			VolumeSource: corev1.VolumeSource{
+				Ephemeral: &corev1.EphemeralVolumeSource{VolumeClaimTemplate: template},
+			},
+		})
+	})
+}
+
+func TestArgoCDReconciler_reconcileRepoDeployment_autoscale(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	var replicas int32 = 2
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.Replicas = &replicas
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+
+	// replicas set by the HorizontalPodAutoscaler are left alone
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	var scaled int32 = 5
+	deployment.Spec.Replicas = &scaled
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+
+	a.Spec.Repo.Autoscale.Enabled = true
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	assert.Equal(t, scaled, *deployment.Spec.Replicas)
 }
 
 func TestArgoCDReconciler_reconcile_ServerDeployment_env(t *testing.T) {
@@ -1309,6 +1389,91 @@ func TestArgoCDReconciler_reconcileRedisDeploymentWithTLS(t *testing.T) {
 	}
 }
 
+func TestArgoCDReconciler_reconcileRedisDeploymentWithMutualTLS(t *testing.T) {
+	cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.TLS.MutualTLS = true
+	})
+	r := makeTestReconciler(t, cr)
+
+	want := []string{
+		"--save", "",
+		"--appendonly", "no",
+		"--tls-port", "6379",
+		"--port", "0",
+		"--tls-cert-file", "/app/config/redis/tls/tls.crt",
+		"--tls-key-file", "/app/config/redis/tls/tls.key",
+		"--tls-auth-clients", "yes",
+		"--tls-ca-cert-file", "/app/config/redis/ca/ca.crt",
+	}
+
+	assert.NoError(t, r.reconcileRedisDeployment(cr, true))
+	d := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-redis", Namespace: cr.Namespace}, d))
+	assert.Equal(t, want, d.Spec.Template.Spec.Containers[0].Args)
+	assert.Contains(t, d.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "argocd-redis-client-ca",
+		MountPath: "/app/config/redis/ca",
+	})
+	assert.Equal(t, cr.Name+"-ca", d.Spec.Template.Spec.Volumes[1].Secret.SecretName)
+
+	// disabling mutual TLS stops requiring client certificates
+	cr.Spec.TLS.MutualTLS = false
+	assert.NoError(t, r.reconcileRedisDeployment(cr, true))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-redis", Namespace: cr.Namespace}, d))
+	assert.Contains(t, strings.Join(d.Spec.Template.Spec.Containers[0].Args, " "), "--tls-auth-clients no")
+	assert.Len(t, d.Spec.Template.Spec.Volumes, 1)
+}
+
+func TestArgoCDComponentCommands_mutualTLS(t *testing.T) {
+	cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.TLS.MutualTLS = true
+	})
+
+	clientArgs := func(dir string) string {
+		return "--redis-client-certificate " + dir + "/tls.crt --redis-client-key " + dir + "/tls.key"
+	}
+
+	server := strings.Join(getArgoServerCommand(cr, true), " ")
+	assert.Contains(t, server, clientArgs("/app/config/server/tls/redis-client"))
+	assert.Contains(t, server, "--repo-server-strict-tls")
+
+	repo := strings.Join(getArgoRepoCommand(cr, true), " ")
+	assert.Contains(t, repo, clientArgs("/app/config/reposerver/tls/redis-client"))
+
+	controller := strings.Join(getArgoApplicationControllerCommand(cr, true), " ")
+	assert.Contains(t, controller, clientArgs("/app/config/controller/tls/redis-client"))
+
+	// Redis does not require client certificates without TLS, or behind HAProxy
+	assert.NotContains(t, strings.Join(getArgoServerCommand(cr, false), " "), "--redis-client-certificate")
+	cr.Spec.HA.Enabled = true
+	assert.NotContains(t, strings.Join(getArgoServerCommand(cr, true), " "), "--redis-client-certificate")
+	assert.Contains(t, strings.Join(getArgoRedisArgs(cr, true), " "), "--tls-auth-clients no")
+}
+
+func TestArgoCDReconciler_reconcileServerDeployment_mutualTLS(t *testing.T) {
+	cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.TLS.MutualTLS = true
+	})
+	r := makeTestReconciler(t, cr)
+
+	assert.NoError(t, r.reconcileServerDeployment(cr, true))
+	d := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-server", Namespace: cr.Namespace}, d))
+	assert.Contains(t, d.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "argocd-server-client-tls",
+		MountPath: "/app/config/server/tls/redis-client",
+	})
+	assert.Contains(t, d.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "argocd-server-client-tls",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: cr.Name + "-server-client-tls",
+				Optional:   util.BoolPtr(true),
+			},
+		},
+	})
+}
+
 func TestArgoCDReconciler_reconcileRedisDeployment(t *testing.T) {
 	// tests reconciler hook for redis deployment
 	cr := makeTestArgoCD()
@@ -100,16 +100,13 @@ func (r *ArgoCDReconciler) getDexOAuthClientSecret(cr *argoproj.ArgoCD) (*string
 // reconcileDexConfiguration will ensure that Dex is configured properly.
 func (r *ArgoCDReconciler) reconcileDexConfiguration(cm *corev1.ConfigMap, cr *argoproj.ArgoCD) error {
 	actual := cm.Data[common.ArgoCDKeyDexConfig]
-	desired := getDexConfig(cr)
+	desired, err := r.getDesiredDexConfig(cr)
+	if err != nil {
+		return err
+	}
 
-	// If no dexConfig expressed but openShiftOAuth is requested through `.spec.sso.dex`, use default
-	// openshift dex config
-	if len(desired) <= 0 && (cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil && cr.Spec.SSO.Dex.OpenShiftOAuth) {
-		cfg, err := r.getOpenShiftDexConfig(cr)
-		if err != nil {
-			return err
-		}
-		desired = cfg
+	if err := r.validateDexConnectorSecrets(cr); err != nil {
+		return err
 	}
 
 	if actual != desired {
@@ -132,8 +129,39 @@ func (r *ArgoCDReconciler) reconcileDexConfiguration(cm *corev1.ConfigMap, cr *a
 	return nil
 }
 
-// getOpenShiftDexConfig will return the configuration for the Dex server running on OpenShift.
-func (r *ArgoCDReconciler) getOpenShiftDexConfig(cr *argoproj.ArgoCD) (string, error) {
+// getDesiredDexConfig will return the Dex configuration for the given ArgoCD. A raw Dex configuration takes
+// precedence, otherwise the configuration is rendered from the OpenShift connector and the typed connectors.
+func (r *ArgoCDReconciler) getDesiredDexConfig(cr *argoproj.ArgoCD) (string, error) {
+	if desired := getDexConfig(cr); len(desired) > 0 {
+		return desired, nil
+	}
+
+	connectors := make([]DexConnector, 0)
+
+	// If no dexConfig expressed but openShiftOAuth is requested through `.spec.sso.dex`, use default
+	// openshift dex connector
+	if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil && cr.Spec.SSO.Dex.OpenShiftOAuth {
+		connectors = append(connectors, r.getOpenShiftDexConnector(cr))
+	}
+
+	if err := validateDexConnectors(getDexConnectors(cr)); err != nil {
+		return "", err
+	}
+	connectors = append(connectors, getTypedDexConnectors(cr)...)
+
+	if len(connectors) <= 0 {
+		return "", nil
+	}
+
+	dex := make(map[string]interface{})
+	dex["connectors"] = connectors
+
+	bytes, err := yaml.Marshal(dex)
+	return string(bytes), err
+}
+
+// getOpenShiftDexConnector will return the connector for the Dex server running on OpenShift.
+func (r *ArgoCDReconciler) getOpenShiftDexConnector(cr *argoproj.ArgoCD) DexConnector {
 
 	groups := []string{}
 
@@ -142,7 +170,7 @@ func (r *ArgoCDReconciler) getOpenShiftDexConfig(cr *argoproj.ArgoCD) (string, e
 		groups = cr.Spec.SSO.Dex.Groups
 	}
 
-	connector := DexConnector{
+	return DexConnector{
 		Type: "openshift",
 		ID:   "openshift",
 		Name: "OpenShift",
@@ -155,15 +183,6 @@ func (r *ArgoCDReconciler) getOpenShiftDexConfig(cr *argoproj.ArgoCD) (string, e
 			"groups":       groups,
 		},
 	}
-
-	connectors := make([]DexConnector, 0)
-	connectors = append(connectors, connector)
-
-	dex := make(map[string]interface{})
-	dex["connectors"] = connectors
-
-	bytes, err := yaml.Marshal(dex)
-	return string(bytes), err
 }
 
 // reconcileDexServiceAccount will ensure that the Dex ServiceAccount is configured properly for OpenShift OAuth.
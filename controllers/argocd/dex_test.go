@@ -9,6 +9,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	resourcev1 "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -804,3 +805,149 @@ func TestArgoCDReconciler_reconcileRoleBinding_dex_disabled(t *testing.T) {
 		})
 	}
 }
+
+func TestArgoCDReconciler_reconcileDexConfiguration_typedConnectors(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+			Dex: &argoproj.ArgoCDDexSpec{
+				Connectors: []argoproj.ArgoCDDexConnector{
+					{
+						ID:   "github",
+						Name: "GitHub",
+						GitHub: &argoproj.ArgoCDDexGitHubConnector{
+							ClientID: "client-id",
+							ClientSecret: corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "github-oauth"},
+								Key:                  "clientSecret",
+							},
+							Orgs: []argoproj.ArgoCDDexGitHubOrg{{Name: "argoproj-labs", Teams: []string{"maintainers"}}},
+						},
+					},
+				},
+			},
+		}
+	})
+	cm := newConfigMapWithName(common.ArgoCDConfigMapName, a)
+	cm.Data = map[string]string{}
+	githubSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-oauth", Namespace: a.Namespace},
+		Data:       map[string][]byte{"clientSecret": []byte("s3cr3t")},
+	}
+
+	r := makeTestReconciler(t, a, cm, githubSecret)
+	assert.NoError(t, r.reconcileDexConfiguration(cm, a))
+
+	want := `connectors:
+- config:
+    clientID: client-id
+    clientSecret: $dex.connector.github.clientSecret
+    orgs:
+    - name: argoproj-labs
+      teams:
+      - maintainers
+  id: github
+  name: GitHub
+  type: github
+`
+	assert.Equal(t, want, cm.Data[common.ArgoCDKeyDexConfig])
+
+	// A missing referenced Secret is reported.
+	assert.NoError(t, r.Client.Delete(context.TODO(), githubSecret))
+	assert.Error(t, r.reconcileDexConfiguration(cm, a))
+}
+
+func TestValidateDexConnectors(t *testing.T) {
+	clientSecret := corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "oauth"},
+		Key:                  "clientSecret",
+	}
+
+	tests := []struct {
+		name       string
+		connectors []argoproj.ArgoCDDexConnector
+		wantErr    bool
+	}{
+		{
+			name: "valid oidc connector",
+			connectors: []argoproj.ArgoCDDexConnector{{ID: "oidc", Name: "OIDC", OIDC: &argoproj.ArgoCDDexOIDCConnector{
+				Issuer: "https://idp.example.com", ClientID: "client-id", ClientSecret: clientSecret,
+			}}},
+		},
+		{
+			name: "missing oidc issuer",
+			connectors: []argoproj.ArgoCDDexConnector{{ID: "oidc", Name: "OIDC", OIDC: &argoproj.ArgoCDDexOIDCConnector{
+				ClientID: "client-id", ClientSecret: clientSecret,
+			}}},
+			wantErr: true,
+		},
+		{
+			name:       "no connector type",
+			connectors: []argoproj.ArgoCDDexConnector{{ID: "none", Name: "None"}},
+			wantErr:    true,
+		},
+		{
+			name: "several connector types",
+			connectors: []argoproj.ArgoCDDexConnector{{ID: "both", Name: "Both",
+				GitHub: &argoproj.ArgoCDDexGitHubConnector{ClientID: "client-id", ClientSecret: clientSecret},
+				GitLab: &argoproj.ArgoCDDexGitLabConnector{ClientID: "client-id", ClientSecret: clientSecret},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate ids",
+			connectors: []argoproj.ArgoCDDexConnector{
+				{ID: "gitlab", Name: "GitLab", GitLab: &argoproj.ArgoCDDexGitLabConnector{ClientID: "client-id", ClientSecret: clientSecret}},
+				{ID: "gitlab", Name: "GitLab", GitLab: &argoproj.ArgoCDDexGitLabConnector{ClientID: "client-id", ClientSecret: clientSecret}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "saml without ca",
+			connectors: []argoproj.ArgoCDDexConnector{{ID: "saml", Name: "SAML", SAML: &argoproj.ArgoCDDexSAMLConnector{
+				SSOURL: "https://idp.example.com/sso", UsernameAttr: "name", EmailAttr: "email",
+			}}},
+			wantErr: true,
+		},
+		{
+			name: "valid ldap connector",
+			connectors: []argoproj.ArgoCDDexConnector{{ID: "ldap", Name: "LDAP", LDAP: &argoproj.ArgoCDDexLDAPConnector{
+				Host:       "ldap.example.com:636",
+				UserSearch: argoproj.ArgoCDDexLDAPUserSearch{BaseDN: "ou=people,dc=example,dc=com", Username: "uid"},
+			}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateDexConnectors(test.connectors)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSyncDexConnectorSecrets(t *testing.T) {
+	secret := &corev1.Secret{Data: map[string][]byte{
+		common.ArgoCDKeyServerSecretKey:     []byte("key"),
+		"dex.connector.old.clientSecret":    []byte("old"),
+		"dex.connector.github.clientSecret": []byte("s3cr3t"),
+	}}
+
+	assert.False(t, syncDexConnectorSecrets(secret, map[string][]byte{
+		"dex.connector.old.clientSecret":    []byte("old"),
+		"dex.connector.github.clientSecret": []byte("s3cr3t"),
+	}))
+
+	assert.True(t, syncDexConnectorSecrets(secret, map[string][]byte{
+		"dex.connector.github.clientSecret": []byte("rotated"),
+	}))
+	assert.Equal(t, map[string][]byte{
+		common.ArgoCDKeyServerSecretKey:     []byte("key"),
+		"dex.connector.github.clientSecret": []byte("rotated"),
+	}, secret.Data)
+}
@@ -0,0 +1,348 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+)
+
+// getDexConnectors returns the typed Dex connectors of the given ArgoCD.
+func getDexConnectors(cr *argoproj.ArgoCD) []argoproj.ArgoCDDexConnector {
+	if cr.Spec.SSO == nil || cr.Spec.SSO.Dex == nil {
+		return nil
+	}
+	return cr.Spec.SSO.Dex.Connectors
+}
+
+// getDexConnectorSecretKey returns the key of the Argo CD secret holding the given secret field of a Dex connector.
+func getDexConnectorSecretKey(connectorID, field string) string {
+	return fmt.Sprintf("%s%s.%s", common.ArgoCDDexConnectorSecretKeyPrefix, connectorID, field)
+}
+
+// getDexConnectorSecretRefs returns the Secret keys referenced by the typed Dex connectors, indexed by the key of the
+// Argo CD secret they are copied to.
+func getDexConnectorSecretRefs(cr *argoproj.ArgoCD) map[string]corev1.SecretKeySelector {
+	refs := map[string]corev1.SecretKeySelector{}
+	for _, connector := range getDexConnectors(cr) {
+		switch {
+		case connector.GitHub != nil:
+			refs[getDexConnectorSecretKey(connector.ID, "clientSecret")] = connector.GitHub.ClientSecret
+		case connector.GitLab != nil:
+			refs[getDexConnectorSecretKey(connector.ID, "clientSecret")] = connector.GitLab.ClientSecret
+		case connector.LDAP != nil:
+			if connector.LDAP.BindPW != nil {
+				refs[getDexConnectorSecretKey(connector.ID, "bindPW")] = *connector.LDAP.BindPW
+			}
+		case connector.Microsoft != nil:
+			refs[getDexConnectorSecretKey(connector.ID, "clientSecret")] = connector.Microsoft.ClientSecret
+		case connector.OIDC != nil:
+			refs[getDexConnectorSecretKey(connector.ID, "clientSecret")] = connector.OIDC.ClientSecret
+		}
+	}
+	return refs
+}
+
+// getDexConnectorType returns the Dex type of the given connector, or an empty string if not exactly one connector
+// type is set.
+func getDexConnectorType(connector argoproj.ArgoCDDexConnector) string {
+	types := []string{}
+	if connector.GitHub != nil {
+		types = append(types, "github")
+	}
+	if connector.GitLab != nil {
+		types = append(types, "gitlab")
+	}
+	if connector.LDAP != nil {
+		types = append(types, "ldap")
+	}
+	if connector.Microsoft != nil {
+		types = append(types, "microsoft")
+	}
+	if connector.OIDC != nil {
+		types = append(types, "oidc")
+	}
+	if connector.SAML != nil {
+		types = append(types, "saml")
+	}
+	if len(types) != 1 {
+		return ""
+	}
+	return types[0]
+}
+
+// validateDexConnectors returns an error if a typed Dex connector is missing a required field.
+func validateDexConnectors(connectors []argoproj.ArgoCDDexConnector) error {
+	ids := map[string]bool{}
+	for i, connector := range connectors {
+		field := fmt.Sprintf(".spec.sso.dex.connectors[%d]", i)
+		if len(connector.ID) <= 0 || len(connector.Name) <= 0 {
+			return fmt.Errorf("%s: id and name are required", field)
+		}
+		if ids[connector.ID] {
+			return fmt.Errorf("%s: duplicate connector id %s", field, connector.ID)
+		}
+		ids[connector.ID] = true
+
+		missing := []string{}
+		require := func(name, value string) {
+			if len(value) <= 0 {
+				missing = append(missing, name)
+			}
+		}
+
+		switch getDexConnectorType(connector) {
+		case "github":
+			require("github.clientID", connector.GitHub.ClientID)
+			require("github.clientSecret.name", connector.GitHub.ClientSecret.Name)
+			require("github.clientSecret.key", connector.GitHub.ClientSecret.Key)
+		case "gitlab":
+			require("gitlab.clientID", connector.GitLab.ClientID)
+			require("gitlab.clientSecret.name", connector.GitLab.ClientSecret.Name)
+			require("gitlab.clientSecret.key", connector.GitLab.ClientSecret.Key)
+		case "ldap":
+			require("ldap.host", connector.LDAP.Host)
+			require("ldap.userSearch.baseDN", connector.LDAP.UserSearch.BaseDN)
+			require("ldap.userSearch.username", connector.LDAP.UserSearch.Username)
+			if connector.LDAP.BindPW != nil {
+				require("ldap.bindDN", connector.LDAP.BindDN)
+				require("ldap.bindPW.name", connector.LDAP.BindPW.Name)
+				require("ldap.bindPW.key", connector.LDAP.BindPW.Key)
+			}
+			if connector.LDAP.GroupSearch != nil {
+				require("ldap.groupSearch.baseDN", connector.LDAP.GroupSearch.BaseDN)
+				require("ldap.groupSearch.userAttr", connector.LDAP.GroupSearch.UserAttr)
+				require("ldap.groupSearch.groupAttr", connector.LDAP.GroupSearch.GroupAttr)
+				require("ldap.groupSearch.nameAttr", connector.LDAP.GroupSearch.NameAttr)
+			}
+		case "microsoft":
+			require("microsoft.clientID", connector.Microsoft.ClientID)
+			require("microsoft.clientSecret.name", connector.Microsoft.ClientSecret.Name)
+			require("microsoft.clientSecret.key", connector.Microsoft.ClientSecret.Key)
+			if len(connector.Microsoft.Groups) > 0 {
+				require("microsoft.tenant", connector.Microsoft.Tenant)
+			}
+		case "oidc":
+			require("oidc.issuer", connector.OIDC.Issuer)
+			require("oidc.clientID", connector.OIDC.ClientID)
+			require("oidc.clientSecret.name", connector.OIDC.ClientSecret.Name)
+			require("oidc.clientSecret.key", connector.OIDC.ClientSecret.Key)
+		case "saml":
+			require("saml.ssoURL", connector.SAML.SSOURL)
+			require("saml.usernameAttr", connector.SAML.UsernameAttr)
+			require("saml.emailAttr", connector.SAML.EmailAttr)
+			if !connector.SAML.InsecureSkipSignatureValidation {
+				require("saml.ca", connector.SAML.CA)
+			}
+		default:
+			return fmt.Errorf("%s: exactly one of github, gitlab, ldap, microsoft, oidc or saml must be set", field)
+		}
+
+		if len(missing) > 0 {
+			return fmt.Errorf("%s: missing required fields %s", field, strings.Join(missing, ", "))
+		}
+	}
+	return nil
+}
+
+// setIfNotEmpty sets the given value in the Dex connector config, unless it is empty.
+func setIfNotEmpty(config map[string]interface{}, key string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		if len(v) <= 0 {
+			return
+		}
+	case bool:
+		if !v {
+			return
+		}
+	case []string:
+		if len(v) <= 0 {
+			return
+		}
+	}
+	config[key] = value
+}
+
+// getDexConnectorConfig returns the Dex configuration of the given typed connector. Secrets are referenced from the
+// Argo CD secret, and the redirect URI is set by Argo CD.
+func getDexConnectorConfig(connector argoproj.ArgoCDDexConnector) map[string]interface{} {
+	config := map[string]interface{}{}
+	clientSecret := "$" + getDexConnectorSecretKey(connector.ID, "clientSecret")
+
+	switch {
+	case connector.GitHub != nil:
+		config["clientID"] = connector.GitHub.ClientID
+		config["clientSecret"] = clientSecret
+		setIfNotEmpty(config, "hostName", connector.GitHub.HostName)
+		setIfNotEmpty(config, "loadAllGroups", connector.GitHub.LoadAllGroups)
+		setIfNotEmpty(config, "teamNameField", connector.GitHub.TeamNameField)
+		if len(connector.GitHub.Orgs) > 0 {
+			orgs := []map[string]interface{}{}
+			for _, org := range connector.GitHub.Orgs {
+				o := map[string]interface{}{"name": org.Name}
+				setIfNotEmpty(o, "teams", org.Teams)
+				orgs = append(orgs, o)
+			}
+			config["orgs"] = orgs
+		}
+	case connector.GitLab != nil:
+		config["clientID"] = connector.GitLab.ClientID
+		config["clientSecret"] = clientSecret
+		setIfNotEmpty(config, "baseURL", connector.GitLab.BaseURL)
+		setIfNotEmpty(config, "groups", connector.GitLab.Groups)
+		setIfNotEmpty(config, "useLoginAsID", connector.GitLab.UseLoginAsID)
+	case connector.LDAP != nil:
+		ldap := connector.LDAP
+		config["host"] = ldap.Host
+		setIfNotEmpty(config, "insecureNoSSL", ldap.InsecureNoSSL)
+		setIfNotEmpty(config, "insecureSkipVerify", ldap.InsecureSkipVerify)
+		setIfNotEmpty(config, "startTLS", ldap.StartTLS)
+		setIfNotEmpty(config, "bindDN", ldap.BindDN)
+		if ldap.BindPW != nil {
+			config["bindPW"] = "$" + getDexConnectorSecretKey(connector.ID, "bindPW")
+		}
+		setIfNotEmpty(config, "usernamePrompt", ldap.UsernamePrompt)
+
+		userSearch := map[string]interface{}{
+			"baseDN":   ldap.UserSearch.BaseDN,
+			"username": ldap.UserSearch.Username,
+		}
+		setIfNotEmpty(userSearch, "filter", ldap.UserSearch.Filter)
+		setIfNotEmpty(userSearch, "idAttr", ldap.UserSearch.IDAttr)
+		setIfNotEmpty(userSearch, "emailAttr", ldap.UserSearch.EmailAttr)
+		setIfNotEmpty(userSearch, "nameAttr", ldap.UserSearch.NameAttr)
+		config["userSearch"] = userSearch
+
+		if ldap.GroupSearch != nil {
+			groupSearch := map[string]interface{}{
+				"baseDN": ldap.GroupSearch.BaseDN,
+				"userMatchers": []map[string]interface{}{{
+					"userAttr":  ldap.GroupSearch.UserAttr,
+					"groupAttr": ldap.GroupSearch.GroupAttr,
+				}},
+				"nameAttr": ldap.GroupSearch.NameAttr,
+			}
+			setIfNotEmpty(groupSearch, "filter", ldap.GroupSearch.Filter)
+			config["groupSearch"] = groupSearch
+		}
+	case connector.Microsoft != nil:
+		config["clientID"] = connector.Microsoft.ClientID
+		config["clientSecret"] = clientSecret
+		setIfNotEmpty(config, "tenant", connector.Microsoft.Tenant)
+		setIfNotEmpty(config, "groups", connector.Microsoft.Groups)
+		setIfNotEmpty(config, "onlySecurityGroups", connector.Microsoft.OnlySecurityGroups)
+	case connector.OIDC != nil:
+		config["issuer"] = connector.OIDC.Issuer
+		config["clientID"] = connector.OIDC.ClientID
+		config["clientSecret"] = clientSecret
+		setIfNotEmpty(config, "scopes", connector.OIDC.Scopes)
+		setIfNotEmpty(config, "getUserInfo", connector.OIDC.GetUserInfo)
+		setIfNotEmpty(config, "insecureEnableGroups", connector.OIDC.InsecureEnableGroups)
+		setIfNotEmpty(config, "userNameKey", connector.OIDC.UserNameKey)
+	case connector.SAML != nil:
+		config["ssoURL"] = connector.SAML.SSOURL
+		if len(connector.SAML.CA) > 0 {
+			config["caData"] = base64.StdEncoding.EncodeToString([]byte(connector.SAML.CA))
+		}
+		setIfNotEmpty(config, "insecureSkipSignatureValidation", connector.SAML.InsecureSkipSignatureValidation)
+		setIfNotEmpty(config, "entityIssuer", connector.SAML.EntityIssuer)
+		setIfNotEmpty(config, "ssoIssuer", connector.SAML.SSOIssuer)
+		config["usernameAttr"] = connector.SAML.UsernameAttr
+		config["emailAttr"] = connector.SAML.EmailAttr
+		setIfNotEmpty(config, "groupsAttr", connector.SAML.GroupsAttr)
+		setIfNotEmpty(config, "nameIDPolicyFormat", connector.SAML.NameIDPolicyFormat)
+	}
+	return config
+}
+
+// getTypedDexConnectors returns the Dex connectors rendered from the typed connectors of the given ArgoCD.
+func getTypedDexConnectors(cr *argoproj.ArgoCD) []DexConnector {
+	connectors := []DexConnector{}
+	for _, connector := range getDexConnectors(cr) {
+		connectors = append(connectors, DexConnector{
+			ID:     connector.ID,
+			Name:   connector.Name,
+			Type:   getDexConnectorType(connector),
+			Config: getDexConnectorConfig(connector),
+		})
+	}
+	return connectors
+}
+
+// getDexConnectorSecretData returns the values of the Secret keys referenced by the typed Dex connectors, indexed by
+// the key of the Argo CD secret they are copied to. Missing Secrets or keys are skipped, and reported by the Dex
+// configuration reconciliation.
+func (r *ArgoCDReconciler) getDexConnectorSecretData(cr *argoproj.ArgoCD) map[string][]byte {
+	data := map[string][]byte{}
+	for key, ref := range getDexConnectorSecretRefs(cr) {
+		secret := util.NewSecretWithName(cr, ref.Name)
+		if !util.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+			log.Info(fmt.Sprintf("secret %s referenced by dex connector not found", ref.Name))
+			continue
+		}
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			log.Info(fmt.Sprintf("secret %s referenced by dex connector has no key %s", ref.Name, ref.Key))
+			continue
+		}
+		data[key] = value
+	}
+	return data
+}
+
+// validateDexConnectorSecrets returns an error if a non-optional Secret key referenced by a typed Dex connector does
+// not exist.
+func (r *ArgoCDReconciler) validateDexConnectorSecrets(cr *argoproj.ArgoCD) error {
+	for _, ref := range getDexConnectorSecretRefs(cr) {
+		if ref.Optional != nil && *ref.Optional {
+			continue
+		}
+		secret := util.NewSecretWithName(cr, ref.Name)
+		if !util.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+			return fmt.Errorf("secret %s referenced by dex connector not found", ref.Name)
+		}
+		if _, ok := secret.Data[ref.Key]; !ok {
+			return fmt.Errorf("secret %s referenced by dex connector has no key %s", ref.Name, ref.Key)
+		}
+	}
+	return nil
+}
+
+// syncDexConnectorSecrets copies the given Dex connector secrets into the Argo CD secret, and removes the ones that
+// are not referenced anymore. It returns true if the Argo CD secret has changed.
+func syncDexConnectorSecrets(secret *corev1.Secret, desired map[string][]byte) bool {
+	changed := false
+	for key := range secret.Data {
+		if _, ok := desired[key]; !ok && strings.HasPrefix(key, common.ArgoCDDexConnectorSecretKeyPrefix) {
+			delete(secret.Data, key)
+			changed = true
+		}
+	}
+	for key, value := range desired {
+		if string(secret.Data[key]) != string(value) {
+			secret.Data[key] = value
+			changed = true
+		}
+	}
+	return changed
+}
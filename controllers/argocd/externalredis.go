@@ -0,0 +1,182 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+)
+
+const (
+	externalRedisCAVolumeName = "argocd-redis-external-ca"
+	externalRedisCAMountPath  = "/app/config/redis-external"
+	externalRedisCAFileName   = "ca.crt"
+)
+
+// isRedisExternal returns true if the Argo CD components should use an externally managed Redis instead of the one
+// reconciled by the operator.
+func isRedisExternal(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Redis.External != nil
+}
+
+// validateExternalRedis ensures that the external Redis of the given ArgoCD, if any, has an address or Sentinels.
+func validateExternalRedis(cr *argoproj.ArgoCD) error {
+	if !isRedisExternal(cr) {
+		return nil
+	}
+	external := cr.Spec.Redis.External
+	if len(external.Address) == 0 && (external.Sentinel == nil || len(external.Sentinel.Addresses) == 0) {
+		return fmt.Errorf(".spec.redis.external must set either address or sentinel.addresses")
+	}
+	return nil
+}
+
+// getExternalRedisArgs returns the command line arguments pointing an Argo CD component to the external Redis.
+func getExternalRedisArgs(cr *argoproj.ArgoCD) []string {
+	external := cr.Spec.Redis.External
+	args := make([]string, 0)
+
+	if external.Sentinel != nil && len(external.Sentinel.Addresses) > 0 {
+		for _, addr := range external.Sentinel.Addresses {
+			args = append(args, "--sentinel", addr)
+		}
+		masterName := external.Sentinel.MasterName
+		if len(masterName) == 0 {
+			masterName = common.ArgoCDDefaultRedisSentinelMaster
+		}
+		args = append(args, "--sentinelmaster", masterName)
+	} else {
+		args = append(args, "--redis", external.Address)
+	}
+
+	if external.TLS != nil {
+		args = append(args, "--redis-use-tls")
+		if external.TLS.InsecureSkipVerify {
+			args = append(args, "--redis-insecure-skip-tls-verify")
+		} else if external.TLS.CASecret != nil {
+			args = append(args, "--redis-ca-certificate", filepath.Join(externalRedisCAMountPath, externalRedisCAFileName))
+		}
+	}
+
+	return args
+}
+
+// getExternalRedisEnv returns the environment variables carrying the credentials of the external Redis and of its
+// Sentinels.
+func getExternalRedisEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
+	env := make([]corev1.EnvVar, 0)
+	if !isRedisExternal(cr) {
+		return env
+	}
+
+	external := cr.Spec.Redis.External
+	if len(external.Username) > 0 {
+		env = append(env, corev1.EnvVar{
+			Name:  "REDIS_USERNAME",
+			Value: external.Username,
+		})
+	}
+	if external.PasswordSecret != nil {
+		env = append(env, corev1.EnvVar{
+			Name: "REDIS_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: external.PasswordSecret,
+			},
+		})
+	}
+	if external.Sentinel != nil && len(external.Sentinel.Addresses) > 0 {
+		if len(external.Sentinel.Username) > 0 {
+			env = append(env, corev1.EnvVar{
+				Name:  "REDIS_SENTINEL_USERNAME",
+				Value: external.Sentinel.Username,
+			})
+		}
+		if external.Sentinel.PasswordSecret != nil {
+			env = append(env, corev1.EnvVar{
+				Name: "REDIS_SENTINEL_PASSWORD",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: external.Sentinel.PasswordSecret,
+				},
+			})
+		}
+	}
+	return env
+}
+
+// isExternalRedisCAConfigured returns true if a CA certificate has been provided to verify the external Redis.
+func isExternalRedisCAConfigured(cr *argoproj.ArgoCD) bool {
+	return isRedisExternal(cr) && cr.Spec.Redis.External.TLS != nil &&
+		!cr.Spec.Redis.External.TLS.InsecureSkipVerify && cr.Spec.Redis.External.TLS.CASecret != nil
+}
+
+// getExternalRedisCAVolume returns the Volume holding the CA certificate of the external Redis.
+func getExternalRedisCAVolume(cr *argoproj.ArgoCD) corev1.Volume {
+	caSecret := cr.Spec.Redis.External.TLS.CASecret
+	return corev1.Volume{
+		Name: externalRedisCAVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: caSecret.Name,
+				Items: []corev1.KeyToPath{
+					{
+						Key:  caSecret.Key,
+						Path: externalRedisCAFileName,
+					},
+				},
+				Optional: caSecret.Optional,
+			},
+		},
+	}
+}
+
+// getExternalRedisCAVolumeMount returns the VolumeMount of the CA certificate of the external Redis.
+func getExternalRedisCAVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      externalRedisCAVolumeName,
+		MountPath: externalRedisCAMountPath,
+		ReadOnly:  true,
+	}
+}
+
+// deleteRedisDeployments will remove the operator managed Redis Deployments, if present.
+func (r *ArgoCDReconciler) deleteRedisDeployments(cr *argoproj.ArgoCD) error {
+	for _, suffix := range []string{"redis", "redis-ha-haproxy"} {
+		if err := r.deleteIfFound(cr, newDeploymentWithSuffix(suffix, "redis", cr)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteRedisStatefulSet will remove the operator managed Redis HA StatefulSet, if present.
+func (r *ArgoCDReconciler) deleteRedisStatefulSet(cr *argoproj.ArgoCD) error {
+	return r.deleteIfFound(cr, newStatefulSetWithSuffix("redis-ha-server", "redis", cr))
+}
+
+// deleteRedisServices will remove the operator managed Redis Services, if present.
+func (r *ArgoCDReconciler) deleteRedisServices(cr *argoproj.ArgoCD) error {
+	suffixes := []string{"redis", "redis-ha", "redis-ha-haproxy"}
+	for i := int32(0); i < common.ArgoCDDefaultRedisHAReplicas; i++ {
+		suffixes = append(suffixes, fmt.Sprintf("redis-ha-announce-%d", i))
+	}
+	for _, suffix := range suffixes {
+		if err := r.deleteIfFound(cr, newServiceWithSuffix(suffix, "redis", cr)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ArgoCDReconciler) deleteIfFound(cr *argoproj.ArgoCD, obj client.Object) error {
+	if !util.IsObjectFound(r.Client, cr.Namespace, obj.GetName(), obj) {
+		return nil
+	}
+	log.Info(fmt.Sprintf("deleting %s as an external redis is configured", obj.GetName()))
+	return r.Client.Delete(context.TODO(), obj)
+}
@@ -0,0 +1,183 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func externalRedis(external *argoproj.ArgoCDRedisExternalSpec) argoCDOpt {
+	return func(a *argoproj.ArgoCD) {
+		a.Spec.Redis.External = external
+	}
+}
+
+func TestGetExternalRedisArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		external *argoproj.ArgoCDRedisExternalSpec
+		want     []string
+	}{
+		{
+			name:     "address only",
+			external: &argoproj.ArgoCDRedisExternalSpec{Address: "redis.example.com:6379"},
+			want:     []string{"--redis", "redis.example.com:6379"},
+		},
+		{
+			name: "tls with ca",
+			external: &argoproj.ArgoCDRedisExternalSpec{
+				Address: "redis.example.com:6380",
+				TLS: &argoproj.ArgoCDRedisExternalTLSSpec{
+					CASecret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "redis-ca"},
+						Key:                  "ca.pem",
+					},
+				},
+			},
+			want: []string{"--redis", "redis.example.com:6380", "--redis-use-tls", "--redis-ca-certificate", "/app/config/redis-external/ca.crt"},
+		},
+		{
+			name: "tls without verification",
+			external: &argoproj.ArgoCDRedisExternalSpec{
+				Address: "redis.example.com:6380",
+				TLS:     &argoproj.ArgoCDRedisExternalTLSSpec{InsecureSkipVerify: true},
+			},
+			want: []string{"--redis", "redis.example.com:6380", "--redis-use-tls", "--redis-insecure-skip-tls-verify"},
+		},
+		{
+			name: "sentinel",
+			external: &argoproj.ArgoCDRedisExternalSpec{
+				Address: "ignored:6379",
+				Sentinel: &argoproj.ArgoCDRedisSentinelSpec{
+					Addresses: []string{"sentinel-0:26379", "sentinel-1:26379"},
+				},
+			},
+			want: []string{"--sentinel", "sentinel-0:26379", "--sentinel", "sentinel-1:26379", "--sentinelmaster", "master"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cr := makeTestArgoCD(externalRedis(test.external))
+			assert.Equal(t, test.want, getExternalRedisArgs(cr))
+		})
+	}
+}
+
+func TestValidateExternalRedis(t *testing.T) {
+	assert.NoError(t, validateExternalRedis(makeTestArgoCD()))
+	assert.NoError(t, validateExternalRedis(makeTestArgoCD(externalRedis(&argoproj.ArgoCDRedisExternalSpec{Address: "redis.example.com:6379"}))))
+	assert.NoError(t, validateExternalRedis(makeTestArgoCD(externalRedis(&argoproj.ArgoCDRedisExternalSpec{
+		Sentinel: &argoproj.ArgoCDRedisSentinelSpec{Addresses: []string{"sentinel-0:26379"}},
+	}))))
+
+	for _, external := range []*argoproj.ArgoCDRedisExternalSpec{
+		{},
+		{Sentinel: &argoproj.ArgoCDRedisSentinelSpec{}},
+	} {
+		assert.EqualError(t, validateExternalRedis(makeTestArgoCD(externalRedis(external))), ".spec.redis.external must set either address or sentinel.addresses")
+	}
+}
+
+func TestGetExternalRedisEnv_sentinel(t *testing.T) {
+	passwordSecret := &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "sentinel-auth"},
+		Key:                  "password",
+	}
+	cr := makeTestArgoCD(externalRedis(&argoproj.ArgoCDRedisExternalSpec{
+		Username: "argocd",
+		Sentinel: &argoproj.ArgoCDRedisSentinelSpec{
+			Addresses:      []string{"sentinel-0:26379"},
+			Username:       "sentinel",
+			PasswordSecret: passwordSecret,
+		},
+	}))
+
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "REDIS_USERNAME", Value: "argocd"},
+		{Name: "REDIS_SENTINEL_USERNAME", Value: "sentinel"},
+		{Name: "REDIS_SENTINEL_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: passwordSecret}},
+	}, getExternalRedisEnv(cr))
+}
+
+func TestArgoCDReconciler_reconcileStatusRedis_externalRedis(t *testing.T) {
+	cr := makeTestArgoCD(externalRedis(&argoproj.ArgoCDRedisExternalSpec{Address: "redis.example.com:6379"}))
+	r := makeTestReconciler(t, cr)
+
+	assert.NoError(t, r.reconcileStatusRedis(cr))
+	assert.Equal(t, "Running", cr.Status.Redis)
+
+	cr.Spec.Redis.External.Address = ""
+	assert.NoError(t, r.reconcileStatusRedis(cr))
+	assert.Equal(t, "Failed", cr.Status.Redis)
+}
+
+func TestGetArgoComponentCommands_externalRedis(t *testing.T) {
+	cr := makeTestArgoCD(externalRedis(&argoproj.ArgoCDRedisExternalSpec{Address: "redis.example.com:6379"}))
+
+	for _, cmd := range [][]string{
+		getArgoRepoCommand(cr, true),
+		getArgoServerCommand(cr, true),
+		getArgoApplicationControllerCommand(cr, true),
+	} {
+		assert.Contains(t, cmd, "redis.example.com:6379")
+		assert.NotContains(t, cmd, getRedisServerAddress(cr))
+		assert.NotContains(t, cmd, "--redis-use-tls")
+	}
+}
+
+func TestArgoCDReconciler_reconcileRepoDeployment_externalRedis(t *testing.T) {
+	cr := makeTestArgoCD(externalRedis(&argoproj.ArgoCDRedisExternalSpec{
+		Address:  "redis.example.com:6379",
+		Username: "argocd",
+		PasswordSecret: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "redis-auth"},
+			Key:                  "password",
+		},
+		TLS: &argoproj.ArgoCDRedisExternalTLSSpec{
+			CASecret: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "redis-ca"},
+				Key:                  "ca.pem",
+			},
+		},
+	}))
+	r := makeTestReconciler(t, cr)
+
+	assert.NoError(t, r.reconcileRepoDeployment(cr, false))
+	d := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-repo-server", Namespace: cr.Namespace}, d))
+
+	container := d.Spec.Template.Spec.Containers[0]
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "REDIS_USERNAME", Value: "argocd"})
+	assert.Contains(t, container.Env, corev1.EnvVar{
+		Name:      "REDIS_PASSWORD",
+		ValueFrom: &corev1.EnvVarSource{SecretKeyRef: cr.Spec.Redis.External.PasswordSecret},
+	})
+	assert.Contains(t, container.VolumeMounts, getExternalRedisCAVolumeMount())
+	assert.Contains(t, d.Spec.Template.Spec.Volumes, getExternalRedisCAVolume(cr))
+}
+
+func TestArgoCDReconciler_deleteRedisResources_externalRedis(t *testing.T) {
+	cr := makeTestArgoCD()
+	r := makeTestReconciler(t, cr)
+
+	assert.NoError(t, r.reconcileRedisDeployment(cr, false))
+	assert.NoError(t, r.reconcileRedisService(cr))
+
+	cr.Spec.Redis.External = &argoproj.ArgoCDRedisExternalSpec{Address: "redis.example.com:6379"}
+	assert.NoError(t, r.deleteRedisDeployments(cr))
+	assert.NoError(t, r.deleteRedisServices(cr))
+	assert.NoError(t, r.deleteRedisStatefulSet(cr))
+
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-redis", Namespace: cr.Namespace}, &appsv1.Deployment{})
+	assert.True(t, apierrors.IsNotFound(err))
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-redis", Namespace: cr.Namespace}, &corev1.Service{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
@@ -0,0 +1,281 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/pkg/networking"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+	"github.com/argoproj-labs/argocd-operator/pkg/workloads"
+)
+
+// gatewayRoute describes the Gateway API route of an ArgoCD component.
+type gatewayRoute struct {
+	field   string
+	kind    string
+	name    string
+	enabled bool
+	spec    argoproj.ArgoCDGatewaySpec
+	host    string
+	backend networking.GatewayBackendReference
+}
+
+// getGatewayRedirectRouteName returns the name of the HTTPRoute redirecting to the HTTPS listener for the given route.
+func getGatewayRedirectRouteName(name string) string {
+	return fmt.Sprintf("%s-redirect", name)
+}
+
+// getGatewayHostnames returns the hostnames for a Gateway API route, falling back to the host of the component.
+func getGatewayHostnames(spec argoproj.ArgoCDGatewaySpec, host string) []string {
+	if len(spec.Hostnames) > 0 {
+		return spec.Hostnames
+	}
+	if len(host) > 0 {
+		return []string{host}
+	}
+	return nil
+}
+
+// getGatewayParentRefs returns the parent references for a Gateway API route, attached to the given listener if set.
+func getGatewayParentRefs(spec argoproj.ArgoCDGatewaySpec, sectionName string) []networking.GatewayParentReference {
+	refs := make([]networking.GatewayParentReference, 0, len(spec.ParentRefs))
+	for _, parentRef := range spec.ParentRefs {
+		ref := networking.GatewayParentReference{
+			Name:        parentRef.Name,
+			Namespace:   parentRef.Namespace,
+			SectionName: parentRef.SectionName,
+			Port:        parentRef.Port,
+		}
+		if len(sectionName) > 0 {
+			ref.SectionName = sectionName
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// getGatewayRouteObjectMeta returns the metadata for the Gateway API route with the given name.
+func getGatewayRouteObjectMeta(cr *argoproj.ArgoCD, name string, spec argoproj.ArgoCDGatewaySpec) metav1.ObjectMeta {
+	labels := common.DefaultLabels(cr.Name, cr.Name, "")
+	labels[common.AppK8sKeyName] = name
+	for key, val := range spec.Labels {
+		labels[key] = val
+	}
+
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   cr.Namespace,
+		Labels:      labels,
+		Annotations: spec.Annotations,
+	}
+}
+
+// validateGatewaySpec ensures that an enabled Gateway API route can be attached to a Gateway.
+func validateGatewaySpec(field string, spec argoproj.ArgoCDGatewaySpec) error {
+	if len(spec.ParentRefs) <= 0 {
+		return fmt.Errorf("%s.parentRefs must reference at least one Gateway", field)
+	}
+	for _, parentRef := range spec.ParentRefs {
+		if len(parentRef.Name) <= 0 {
+			return fmt.Errorf("%s.parentRefs must not contain a Gateway without a name", field)
+		}
+	}
+	return nil
+}
+
+// getDesiredGatewayRoutes returns the route and, if HTTPS redirection is configured, the redirect route for the given component.
+func getDesiredGatewayRoutes(cr *argoproj.ArgoCD, route gatewayRoute) ([]*unstructured.Unstructured, error) {
+	sectionName := ""
+	if route.spec.TLS != nil {
+		sectionName = route.spec.TLS.SectionName
+	}
+
+	if route.kind == networking.GRPCRouteKind {
+		desired, err := networking.RequestGRPCRoute(networking.GRPCRouteRequest{
+			ObjectMeta: getGatewayRouteObjectMeta(cr, route.name, route.spec),
+			ParentRefs: getGatewayParentRefs(route.spec, sectionName),
+			Hostnames:  getGatewayHostnames(route.spec, route.host),
+			Backend:    route.backend,
+		})
+		return []*unstructured.Unstructured{desired}, err
+	}
+
+	desired, err := networking.RequestHTTPRoute(networking.HTTPRouteRequest{
+		ObjectMeta: getGatewayRouteObjectMeta(cr, route.name, route.spec),
+		ParentRefs: getGatewayParentRefs(route.spec, sectionName),
+		Hostnames:  getGatewayHostnames(route.spec, route.host),
+		Path:       route.spec.Path,
+		Backend:    route.backend,
+	})
+	if err != nil {
+		return nil, err
+	}
+	routes := []*unstructured.Unstructured{desired}
+
+	if route.spec.TLS != nil && len(route.spec.TLS.RedirectSectionName) > 0 {
+		name := getGatewayRedirectRouteName(route.name)
+		redirect, err := networking.RequestHTTPRoute(networking.HTTPRouteRequest{
+			ObjectMeta:    getGatewayRouteObjectMeta(cr, name, route.spec),
+			ParentRefs:    getGatewayParentRefs(route.spec, route.spec.TLS.RedirectSectionName),
+			Hostnames:     getGatewayHostnames(route.spec, route.host),
+			Path:          route.spec.Path,
+			HTTPSRedirect: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, redirect)
+	}
+	return routes, nil
+}
+
+// reconcileGatewayRoute will ensure that the Gateway API route of a component is present when enabled, and removed otherwise.
+func (r *ArgoCDReconciler) reconcileGatewayRoute(cr *argoproj.ArgoCD, route gatewayRoute) error {
+	desiredRoutes := []*unstructured.Unstructured{}
+	if route.enabled {
+		var err error
+		if desiredRoutes, err = getDesiredGatewayRoutes(cr, route); err != nil {
+			return err
+		}
+	}
+
+	desiredNames := map[string]bool{}
+	for _, desired := range desiredRoutes {
+		desiredNames[desired.GetName()] = true
+
+		existing, err := networking.GetGatewayRoute(desired.GetKind(), desired.GetName(), desired.GetNamespace(), r.Client)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
+			if err := controllerutil.SetControllerReference(cr, desired, r.Scheme); err != nil {
+				return err
+			}
+			log.Info(fmt.Sprintf("creating %s %s for ArgoCD %s in namespace %s", desired.GetKind(), desired.GetName(), cr.Name, cr.Namespace))
+			if err := networking.CreateGatewayRoute(desired, r.Client); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !networking.GatewayRouteNeedsUpdate(existing, desired) {
+			continue
+		}
+		existing.Object["spec"] = desired.Object["spec"]
+		existing.SetLabels(desired.GetLabels())
+		existing.SetAnnotations(desired.GetAnnotations())
+		log.Info(fmt.Sprintf("updating %s %s for ArgoCD %s in namespace %s", existing.GetKind(), existing.GetName(), cr.Name, cr.Namespace))
+		if err := networking.UpdateGatewayRoute(existing, r.Client); err != nil {
+			return err
+		}
+	}
+
+	// Remove the routes that are no longer desired, such as a redirect route once HTTPS redirection is turned off.
+	if !desiredNames[route.name] {
+		if err := networking.DeleteGatewayRoute(route.kind, route.name, cr.Namespace, r.Client); err != nil {
+			return err
+		}
+	}
+	if redirect := getGatewayRedirectRouteName(route.name); route.kind == networking.HTTPRouteKind && !desiredNames[redirect] {
+		if err := networking.DeleteGatewayRoute(networking.HTTPRouteKind, redirect, cr.Namespace, r.Client); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getGatewayRoutes returns the Gateway API routes for all the ArgoCD components that can be exposed through a Gateway.
+func getGatewayRoutes(cr *argoproj.ArgoCD) []gatewayRoute {
+	routes := []gatewayRoute{
+		{
+			kind:    networking.HTTPRouteKind,
+			field:   ".spec.server.gateway",
+			name:    util.NameWithSuffix(cr.Name, "server"),
+			enabled: cr.Spec.Server.Gateway.Enabled,
+			spec:    cr.Spec.Server.Gateway,
+			host:    cr.Spec.Server.Host,
+			backend: networking.GatewayBackendReference{Name: util.NameWithSuffix(cr.Name, "server"), Port: 80},
+		},
+		{
+			kind:    networking.GRPCRouteKind,
+			field:   ".spec.server.grpc.gateway",
+			name:    util.NameWithSuffix(cr.Name, "grpc"),
+			enabled: cr.Spec.Server.GRPC.Gateway.Enabled,
+			spec:    cr.Spec.Server.GRPC.Gateway,
+			host:    cr.Spec.Server.GRPC.Host,
+			backend: networking.GatewayBackendReference{Name: util.NameWithSuffix(cr.Name, "server"), Port: 443},
+		},
+		{
+			kind:    networking.HTTPRouteKind,
+			field:   ".spec.grafana.gateway",
+			name:    util.NameWithSuffix(cr.Name, "grafana"),
+			enabled: cr.Spec.Grafana.Enabled && cr.Spec.Grafana.Gateway.Enabled,
+			spec:    cr.Spec.Grafana.Gateway,
+			host:    cr.Spec.Grafana.Host,
+			backend: networking.GatewayBackendReference{Name: util.NameWithSuffix(cr.Name, "grafana"), Port: 80},
+		},
+		{
+			kind:    networking.HTTPRouteKind,
+			field:   ".spec.prometheus.gateway",
+			name:    util.NameWithSuffix(cr.Name, "prometheus"),
+			enabled: cr.Spec.Prometheus.Enabled && cr.Spec.Prometheus.Gateway.Enabled,
+			spec:    cr.Spec.Prometheus.Gateway,
+			host:    cr.Spec.Prometheus.Host,
+			backend: networking.GatewayBackendReference{Name: "prometheus-operated", Port: 9090},
+		},
+	}
+
+	// On OpenShift Keycloak is exposed through the Route of its template.
+	keycloak := gatewayRoute{
+		field:   ".spec.sso.keycloak.gateway",
+		kind:    networking.HTTPRouteKind,
+		name:    defaultKeycloakIdentifier,
+		backend: networking.GatewayBackendReference{Name: defaultKeycloakIdentifier, Port: httpPort},
+	}
+	if cr.Spec.SSO != nil && cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeKeycloak && cr.Spec.SSO.Keycloak != nil {
+		keycloak.enabled = cr.Spec.SSO.Keycloak.Gateway.Enabled && !workloads.IsTemplateAPIAvailable()
+		keycloak.spec = cr.Spec.SSO.Keycloak.Gateway
+	}
+	return append(routes, keycloak)
+}
+
+// reconcileGatewayRoutes will ensure that all ArgoCD Gateway API routes are present.
+func (r *ArgoCDReconciler) reconcileGatewayRoutes(cr *argoproj.ArgoCD) error {
+	for _, route := range getGatewayRoutes(cr) {
+		if !networking.IsGatewayRouteKindAvailable(route.kind) {
+			if route.enabled {
+				log.Info(fmt.Sprintf("skipping %s as %s is not served by the cluster", route.field, route.kind))
+			}
+			continue
+		}
+		if route.enabled {
+			if err := validateGatewaySpec(route.field, route.spec); err != nil {
+				return err
+			}
+		}
+		if err := r.reconcileGatewayRoute(cr, route); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package argocd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/pkg/networking"
+)
+
+func withServerGateway(spec argoproj.ArgoCDGatewaySpec) func(*argoproj.ArgoCD) {
+	return func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Gateway = spec
+	}
+}
+
+// withGatewayAPI marks all the Gateway API route kinds as served for the duration of the test.
+func withGatewayAPI(t *testing.T) {
+	networking.SetGatewayAPIFound(true)
+	t.Cleanup(func() {
+		networking.SetGatewayAPIFound(false)
+	})
+}
+
+func TestReconcileGatewayRoutes_server(t *testing.T) {
+	withGatewayAPI(t)
+	argoCD := makeArgoCD(withServerGateway(argoproj.ArgoCDGatewaySpec{
+		Enabled:    true,
+		ParentRefs: []argoproj.ArgoCDGatewayParentReference{{Name: "gateway", Namespace: "gateway-ns"}},
+		Labels:     map[string]string{"my-key": "my-value"},
+	}), func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Host = "argocd.example.com"
+	})
+	r := makeReconciler(t, argoCD, argoCD)
+
+	assert.NoError(t, r.reconcileGatewayRoutes(argoCD))
+
+	route, err := networking.GetGatewayRoute(networking.HTTPRouteKind, testArgoCDName+"-server", testNamespace, r.Client)
+	assert.NoError(t, err)
+	assert.Equal(t, "my-value", route.GetLabels()["my-key"])
+	assert.Equal(t, testArgoCDName, route.GetOwnerReferences()[0].Name)
+
+	hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	assert.Equal(t, []string{"argocd.example.com"}, hostnames)
+
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	backendRefs := rules[0].(map[string]interface{})["backendRefs"]
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": testArgoCDName + "-server", "port": int64(80)}}, backendRefs)
+
+	// routes for components without a gateway are not created
+	_, err = networking.GetGatewayRoute(networking.GRPCRouteKind, testArgoCDName+"-grpc", testNamespace, r.Client)
+	assert.True(t, errors.IsNotFound(err))
+
+	// changes to the spec are applied to the existing route
+	argoCD.Spec.Server.Gateway.Hostnames = []string{"cd.example.com"}
+	assert.NoError(t, r.reconcileGatewayRoutes(argoCD))
+	route, err = networking.GetGatewayRoute(networking.HTTPRouteKind, testArgoCDName+"-server", testNamespace, r.Client)
+	assert.NoError(t, err)
+	hostnames, _, _ = unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	assert.Equal(t, []string{"cd.example.com"}, hostnames)
+
+	// disabling the gateway removes the route
+	argoCD.Spec.Server.Gateway.Enabled = false
+	assert.NoError(t, r.reconcileGatewayRoutes(argoCD))
+	_, err = networking.GetGatewayRoute(networking.HTTPRouteKind, testArgoCDName+"-server", testNamespace, r.Client)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReconcileGatewayRoutes_httpsRedirect(t *testing.T) {
+	withGatewayAPI(t)
+	argoCD := makeArgoCD(withServerGateway(argoproj.ArgoCDGatewaySpec{
+		Enabled:    true,
+		ParentRefs: []argoproj.ArgoCDGatewayParentReference{{Name: "gateway"}},
+		TLS: &argoproj.ArgoCDGatewayTLSSpec{
+			SectionName:         "https",
+			RedirectSectionName: "http",
+		},
+	}))
+	r := makeReconciler(t, argoCD, argoCD)
+
+	assert.NoError(t, r.reconcileGatewayRoutes(argoCD))
+
+	route, err := networking.GetGatewayRoute(networking.HTTPRouteKind, testArgoCDName+"-server", testNamespace, r.Client)
+	assert.NoError(t, err)
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "gateway", "sectionName": "https"}}, parentRefs)
+
+	redirect, err := networking.GetGatewayRoute(networking.HTTPRouteKind, testArgoCDName+"-server-redirect", testNamespace, r.Client)
+	assert.NoError(t, err)
+	parentRefs, _, _ = unstructured.NestedSlice(redirect.Object, "spec", "parentRefs")
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "gateway", "sectionName": "http"}}, parentRefs)
+
+	// turning off the redirect removes the redirect route only
+	argoCD.Spec.Server.Gateway.TLS.RedirectSectionName = ""
+	assert.NoError(t, r.reconcileGatewayRoutes(argoCD))
+	_, err = networking.GetGatewayRoute(networking.HTTPRouteKind, testArgoCDName+"-server-redirect", testNamespace, r.Client)
+	assert.True(t, errors.IsNotFound(err))
+	_, err = networking.GetGatewayRoute(networking.HTTPRouteKind, testArgoCDName+"-server", testNamespace, r.Client)
+	assert.NoError(t, err)
+}
+
+func TestReconcileGatewayRoutes_grpc(t *testing.T) {
+	withGatewayAPI(t)
+	argoCD := makeArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.GRPC.Gateway = argoproj.ArgoCDGatewaySpec{
+			Enabled:    true,
+			ParentRefs: []argoproj.ArgoCDGatewayParentReference{{Name: "gateway"}},
+		}
+	})
+	r := makeReconciler(t, argoCD, argoCD)
+
+	assert.NoError(t, r.reconcileGatewayRoutes(argoCD))
+
+	route, err := networking.GetGatewayRoute(networking.GRPCRouteKind, testArgoCDName+"-grpc", testNamespace, r.Client)
+	assert.NoError(t, err)
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	backendRefs := rules[0].(map[string]interface{})["backendRefs"]
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": testArgoCDName + "-server", "port": int64(443)}}, backendRefs)
+}
+
+func TestReconcileGatewayRoutes_invalidSpec(t *testing.T) {
+	withGatewayAPI(t)
+	tests := []struct {
+		name string
+		spec argoproj.ArgoCDGatewaySpec
+	}{
+		{
+			name: "no parent refs",
+			spec: argoproj.ArgoCDGatewaySpec{Enabled: true},
+		},
+		{
+			name: "parent ref without a name",
+			spec: argoproj.ArgoCDGatewaySpec{
+				Enabled:    true,
+				ParentRefs: []argoproj.ArgoCDGatewayParentReference{{Namespace: "gateway-ns"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			argoCD := makeArgoCD(withServerGateway(test.spec))
+			r := makeReconciler(t, argoCD, argoCD)
+			assert.Error(t, r.reconcileGatewayRoutes(argoCD))
+		})
+	}
+}
+
+func TestReconcileGatewayRoutes_grpcRouteNotServed(t *testing.T) {
+	withGatewayAPI(t)
+	networking.SetGatewayRouteVersion(networking.GRPCRouteKind, "")
+
+	argoCD := makeArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.GRPC.Gateway = argoproj.ArgoCDGatewaySpec{
+			Enabled:    true,
+			ParentRefs: []argoproj.ArgoCDGatewayParentReference{{Name: "gateway"}},
+		}
+	})
+	r := makeReconciler(t, argoCD, argoCD)
+
+	assert.NoError(t, r.reconcileGatewayRoutes(argoCD))
+	_, err := networking.GetGatewayRoute(networking.GRPCRouteKind, testArgoCDName+"-grpc", testNamespace, r.Client)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReconcileGatewayRoutes_grpcRouteV1alpha2(t *testing.T) {
+	withGatewayAPI(t)
+	networking.SetGatewayRouteVersion(networking.GRPCRouteKind, "v1alpha2")
+
+	argoCD := makeArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.GRPC.Gateway = argoproj.ArgoCDGatewaySpec{
+			Enabled:    true,
+			ParentRefs: []argoproj.ArgoCDGatewayParentReference{{Name: "gateway"}},
+		}
+	})
+	r := makeReconciler(t, argoCD, argoCD)
+
+	assert.NoError(t, r.reconcileGatewayRoutes(argoCD))
+	route, err := networking.GetGatewayRoute(networking.GRPCRouteKind, testArgoCDName+"-grpc", testNamespace, r.Client)
+	assert.NoError(t, err)
+	assert.Equal(t, "gateway.networking.k8s.io/v1alpha2", route.GetAPIVersion())
+}
@@ -0,0 +1,300 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/openpgp"        //nolint:staticcheck // deprecated, but the only OpenPGP implementation available
+	"golang.org/x/crypto/openpgp/armor"  //nolint:staticcheck
+	"golang.org/x/crypto/openpgp/packet" //nolint:staticcheck
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+)
+
+const (
+	gpgKeyPhaseValid   = "Valid"
+	gpgKeyPhaseExpired = "Expired"
+	gpgKeyPhaseInvalid = "Invalid"
+)
+
+// transientGPGKeyError is returned when the Secret or ConfigMap holding a GPG key cannot be read for a reason other
+// than its absence, in which case the last known value of the key is kept.
+type transientGPGKeyError struct {
+	error
+}
+
+// getManagedGPGKeyIDs returns the key IDs of the given gpg-keys ConfigMap that were added by the operator.
+func getManagedGPGKeyIDs(cm *corev1.ConfigMap) []string {
+	value := cm.Annotations[common.ArgoCDArgoprojKeyManagedGPGKeys]
+	if len(value) == 0 {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// setManagedGPGKeyIDs records the IDs of the given keys as added by the operator in the annotations of the given
+// gpg-keys ConfigMap, and returns whether the annotations changed.
+func setManagedGPGKeyIDs(cm *corev1.ConfigMap, keys map[string]string) bool {
+	keyIDs := make([]string, 0, len(keys))
+	for keyID := range keys {
+		keyIDs = append(keyIDs, keyID)
+	}
+	sort.Strings(keyIDs)
+	value := strings.Join(keyIDs, ",")
+
+	if cm.Annotations[common.ArgoCDArgoprojKeyManagedGPGKeys] == value {
+		return false
+	}
+	if len(value) == 0 {
+		delete(cm.Annotations, common.ArgoCDArgoprojKeyManagedGPGKeys)
+		return true
+	}
+	if cm.Annotations == nil {
+		cm.Annotations = map[string]string{}
+	}
+	cm.Annotations[common.ArgoCDArgoprojKeyManagedGPGKeys] = value
+	return true
+}
+
+// getGPGKeySecretNames returns the names of the Secrets referenced by the GPG keys of the given ArgoCD.
+func getGPGKeySecretNames(cr *argoproj.ArgoCD) []string {
+	names := []string{}
+	for _, key := range cr.Spec.GPGKeys {
+		if key.SecretRef != nil && !util.ContainsString(names, key.SecretRef.Name) {
+			names = append(names, key.SecretRef.Name)
+		}
+	}
+	return names
+}
+
+// getGPGKeyConfigMapNames returns the names of the ConfigMaps referenced by the GPG keys of the given ArgoCD.
+func getGPGKeyConfigMapNames(cr *argoproj.ArgoCD) []string {
+	names := []string{}
+	for _, key := range cr.Spec.GPGKeys {
+		if key.ConfigMapRef != nil && !util.ContainsString(names, key.ConfigMapRef.Name) {
+			names = append(names, key.ConfigMapRef.Name)
+		}
+	}
+	return names
+}
+
+// validateGPGKeys ensures that the GPG keys of the given ArgoCD have unique names.
+func validateGPGKeys(cr *argoproj.ArgoCD) error {
+	names := map[string]bool{}
+	for _, key := range cr.Spec.GPGKeys {
+		if len(key.Name) <= 0 {
+			return fmt.Errorf(".spec.gpgKeys must not contain a key without a name")
+		}
+		if names[key.Name] {
+			return fmt.Errorf(".spec.gpgKeys must not contain more than one key named %s", key.Name)
+		}
+		names[key.Name] = true
+	}
+	return nil
+}
+
+// getGPGKeyData returns the ASCII-armoured public key of the given GPG key, read inline or from the referenced Secret
+// or ConfigMap.
+func (r *ArgoCDReconciler) getGPGKeyData(cr *argoproj.ArgoCD, key argoproj.ArgoCDGPGKey) (string, error) {
+	sources := 0
+	for _, set := range []bool{len(key.Key) > 0, key.SecretRef != nil, key.ConfigMapRef != nil} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return "", fmt.Errorf("exactly one of key, secretRef and configMapRef must be set")
+	}
+
+	if key.SecretRef != nil {
+		secret := util.NewSecretWithName(cr, key.SecretRef.Name)
+		if err := util.FetchObject(r.Client, cr.Namespace, secret.Name, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				return "", fmt.Errorf("secret %s not found", key.SecretRef.Name)
+			}
+			return "", transientGPGKeyError{fmt.Errorf("failed to get secret %s: %w", key.SecretRef.Name, err)}
+		}
+		data, ok := secret.Data[key.SecretRef.Key]
+		if !ok {
+			return "", fmt.Errorf("secret %s has no key %s", key.SecretRef.Name, key.SecretRef.Key)
+		}
+		return string(data), nil
+	}
+
+	if key.ConfigMapRef != nil {
+		cm := newConfigMapWithName(key.ConfigMapRef.Name, cr)
+		if err := util.FetchObject(r.Client, cr.Namespace, cm.Name, cm); err != nil {
+			if apierrors.IsNotFound(err) {
+				return "", fmt.Errorf("configmap %s not found", key.ConfigMapRef.Name)
+			}
+			return "", transientGPGKeyError{fmt.Errorf("failed to get configmap %s: %w", key.ConfigMapRef.Name, err)}
+		}
+		data, ok := cm.Data[key.ConfigMapRef.Key]
+		if !ok {
+			return "", fmt.Errorf("configmap %s has no key %s", key.ConfigMapRef.Name, key.ConfigMapRef.Key)
+		}
+		return data, nil
+	}
+
+	return key.Key, nil
+}
+
+// getGPGKeyExpiry returns the time the given key expires at, or nil if it does not expire. The self-signature of
+// the primary identity holds the lifetime of the key.
+func getGPGKeyExpiry(entity *openpgp.Entity) *time.Time {
+	names := make([]string, 0, len(entity.Identities))
+	for name := range entity.Identities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sig *packet.Signature
+	for _, name := range names {
+		identity := entity.Identities[name]
+		if identity.SelfSignature == nil {
+			continue
+		}
+		if sig == nil || (identity.SelfSignature.IsPrimaryId != nil && *identity.SelfSignature.IsPrimaryId) {
+			sig = identity.SelfSignature
+		}
+	}
+	if sig == nil || sig.KeyLifetimeSecs == nil || *sig.KeyLifetimeSecs == 0 {
+		return nil
+	}
+	expiry := entity.PrimaryKey.CreationTime.Add(time.Duration(*sig.KeyLifetimeSecs) * time.Second)
+	return &expiry
+}
+
+// parseGPGKeys parses the given ASCII-armoured key ring, and returns the armoured public key of each key by key ID
+// along with the status of each key.
+func parseGPGKeys(name string, data string, now time.Time) (map[string]string, []argoproj.ArgoCDGPGKeyStatus, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid GPG key: %w", err)
+	}
+
+	keys := map[string]string{}
+	statuses := []argoproj.ArgoCDGPGKeyStatus{}
+	for _, entity := range entities {
+		buf := &bytes.Buffer{}
+		w, err := armor.Encode(buf, openpgp.PublicKeyType, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := entity.Serialize(w); err != nil {
+			return nil, nil, fmt.Errorf("invalid GPG key: %w", err)
+		}
+		if err := w.Close(); err != nil {
+			return nil, nil, err
+		}
+		buf.WriteString("\n")
+
+		status := argoproj.ArgoCDGPGKeyStatus{
+			Name:  name,
+			KeyID: entity.PrimaryKey.KeyIdString(),
+			Phase: gpgKeyPhaseValid,
+		}
+		if expiry := getGPGKeyExpiry(entity); expiry != nil {
+			status.Expires = &metav1.Time{Time: expiry.Truncate(time.Second).Local()}
+			if !now.Before(*expiry) {
+				status.Phase = gpgKeyPhaseExpired
+				status.Message = fmt.Sprintf("key expired at %s", expiry.UTC().Format(time.RFC3339))
+			}
+		}
+		keys[status.KeyID] = buf.String()
+		statuses = append(statuses, status)
+	}
+	return keys, statuses, nil
+}
+
+// getLastKnownGPGKeys adds to keys the armoured public keys that the given GPG key last resolved to, as found in the
+// existing argocd-gpg-keys-cm ConfigMap, and returns the status reporting why the key can no longer be read or parsed.
+// The last known keys are only kept when the key cannot be read temporarily, a key whose source is gone or can no
+// longer be parsed is revoked.
+func getLastKnownGPGKeys(cr *argoproj.ArgoCD, name string, existing, keys map[string]string, err error) []argoproj.ArgoCDGPGKeyStatus {
+	statuses := []argoproj.ArgoCDGPGKeyStatus{}
+	if _, ok := err.(transientGPGKeyError); !ok {
+		return append(statuses, argoproj.ArgoCDGPGKeyStatus{Name: name, Phase: gpgKeyPhaseInvalid, Message: err.Error()})
+	}
+	for _, status := range cr.Status.GPGKeys {
+		armored, ok := existing[status.KeyID]
+		if status.Name != name || len(status.KeyID) == 0 || !ok {
+			continue
+		}
+		keys[status.KeyID] = armored
+		statuses = append(statuses, argoproj.ArgoCDGPGKeyStatus{
+			Name:    name,
+			KeyID:   status.KeyID,
+			Expires: status.Expires,
+			Phase:   gpgKeyPhaseInvalid,
+			Message: fmt.Sprintf("%s, the last known key is kept", err.Error()),
+		})
+	}
+	if len(statuses) == 0 {
+		statuses = append(statuses, argoproj.ArgoCDGPGKeyStatus{Name: name, Phase: gpgKeyPhaseInvalid, Message: err.Error()})
+	}
+	return statuses
+}
+
+// getDesiredGPGKeys returns the armoured public keys by key ID of the GPG keys of the given ArgoCD, along with the
+// status of each key. Keys that cannot be read temporarily keep their last known value from the existing keys, keys
+// that cannot be read or parsed are reported in the status, expired keys are kept so that Argo CD reports signatures made with them as such.
+func (r *ArgoCDReconciler) getDesiredGPGKeys(cr *argoproj.ArgoCD, existing map[string]string) (map[string]string, []argoproj.ArgoCDGPGKeyStatus, error) {
+	if err := validateGPGKeys(cr); err != nil {
+		return nil, nil, err
+	}
+
+	keys := map[string]string{}
+	statuses := []argoproj.ArgoCDGPGKeyStatus{}
+	for _, key := range cr.Spec.GPGKeys {
+		data, err := r.getGPGKeyData(cr, key)
+		if err != nil {
+			statuses = append(statuses, getLastKnownGPGKeys(cr, key.Name, existing, keys, err)...)
+			continue
+		}
+		parsed, keyStatuses, err := parseGPGKeys(key.Name, data, time.Now())
+		if err != nil {
+			statuses = append(statuses, getLastKnownGPGKeys(cr, key.Name, existing, keys, err)...)
+			continue
+		}
+		for keyID, armored := range parsed {
+			keys[keyID] = armored
+		}
+		statuses = append(statuses, keyStatuses...)
+	}
+
+	for _, status := range statuses {
+		if status.Phase != gpgKeyPhaseValid {
+			log.Info(fmt.Sprintf("gpg key %s of ArgoCD %s in namespace %s is %s: %s", status.Name, cr.Name, cr.Namespace, strings.ToLower(status.Phase), status.Message))
+		}
+	}
+	if len(keys) == 0 {
+		keys = nil
+	}
+	if len(statuses) == 0 {
+		statuses = nil
+	}
+	return keys, statuses, nil
+}
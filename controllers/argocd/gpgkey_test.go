@@ -0,0 +1,233 @@
+package argocd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/openpgp/packet"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// makeTestGPGKey returns a new ASCII-armoured public key along with its key ID. The key expires after the given
+// lifetime, if any.
+func makeTestGPGKey(t *testing.T, lifetime time.Duration) (string, string) {
+	config := &packet.Config{RSABits: 1024}
+	entity, err := openpgp.NewEntity("Argo CD", "", "argocd@example.com", config)
+	assert.NoError(t, err)
+
+	if lifetime > 0 {
+		for name, identity := range entity.Identities {
+			secs := uint32(lifetime.Seconds())
+			identity.SelfSignature.KeyLifetimeSecs = &secs
+			assert.NoError(t, identity.SelfSignature.SignUserId(name, entity.PrimaryKey, entity.PrivateKey, config))
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	w, err := armor.Encode(buf, openpgp.PublicKeyType, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, entity.Serialize(w))
+	assert.NoError(t, w.Close())
+	return buf.String(), entity.PrimaryKey.KeyIdString()
+}
+
+func TestReconcileGPGKeysConfigMap(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	inlineKey, inlineKeyID := makeTestGPGKey(t, 0)
+	secretKey, secretKeyID := makeTestGPGKey(t, 0)
+	configMapKey, configMapKeyID := makeTestGPGKey(t, 0)
+
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.GPGKeys = []argoproj.ArgoCDGPGKey{
+			{Name: "inline", Key: inlineKey},
+			{Name: "secret", SecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "gpg-keys"},
+				Key:                  "key.asc",
+			}},
+			{Name: "configmap", ConfigMapRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "gpg-keys"},
+				Key:                  "key.asc",
+			}},
+		}
+	})
+	r := makeTestReconciler(t, a,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "gpg-keys", Namespace: a.Namespace},
+			Data:       map[string][]byte{"key.asc": []byte(secretKey)},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "gpg-keys", Namespace: a.Namespace},
+			Data:       map[string]string{"key.asc": configMapKey},
+		},
+	)
+
+	assert.NoError(t, r.reconcileGPGKeysConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDGPGKeysConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Len(t, cm.Data, 3)
+	for _, keyID := range []string{inlineKeyID, secretKeyID, configMapKeyID} {
+		assert.Contains(t, cm.Data[keyID], "-----BEGIN PGP PUBLIC KEY BLOCK-----")
+	}
+
+	assert.Equal(t, []argoproj.ArgoCDGPGKeyStatus{
+		{Name: "inline", KeyID: inlineKeyID, Phase: "Valid"},
+		{Name: "secret", KeyID: secretKeyID, Phase: "Valid"},
+		{Name: "configmap", KeyID: configMapKeyID, Phase: "Valid"},
+	}, a.Status.GPGKeys)
+
+	// keys dropped from the spec are removed from the ConfigMap
+	a.Spec.GPGKeys = a.Spec.GPGKeys[:1]
+	assert.NoError(t, r.reconcileGPGKeysConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDGPGKeysConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Len(t, cm.Data, 1)
+	assert.Contains(t, cm.Data, inlineKeyID)
+}
+
+func TestReconcileGPGKeysConfigMap_unmanaged(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	key, keyID := makeTestGPGKey(t, 0)
+
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.GPGKeys = []argoproj.ArgoCDGPGKey{{Name: "signer", Key: key}}
+	})
+	existing := newConfigMapWithName(common.ArgoCDGPGKeysConfigMapName, a)
+	existing.Data = map[string]string{"4AEE18F83AFDEB23": "added by hand"}
+	r := makeTestReconciler(t, a, existing)
+
+	// keys added by hand are kept next to the keys of the spec
+	assert.NoError(t, r.reconcileGPGKeysConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDGPGKeysConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Len(t, cm.Data, 2)
+	assert.Equal(t, "added by hand", cm.Data["4AEE18F83AFDEB23"])
+	assert.Contains(t, cm.Data, keyID)
+	assert.Equal(t, keyID, cm.Annotations[common.ArgoCDArgoprojKeyManagedGPGKeys])
+
+	// emptying the spec only revokes the keys added by the operator
+	a.Spec.GPGKeys = nil
+	assert.NoError(t, r.reconcileGPGKeysConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDGPGKeysConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, existing.Data, cm.Data)
+	assert.NotContains(t, cm.Annotations, common.ArgoCDArgoprojKeyManagedGPGKeys)
+	assert.Nil(t, a.Status.GPGKeys)
+}
+
+func TestReconcileGPGKeysConfigMap_invalid(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	expiredKey, expiredKeyID := makeTestGPGKey(t, time.Second)
+
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.GPGKeys = []argoproj.ArgoCDGPGKey{
+			{Name: "expired", Key: expiredKey},
+			{Name: "garbage", Key: "not a key"},
+			{Name: "missing", SecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "missing"},
+				Key:                  "key.asc",
+			}},
+		}
+	})
+	r := makeTestReconciler(t, a)
+	time.Sleep(time.Second)
+
+	assert.NoError(t, r.reconcileGPGKeysConfigMap(a))
+
+	// expired keys are kept in the ConfigMap
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDGPGKeysConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Len(t, cm.Data, 1)
+	assert.Contains(t, cm.Data, expiredKeyID)
+
+	assert.Len(t, a.Status.GPGKeys, 3)
+	assert.Equal(t, "Expired", a.Status.GPGKeys[0].Phase)
+	assert.NotNil(t, a.Status.GPGKeys[0].Expires)
+	assert.Equal(t, "Invalid", a.Status.GPGKeys[1].Phase)
+	assert.Contains(t, a.Status.GPGKeys[1].Message, "invalid GPG key")
+	assert.Equal(t, argoproj.ArgoCDGPGKeyStatus{Name: "missing", Phase: "Invalid", Message: "secret missing not found"}, a.Status.GPGKeys[2])
+
+	a.Spec.GPGKeys = append(a.Spec.GPGKeys, argoproj.ArgoCDGPGKey{Name: "expired", Key: expiredKey})
+	assert.EqualError(t, r.reconcileGPGKeysConfigMap(a), ".spec.gpgKeys must not contain more than one key named expired")
+}
+
+func TestReconcileGPGKeysConfigMap_lastKnownKey(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	key, keyID := makeTestGPGKey(t, 0)
+
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.GPGKeys = []argoproj.ArgoCDGPGKey{
+			{Name: "signer", SecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "signer"},
+				Key:                  "key.asc",
+			}},
+		}
+	})
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "signer", Namespace: a.Namespace},
+		Data:       map[string][]byte{"key.asc": []byte(key)},
+	}
+	r := makeTestReconciler(t, a, secret)
+
+	assert.NoError(t, r.reconcileGPGKeysConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDGPGKeysConfigMapName, Namespace: a.Namespace}, cm))
+	armored := cm.Data[keyID]
+	assert.NotEmpty(t, armored)
+
+	// a key that cannot be read temporarily keeps its last known value
+	r.Client = &failingGetClient{Client: r.Client, name: secret.Name}
+	assert.NoError(t, r.reconcileGPGKeysConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDGPGKeysConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, map[string]string{keyID: armored}, cm.Data)
+	assert.Len(t, a.Status.GPGKeys, 1)
+	assert.Equal(t, "Invalid", a.Status.GPGKeys[0].Phase)
+	assert.Equal(t, keyID, a.Status.GPGKeys[0].KeyID)
+	assert.Equal(t, "failed to get secret signer: connection refused, the last known key is kept", a.Status.GPGKeys[0].Message)
+	r.Client = r.Client.(*failingGetClient).Client
+
+	// a key that can no longer be parsed is revoked
+	secret.Data["key.asc"] = []byte("not a key")
+	assert.NoError(t, r.Client.Update(context.TODO(), secret))
+	assert.NoError(t, r.reconcileGPGKeysConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDGPGKeysConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Empty(t, cm.Data)
+	assert.Equal(t, "Invalid", a.Status.GPGKeys[0].Phase)
+	assert.Empty(t, a.Status.GPGKeys[0].KeyID)
+
+	// and so is a key whose Secret is gone
+	secret.Data["key.asc"] = []byte(key)
+	assert.NoError(t, r.Client.Update(context.TODO(), secret))
+	assert.NoError(t, r.reconcileGPGKeysConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDGPGKeysConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, map[string]string{keyID: armored}, cm.Data)
+	assert.NoError(t, r.Client.Delete(context.TODO(), secret))
+	assert.NoError(t, r.reconcileGPGKeysConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDGPGKeysConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Empty(t, cm.Data)
+	assert.Equal(t, []argoproj.ArgoCDGPGKeyStatus{{Name: "signer", Phase: "Invalid", Message: "secret signer not found"}}, a.Status.GPGKeys)
+}
+
+// failingGetClient fails to get the objects of the given name, as when the API server cannot be reached.
+type failingGetClient struct {
+	client.Client
+	name string
+}
+
+func (c *failingGetClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	if key.Name == c.name {
+		return errors.New("connection refused")
+	}
+	return c.Client.Get(ctx, key, obj)
+}
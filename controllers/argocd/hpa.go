@@ -19,11 +19,14 @@ import (
 	"reflect"
 
 	autoscaling "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/pkg/util"
+	"github.com/argoproj-labs/argocd-operator/pkg/workloads"
 )
 
 var (
@@ -107,10 +110,61 @@ func (r *ArgoCDReconciler) reconcileServerHPA(cr *argoproj.ArgoCD) error {
 	return r.Client.Create(context.TODO(), defaultHPA)
 }
 
+// reconcileRepoServerHPA will ensure that the HorizontalPodAutoscaler is present for the Argo CD Repo Server component, and reconcile any detected changes.
+func (r *ArgoCDReconciler) reconcileRepoServerHPA(cr *argoproj.ArgoCD) error {
+	name := util.NameWithSuffix(cr.Name, "repo-server")
+	if !cr.Spec.Repo.Autoscale.Enabled {
+		return workloads.DeleteHorizontalPodAutoscaler(name, cr.Namespace, r.Client) // AutoScale not enabled, delete any existing HPA.
+	}
+
+	spec := autoscaling.HorizontalPodAutoscalerSpec{
+		MaxReplicas:                    maxReplicas,
+		MinReplicas:                    &minReplicas,
+		TargetCPUUtilizationPercentage: &tcup,
+		ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       name,
+		},
+	}
+	if cr.Spec.Repo.Autoscale.HPA != nil {
+		spec = *cr.Spec.Repo.Autoscale.HPA
+	}
+
+	desiredHPA, err := workloads.RequestHorizontalPodAutoscaler(workloads.HorizontalPodAutoscalerRequest{
+		ObjectMeta: newHorizontalPodAutoscalerWithSuffix("repo-server", cr).ObjectMeta,
+		Spec:       spec,
+		Client:     r.Client,
+	})
+	if err != nil {
+		return err
+	}
+
+	existingHPA, err := workloads.GetHorizontalPodAutoscaler(name, cr.Namespace, r.Client)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if err := controllerutil.SetControllerReference(cr, desiredHPA, r.Scheme); err != nil {
+			return err
+		}
+		return workloads.CreateHorizontalPodAutoscaler(desiredHPA, r.Client)
+	}
+
+	if reflect.DeepEqual(existingHPA.Spec, desiredHPA.Spec) {
+		return nil // HorizontalPodAutoscaler found, no changes detected
+	}
+	existingHPA.Spec = desiredHPA.Spec
+	return workloads.UpdateHorizontalPodAutoscaler(existingHPA, r.Client)
+}
+
 // reconcileAutoscalers will ensure that all HorizontalPodAutoscalers are present for the given ArgoCD.
 func (r *ArgoCDReconciler) reconcileAutoscalers(cr *argoproj.ArgoCD) error {
 	if err := r.reconcileServerHPA(cr); err != nil {
 		return err
 	}
+	if err := r.reconcileRepoServerHPA(cr); err != nil {
+		return err
+	}
 	return nil
 }
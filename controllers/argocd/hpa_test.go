@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	autoscaling "k8s.io/api/autoscaling/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -90,3 +91,49 @@ func TestReconcileHPA(t *testing.T) {
 	assert.True(t, errors.IsNotFound(err))
 
 }
+
+func TestReconcileRepoServerHPA(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+
+	existingHPA := newHorizontalPodAutoscalerWithSuffix("repo-server", a)
+	assert.NoError(t, r.reconcileRepoServerHPA(a))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, existingHPA)
+	assert.True(t, errors.IsNotFound(err))
+
+	a.Spec.Repo.Autoscale.Enabled = true
+	assert.NoError(t, r.reconcileRepoServerHPA(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, existingHPA))
+	assert.Equal(t, autoscaling.HorizontalPodAutoscalerSpec{
+		MaxReplicas:                    maxReplicas,
+		MinReplicas:                    &minReplicas,
+		TargetCPUUtilizationPercentage: &tcup,
+		ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       "argocd-repo-server",
+		},
+	}, existingHPA.Spec)
+	assert.True(t, metav1.IsControlledBy(existingHPA, a))
+
+	updatedHPASpec := autoscaling.HorizontalPodAutoscalerSpec{
+		MaxReplicas:                    max,
+		MinReplicas:                    &min,
+		TargetCPUUtilizationPercentage: &cpuUtil,
+		ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       "argocd-repo-server",
+		},
+	}
+	a.Spec.Repo.Autoscale.HPA = &updatedHPASpec
+	assert.NoError(t, r.reconcileRepoServerHPA(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, existingHPA))
+	assert.Equal(t, updatedHPASpec, existingHPA.Spec)
+
+	a.Spec.Repo.Autoscale.Enabled = false
+	assert.NoError(t, r.reconcileRepoServerHPA(a))
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, existingHPA)
+	assert.True(t, errors.IsNotFound(err))
+}
@@ -20,7 +20,9 @@ import (
 	b64 "encoding/base64"
 	json "encoding/json"
 	"fmt"
+	"net/url"
 	"os"
+	"strings"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
@@ -67,6 +69,8 @@ const (
 	keycloakClient = "argocd"
 	// Keycloak realm for Argo CD.
 	keycloakRealm = "argocd"
+	// Realm attribute recording the Argo CD instance that created the realm in an external Keycloak.
+	keycloakRealmOwnerAttribute = "argocd.argoproj.io/owner"
 	// Identifier for Keycloak.
 	defaultKeycloakIdentifier = "keycloak"
 	// Identifier for TemplateInstance and Template.
@@ -101,6 +105,9 @@ type keycloakConfig struct {
 	ArgoCDURL          string
 	KeycloakServerCert []byte
 	VerifyTLS          bool
+	Realm              string
+	RedirectURIs       []string
+	ClientSecret       string
 }
 
 type oidcConfig struct {
@@ -147,6 +154,9 @@ type CustomKeycloakAPIRealm struct {
 	// Client scopes
 	// +optional
 	ClientScopes []keycloakv1alpha1.KeycloakClientScope `json:"clientScopes,omitempty"`
+	// Realm attributes.
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
 	// A set of Identity Providers.
 	// +optional
 	IdentityProviders []*keycloakv1alpha1.KeycloakIdentityProvider `json:"identityProviders,omitempty"`
@@ -1028,6 +1038,41 @@ func createRealmConfig(cfg *keycloakConfig) ([]byte, error) {
 	return json, nil
 }
 
+// newKeycloakAPIClient returns the Argo CD client of the realm managed in an external Keycloak.
+func newKeycloakAPIClient(cfg *keycloakConfig) *keycloakv1alpha1.KeycloakAPIClient {
+	redirectURIs := []string{fmt.Sprintf("%s/%s", cfg.ArgoCDURL, "auth/callback")}
+	redirectURIs = append(redirectURIs, cfg.RedirectURIs...)
+
+	return &keycloakv1alpha1.KeycloakAPIClient{
+		ClientID:                keycloakClient,
+		Name:                    keycloakClient,
+		Enabled:                 true,
+		RootURL:                 cfg.ArgoCDURL,
+		AdminURL:                cfg.ArgoCDURL,
+		ClientAuthenticatorType: "client-secret",
+		Secret:                  cfg.ClientSecret,
+		RedirectUris:            redirectURIs,
+		WebOrigins:              []string{cfg.ArgoCDURL},
+		StandardFlowEnabled:     true,
+	}
+}
+
+// newKeycloakGroupsMapper returns the protocol mapper adding the Keycloak groups of a user to the groups claim.
+func newKeycloakGroupsMapper() keycloakv1alpha1.KeycloakProtocolMapper {
+	return keycloakv1alpha1.KeycloakProtocolMapper{
+		Name:           "groups",
+		Protocol:       "openid-connect",
+		ProtocolMapper: "oidc-group-membership-mapper",
+		Config: map[string]string{
+			"full.path":            "false",
+			"userinfo.token.claim": "true",
+			"id.token.claim":       "true",
+			"access.token.claim":   "true",
+			"claim.name":           "groups",
+		},
+	}
+}
+
 // Gets Keycloak Server cert. This cert is used to authenticate the api calls to the Keycloak service.
 func (r *ArgoCDReconciler) getKCServerCert(cr *argoproj.ArgoCD) ([]byte, error) {
 
@@ -1072,7 +1117,7 @@ func (r *ArgoCDReconciler) updateArgoCDConfiguration(cr *argoproj.ArgoCD, kRoute
 		return err
 	}
 
-	argoCDSecret.Data["oidc.keycloak.clientSecret"] = []byte(oAuthClientSecret)
+	argoCDSecret.Data[common.ArgoCDKeycloakSecretKey] = []byte(oAuthClientSecret)
 	err = r.Client.Update(context.TODO(), argoCDSecret)
 	if err != nil {
 		log.Error(err, fmt.Sprintf("Error updating ArgoCD Secret for ArgoCD %s in namespace %s",
@@ -1113,17 +1158,22 @@ func (r *ArgoCDReconciler) updateArgoCDConfiguration(cr *argoproj.ArgoCD, kRoute
 		}
 	}
 
+	return r.updateArgoCDOIDCConfiguration(cr, fmt.Sprintf("%s/auth/realms/%s", kRouteURL, keycloakRealm))
+}
+
+// Updates the OIDC configuration and RBAC scopes of ArgoCD for the given Keycloak realm issuer.
+func (r *ArgoCDReconciler) updateArgoCDOIDCConfiguration(cr *argoproj.ArgoCD, issuer string) error {
+
 	// Update ArgoCD instance for OIDC Config with Keycloakrealm URL
 	rootCA := ""
 	if cr.Spec.SSO.Keycloak.RootCA != "" {
 		rootCA = cr.Spec.SSO.Keycloak.RootCA
 	}
 	o, err := yaml.Marshal(oidcConfig{
-		Name: "Keycloak",
-		Issuer: fmt.Sprintf("%s/auth/realms/%s",
-			kRouteURL, keycloakRealm),
+		Name:           "Keycloak",
+		Issuer:         issuer,
 		ClientID:       keycloakClient,
-		ClientSecret:   "$oidc.keycloak.clientSecret",
+		ClientSecret:   "$" + common.ArgoCDKeycloakSecretKey,
 		RequestedScope: []string{"openid", "profile", "email", "groups"},
 		RootCA:         rootCA,
 	})
@@ -1191,6 +1241,11 @@ func handleKeycloakPodDeletion(dc *oappsv1.DeploymentConfig) error {
 
 func (r *ArgoCDReconciler) reconcileKeycloakConfiguration(cr *argoproj.ArgoCD) error {
 
+	// An external Keycloak is configured, only manage the Argo CD realm.
+	if isExternalKeycloak(cr) {
+		return r.reconcileExternalKeycloak(cr)
+	}
+
 	// TemplateAPI is available, Install keycloak using openshift templates.
 	if workloads.IsTemplateAPIAvailable() {
 		err := r.reconcileKeycloakForOpenShift(cr)
@@ -1207,7 +1262,12 @@ func (r *ArgoCDReconciler) reconcileKeycloakConfiguration(cr *argoproj.ArgoCD) e
 	return nil
 }
 
-func deleteKeycloakConfiguration(cr *argoproj.ArgoCD) error {
+func (r *ArgoCDReconciler) deleteKeycloakConfiguration(cr *argoproj.ArgoCD) error {
+
+	// If an external Keycloak is configured, remove the Argo CD realm.
+	if isExternalKeycloak(cr) {
+		return r.deleteExternalKeycloakRealm(cr)
+	}
 
 	// If SSO is installed using OpenShift templates.
 	if workloads.IsTemplateAPIAvailable() {
@@ -1554,3 +1614,152 @@ func (r *ArgoCDReconciler) reconcileKeycloak(cr *argoproj.ArgoCD) error {
 
 	return nil
 }
+
+// isExternalKeycloak returns true if the given ArgoCD uses an existing Keycloak instead of deploying one.
+func isExternalKeycloak(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.SSO != nil && cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeKeycloak &&
+		cr.Spec.SSO.Keycloak != nil && cr.Spec.SSO.Keycloak.External != nil
+}
+
+// getExternalKeycloakRealm returns the name of the realm managed in the external Keycloak.
+func getExternalKeycloakRealm(cr *argoproj.ArgoCD) string {
+	if realm := cr.Spec.SSO.Keycloak.External.Realm; realm != "" {
+		return realm
+	}
+	return keycloakRealm
+}
+
+// prepares a keycloak config which is used in reconciling the Argo CD realm of an external keycloak.
+func (r *ArgoCDReconciler) prepareExternalKeycloakConfig(cr *argoproj.ArgoCD) (*keycloakConfig, error) {
+	external := cr.Spec.SSO.Keycloak.External
+
+	// Get the admin credentials of the external keycloak.
+	credentials := util.NewSecretWithName(cr, external.CredentialsSecret)
+	if err := util.FetchObject(r.Client, cr.Namespace, credentials.Name, credentials); err != nil {
+		return nil, err
+	}
+
+	// By default TLS Verification should be enabled.
+	tlsVerification := cr.Spec.SSO.Keycloak.VerifyTLS == nil || *cr.Spec.SSO.Keycloak.VerifyTLS
+
+	var serverCert []byte
+	if cr.Spec.SSO.Keycloak.RootCA != "" {
+		serverCert = []byte(cr.Spec.SSO.Keycloak.RootCA)
+	}
+
+	return &keycloakConfig{
+		ArgoName:           cr.Name,
+		ArgoNamespace:      cr.Namespace,
+		Username:           string(credentials.Data[corev1.BasicAuthUsernameKey]),
+		Password:           string(credentials.Data[corev1.BasicAuthPasswordKey]),
+		KeycloakURL:        strings.TrimSuffix(external.URL, "/"),
+		ArgoCDURL:          r.getArgoServerURI(cr),
+		KeycloakServerCert: serverCert,
+		VerifyTLS:          tlsVerification,
+		Realm:              getExternalKeycloakRealm(cr),
+		RedirectURIs:       external.RedirectURIs,
+	}, nil
+}
+
+// Configures the Argo CD realm, client and groups mapper of an external Keycloak.
+func (r *ArgoCDReconciler) reconcileExternalKeycloak(cr *argoproj.ArgoCD) error {
+
+	if _, err := url.ParseRequestURI(cr.Spec.SSO.Keycloak.External.URL); err != nil {
+		err = fmt.Errorf("invalid external keycloak url: %w", err)
+		setExternalKeycloakRealmStatus(cr, err)
+		return err
+	}
+
+	cfg, err := r.prepareExternalKeycloakConfig(cr)
+	if err != nil {
+		setExternalKeycloakRealmStatus(cr, err)
+		return err
+	}
+
+	// The client secret is generated once and kept in argocd-secret.
+	argoCDSecret := util.NewSecretWithName(cr, common.ArgoCDSecretName)
+	if err := util.FetchObject(r.Client, cr.Namespace, argoCDSecret.Name, argoCDSecret); err != nil {
+		log.Error(err, fmt.Sprintf("ArgoCD secret not found for ArgoCD %s in namespace %s",
+			cr.Name, cr.Namespace))
+		return err
+	}
+
+	cfg.ClientSecret = string(argoCDSecret.Data[common.ArgoCDKeycloakSecretKey])
+	if cfg.ClientSecret == "" {
+		secret, err := util.GenerateRandomString(32)
+		if err != nil {
+			return err
+		}
+		cfg.ClientSecret = secret
+	}
+
+	h, err := newExternalKeycloakClient(cfg)
+	if err != nil {
+		setExternalKeycloakRealmStatus(cr, err)
+		log.Error(err, fmt.Sprintf("Failed to log in keycloak %s for ArgoCD %s in namespace %s",
+			cfg.KeycloakURL, cr.Name, cr.Namespace))
+		return err
+	}
+
+	err = h.reconcileRealm(cfg)
+	setExternalKeycloakRealmStatus(cr, err)
+	if err != nil {
+		log.Error(err, fmt.Sprintf("Failed reconciling keycloak realm %s for ArgoCD %s in namespace %s",
+			cfg.Realm, cr.Name, cr.Namespace))
+		return err
+	}
+
+	if string(argoCDSecret.Data[common.ArgoCDKeycloakSecretKey]) != cfg.ClientSecret {
+		if argoCDSecret.Data == nil {
+			argoCDSecret.Data = map[string][]byte{}
+		}
+		argoCDSecret.Data[common.ArgoCDKeycloakSecretKey] = []byte(cfg.ClientSecret)
+		if err := r.Client.Update(context.TODO(), argoCDSecret); err != nil {
+			return err
+		}
+	}
+
+	return r.updateArgoCDOIDCConfiguration(cr, fmt.Sprintf("%s/realms/%s", cfg.KeycloakURL, cfg.Realm))
+}
+
+// externalKeycloakRealmStatus holds the result of the last realm reconciliation of the external Keycloak used by each
+// Argo CD instance, keyed by namespace/name.
+var externalKeycloakRealmStatus = make(map[string]string)
+
+// getExternalKeycloakRealmStatusKey returns the key of the given ArgoCD in externalKeycloakRealmStatus.
+func getExternalKeycloakRealmStatusKey(cr *argoproj.ArgoCD) string {
+	return fmt.Sprintf("%s/%s", cr.Namespace, cr.Name)
+}
+
+// setExternalKeycloakRealmStatus records the result of the realm reconciliation of an external Keycloak, to be reported
+// in the SSO status.
+func setExternalKeycloakRealmStatus(cr *argoproj.ArgoCD, err error) {
+	if err != nil {
+		externalKeycloakRealmStatus[getExternalKeycloakRealmStatusKey(cr)] = "Failed"
+		return
+	}
+	externalKeycloakRealmStatus[getExternalKeycloakRealmStatusKey(cr)] = "Running"
+}
+
+// Removes the Argo CD realm from an external Keycloak if the operator created it, or only the Argo CD client of the
+// realm otherwise.
+func (r *ArgoCDReconciler) deleteExternalKeycloakRealm(cr *argoproj.ArgoCD) error {
+	delete(externalKeycloakRealmStatus, getExternalKeycloakRealmStatusKey(cr))
+
+	cfg, err := r.prepareExternalKeycloakConfig(cr)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Info(fmt.Sprintf("keycloak credentials secret not found, skipping removal of realm for ArgoCD %s in namespace %s",
+				cr.Name, cr.Namespace))
+			return nil
+		}
+		return err
+	}
+
+	h, err := newExternalKeycloakClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	return h.deleteRealm(cfg)
+}
@@ -6,9 +6,11 @@ import (
 	"crypto/x509"
 	json "encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
 
 	keycloakv1alpha1 "github.com/keycloak/keycloak-operator/pkg/apis/keycloak/v1alpha1"
@@ -23,6 +25,8 @@ type httpclient struct {
 	requester requester
 	URL       string
 	token     string
+	// external is set for an external Keycloak, whose URL includes the context path if any.
+	external bool
 }
 
 // Creates a new realm for Keycloak.
@@ -73,7 +77,7 @@ func (h *httpclient) login(user, pass string) error {
 
 	req, err := http.NewRequest(
 		"POST",
-		fmt.Sprintf("%s%s", h.URL, authURL),
+		fmt.Sprintf("%s%s", h.URL, h.path(authURL)),
 		strings.NewReader(form.Encode()),
 	)
 	if err != nil {
@@ -99,7 +103,7 @@ func (h *httpclient) login(user, pass string) error {
 	}
 
 	if tokenRes.Error != "" {
-		return err
+		return errors.Errorf("keycloak login failed: %s %s", tokenRes.Error, tokenRes.ErrorDescription)
 	}
 
 	h.token = tokenRes.AccessToken
@@ -110,7 +114,7 @@ func (h *httpclient) login(user, pass string) error {
 // Post the updated realm configuration to keycloak realm API.
 func (h *httpclient) post(realmConfig []byte) (string, error) {
 	request, err := http.NewRequest("POST",
-		fmt.Sprintf("%s%s", h.URL, realmURL),
+		fmt.Sprintf("%s%s", h.URL, h.path(realmURL)),
 		bytes.NewBuffer(realmConfig))
 
 	if err != nil {
@@ -191,3 +195,195 @@ func (h *httpclient) validateKeycloakURL(URL string) error {
 	_ = res.Body.Close()
 	return nil
 }
+
+// path returns the given Keycloak API path. The /auth context path of the operator managed Keycloak is not added for
+// an external Keycloak, as it is part of its URL.
+func (h *httpclient) path(p string) string {
+	if h.external {
+		return strings.TrimPrefix(p, "/auth")
+	}
+	return p
+}
+
+// do sends a JSON request to the Keycloak admin API and decodes the response in out, if any. It returns the response
+// status code, and an error if the request failed with a status other than 404 Not Found.
+func (h *httpclient) do(method, path string, in, out interface{}) (int, error) {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return 0, err
+		}
+		body = bytes.NewBuffer(data)
+	}
+
+	request, err := http.NewRequest(method, fmt.Sprintf("%s%s", h.URL, path), body)
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", h.token))
+
+	response, err := h.requester.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return response.StatusCode, err
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		return response.StatusCode, nil
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		return response.StatusCode, errors.Errorf("keycloak request %s %s failed: %s %s", method, path, response.Status, string(data))
+	}
+
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return response.StatusCode, err
+		}
+	}
+	return response.StatusCode, nil
+}
+
+// newExternalKeycloakClient returns a http client logged in the admin API of an external Keycloak.
+func newExternalKeycloakClient(cfg *keycloakConfig) (*httpclient, error) {
+	req, err := defaultRequester(cfg.KeycloakServerCert, cfg.VerifyTLS)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &httpclient{
+		requester: req,
+		URL:       strings.TrimSuffix(cfg.KeycloakURL, "/"),
+		external:  true,
+	}
+
+	if err := h.login(cfg.Username, cfg.Password); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// reconcileRealm ensures that the Argo CD realm, client and groups mapper exist in Keycloak and match the given
+// configuration. It is safe to call on every reconciliation.
+func (h *httpclient) reconcileRealm(cfg *keycloakConfig) error {
+	realmPath := fmt.Sprintf("%s/%s", h.path(realmURL), url.PathEscape(cfg.Realm))
+
+	status, err := h.do(http.MethodGet, realmPath, nil, nil)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound {
+		log.Info(fmt.Sprintf("creating keycloak realm %s for ArgoCD %s in namespace %s", cfg.Realm, cfg.ArgoName, cfg.ArgoNamespace))
+		realm := &CustomKeycloakAPIRealm{
+			Realm:       cfg.Realm,
+			Enabled:     true,
+			SslRequired: "external",
+			Attributes: map[string]string{
+				keycloakRealmOwnerAttribute: getKeycloakRealmOwner(cfg),
+			},
+		}
+		if _, err := h.do(http.MethodPost, h.path(realmURL), realm, nil); err != nil {
+			return err
+		}
+	}
+
+	// Create or update the Argo CD client.
+	clientsPath := fmt.Sprintf("%s/clients", realmPath)
+	client := newKeycloakAPIClient(cfg)
+
+	existing, err := h.getRealmClient(clientsPath)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		if _, err := h.do(http.MethodPost, clientsPath, client, nil); err != nil {
+			return err
+		}
+		if existing, err = h.getRealmClient(clientsPath); err != nil {
+			return err
+		}
+		if existing == nil {
+			return errors.Errorf("keycloak client %s not found in realm %s after creation", keycloakClient, cfg.Realm)
+		}
+	} else {
+		client.ID = existing.ID
+		if _, err := h.do(http.MethodPut, fmt.Sprintf("%s/%s", clientsPath, existing.ID), client, nil); err != nil {
+			return err
+		}
+	}
+
+	// Create or update the groups mapper of the Argo CD client.
+	mappersPath := fmt.Sprintf("%s/%s/protocol-mappers/models", clientsPath, existing.ID)
+	mappers := []keycloakv1alpha1.KeycloakProtocolMapper{}
+	if _, err := h.do(http.MethodGet, mappersPath, nil, &mappers); err != nil {
+		return err
+	}
+
+	mapper := newKeycloakGroupsMapper()
+	for _, m := range mappers {
+		if m.Name != mapper.Name {
+			continue
+		}
+		if m.ProtocolMapper == mapper.ProtocolMapper && reflect.DeepEqual(m.Config, mapper.Config) {
+			return nil
+		}
+		mapper.ID = m.ID
+		_, err := h.do(http.MethodPut, fmt.Sprintf("%s/%s", mappersPath, m.ID), mapper, nil)
+		return err
+	}
+
+	_, err = h.do(http.MethodPost, mappersPath, mapper, nil)
+	return err
+}
+
+// getRealmClient returns the Argo CD client of the realm, or nil if it does not exist.
+func (h *httpclient) getRealmClient(clientsPath string) (*keycloakv1alpha1.KeycloakAPIClient, error) {
+	clients := []*keycloakv1alpha1.KeycloakAPIClient{}
+	if _, err := h.do(http.MethodGet, fmt.Sprintf("%s?clientId=%s", clientsPath, url.QueryEscape(keycloakClient)), nil, &clients); err != nil {
+		return nil, err
+	}
+	for _, c := range clients {
+		if c.ClientID == keycloakClient {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+// getKeycloakRealmOwner returns the value of the owner attribute of the realms created for the given configuration.
+func getKeycloakRealmOwner(cfg *keycloakConfig) string {
+	return fmt.Sprintf("%s/%s", cfg.ArgoNamespace, cfg.ArgoName)
+}
+
+// deleteRealm removes the Argo CD realm, along with its client and mappers, from Keycloak if it was created by the
+// operator for the given configuration. Only the Argo CD client is removed from a realm created by someone else.
+func (h *httpclient) deleteRealm(cfg *keycloakConfig) error {
+	realmPath := fmt.Sprintf("%s/%s", h.path(realmURL), url.PathEscape(cfg.Realm))
+
+	realm := &CustomKeycloakAPIRealm{}
+	status, err := h.do(http.MethodGet, realmPath, nil, realm)
+	if err != nil || status == http.StatusNotFound {
+		return err
+	}
+
+	if realm.Attributes[keycloakRealmOwnerAttribute] == getKeycloakRealmOwner(cfg) {
+		log.Info(fmt.Sprintf("deleting keycloak realm %s for ArgoCD %s in namespace %s", cfg.Realm, cfg.ArgoName, cfg.ArgoNamespace))
+		_, err := h.do(http.MethodDelete, realmPath, nil, nil)
+		return err
+	}
+
+	clientsPath := fmt.Sprintf("%s/clients", realmPath)
+	existing, err := h.getRealmClient(clientsPath)
+	if err != nil || existing == nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("deleting keycloak client %s from realm %s for ArgoCD %s in namespace %s", keycloakClient, cfg.Realm, cfg.ArgoName, cfg.ArgoNamespace))
+	_, err = h.do(http.MethodDelete, fmt.Sprintf("%s/%s", clientsPath, existing.ID), nil, nil)
+	return err
+}
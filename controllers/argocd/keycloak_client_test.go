@@ -1,8 +1,10 @@
 package argocd
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"encoding/pem"
@@ -107,3 +109,175 @@ func TestClient_useKeycloakServerCertificate(t *testing.T) {
 	assert.Equal(t, resp.StatusCode, 200)
 
 }
+
+// fakeKeycloak is a stub of the Keycloak admin API managing a single realm.
+type fakeKeycloak struct {
+	t       *testing.T
+	realm   *CustomKeycloakAPIRealm
+	client  *keycloakv1alpha1.KeycloakAPIClient
+	mappers []keycloakv1alpha1.KeycloakProtocolMapper
+	calls   []string
+}
+
+func (f *fakeKeycloak) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.calls = append(f.calls, req.Method+" "+req.URL.Path)
+
+	decode := func(v interface{}) {
+		assert.NoError(f.t, json.NewDecoder(req.Body).Decode(v))
+	}
+	encode := func(v interface{}) {
+		assert.NoError(f.t, json.NewEncoder(w).Encode(v))
+	}
+
+	switch {
+	case req.URL.Path == "/realms/master/protocol/openid-connect/token":
+		encode(keycloakv1alpha1.TokenResponse{AccessToken: "dummy"})
+	case req.Header.Get("Authorization") != "Bearer dummy":
+		w.WriteHeader(http.StatusUnauthorized)
+	case req.Method == http.MethodPost && req.URL.Path == "/admin/realms":
+		f.realm = &CustomKeycloakAPIRealm{}
+		decode(f.realm)
+		w.WriteHeader(http.StatusCreated)
+	case f.realm == nil || !strings.HasPrefix(req.URL.Path, "/admin/realms/"+f.realm.Realm):
+		w.WriteHeader(http.StatusNotFound)
+	case req.Method == http.MethodDelete && req.URL.Path == "/admin/realms/"+f.realm.Realm:
+		f.realm, f.client, f.mappers = nil, nil, nil
+		w.WriteHeader(http.StatusNoContent)
+	case req.Method == http.MethodGet && req.URL.Path == "/admin/realms/"+f.realm.Realm:
+		encode(f.realm)
+	case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/clients"):
+		clients := []*keycloakv1alpha1.KeycloakAPIClient{}
+		if f.client != nil && f.client.ClientID == req.URL.Query().Get("clientId") {
+			clients = append(clients, f.client)
+		}
+		encode(clients)
+	case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/clients"):
+		f.client = &keycloakv1alpha1.KeycloakAPIClient{}
+		decode(f.client)
+		f.client.ID = "client-uuid"
+		w.WriteHeader(http.StatusCreated)
+	case req.Method == http.MethodPut && strings.HasSuffix(req.URL.Path, "/clients/client-uuid"):
+		f.client = &keycloakv1alpha1.KeycloakAPIClient{}
+		decode(f.client)
+		w.WriteHeader(http.StatusNoContent)
+	case req.Method == http.MethodDelete && strings.HasSuffix(req.URL.Path, "/clients/client-uuid"):
+		f.client, f.mappers = nil, nil
+		w.WriteHeader(http.StatusNoContent)
+	case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/protocol-mappers/models"):
+		encode(f.mappers)
+	case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/protocol-mappers/models"):
+		mapper := keycloakv1alpha1.KeycloakProtocolMapper{}
+		decode(&mapper)
+		mapper.ID = "mapper-uuid"
+		f.mappers = append(f.mappers, mapper)
+		w.WriteHeader(http.StatusCreated)
+	case req.Method == http.MethodPut && strings.HasSuffix(req.URL.Path, "/protocol-mappers/models/mapper-uuid"):
+		mapper := keycloakv1alpha1.KeycloakProtocolMapper{}
+		decode(&mapper)
+		f.mappers = []keycloakv1alpha1.KeycloakProtocolMapper{mapper}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestKeycloak_reconcileExternalRealm(t *testing.T) {
+	fake := &fakeKeycloak{t: t}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	cfg := &keycloakConfig{
+		ArgoName:      "argocd",
+		ArgoNamespace: "argocd",
+		Username:      "admin",
+		Password:      "admin",
+		KeycloakURL:   server.URL,
+		ArgoCDURL:     "https://argocd.example.com",
+		Realm:         "argocd",
+		RedirectURIs:  []string{"http://localhost:8085/auth/callback"},
+		ClientSecret:  "s3cr3t",
+	}
+
+	h, err := newExternalKeycloakClient(cfg)
+	assert.NoError(t, err)
+
+	// The realm, client and groups mapper are created, and the realm records its owner.
+	assert.NoError(t, h.reconcileRealm(cfg))
+	assert.Equal(t, "argocd", fake.realm.Realm)
+	assert.Equal(t, "argocd/argocd", fake.realm.Attributes[keycloakRealmOwnerAttribute])
+	assert.Equal(t, "s3cr3t", fake.client.Secret)
+	assert.Equal(t, []string{"https://argocd.example.com/auth/callback", "http://localhost:8085/auth/callback"}, fake.client.RedirectUris)
+	assert.Len(t, fake.mappers, 1)
+	assert.Equal(t, "oidc-group-membership-mapper", fake.mappers[0].ProtocolMapper)
+
+	// Reconciling again updates the client in place, and leaves the realm and mapper untouched.
+	fake.calls = nil
+	fake.mappers[0].Config["claim.name"] = "roles"
+	cfg.ArgoCDURL = "https://argocd.example.org"
+	assert.NoError(t, h.reconcileRealm(cfg))
+	assert.NotContains(t, fake.calls, "POST /admin/realms")
+	assert.Contains(t, fake.calls, "PUT /admin/realms/argocd/clients/client-uuid")
+	assert.Contains(t, fake.calls, "PUT /admin/realms/argocd/clients/client-uuid/protocol-mappers/models/mapper-uuid")
+	assert.Equal(t, "client-uuid", fake.client.ID)
+	assert.Equal(t, "https://argocd.example.org/auth/callback", fake.client.RedirectUris[0])
+	assert.Len(t, fake.mappers, 1)
+	assert.Equal(t, "groups", fake.mappers[0].Config["claim.name"])
+
+	// The realm created by the operator is removed.
+	assert.NoError(t, h.deleteRealm(cfg))
+	assert.Nil(t, fake.realm)
+	assert.NoError(t, h.deleteRealm(cfg))
+}
+
+func TestKeycloak_deleteExternalRealm_notOwned(t *testing.T) {
+	fake := &fakeKeycloak{
+		t:     t,
+		realm: &CustomKeycloakAPIRealm{Realm: "shared", Enabled: true},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	cfg := &keycloakConfig{
+		ArgoName:      "argocd",
+		ArgoNamespace: "argocd",
+		Username:      "admin",
+		Password:      "admin",
+		KeycloakURL:   server.URL,
+		ArgoCDURL:     "https://argocd.example.com",
+		Realm:         "shared",
+		ClientSecret:  "s3cr3t",
+	}
+
+	h, err := newExternalKeycloakClient(cfg)
+	assert.NoError(t, err)
+
+	// An existing realm is not claimed by the operator.
+	assert.NoError(t, h.reconcileRealm(cfg))
+	assert.NotContains(t, fake.calls, "POST /admin/realms")
+	assert.Empty(t, fake.realm.Attributes)
+
+	// Only the Argo CD client is removed from a realm the operator did not create.
+	assert.NoError(t, h.deleteRealm(cfg))
+	assert.NotNil(t, fake.realm)
+	assert.Nil(t, fake.client)
+	assert.Contains(t, fake.calls, "DELETE /admin/realms/shared/clients/client-uuid")
+	assert.NotContains(t, fake.calls, "DELETE /admin/realms/shared")
+
+	// Another Argo CD instance does not own the realm either.
+	fake.realm.Attributes = map[string]string{keycloakRealmOwnerAttribute: "other/argocd"}
+	assert.NoError(t, h.deleteRealm(cfg))
+	assert.NotNil(t, fake.realm)
+}
+
+func TestKeycloak_externalLoginFailure(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/auth/realms/master/protocol/openid-connect/token", req.URL.Path)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"Invalid user credentials"}`))
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	_, err := newExternalKeycloakClient(&keycloakConfig{KeycloakURL: server.URL + "/auth/", Username: "admin", Password: "wrong"})
+	assert.Error(t, err)
+}
@@ -16,7 +16,9 @@ package argocd
 
 import (
 	"context"
+	"encoding/pem"
 	"fmt"
+	"net/http/httptest"
 	"testing"
 
 	appsv1 "github.com/openshift/api/apps/v1"
@@ -25,6 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	resourcev1 "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
@@ -485,3 +488,94 @@ func TestKeycloak_NodeLabelSelector(t *testing.T) {
 func removeTemplateAPI() {
 	workloads.SetTemplateAPIFound(false)
 }
+
+func TestKeycloak_reconcileExternalKeycloak(t *testing.T) {
+	fake := &fakeKeycloak{t: t}
+	server := httptest.NewTLSServer(fake)
+	defer server.Close()
+
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeKeycloak,
+			Keycloak: &argoproj.ArgoCDKeycloakSpec{
+				RootCA: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})),
+				External: &argoproj.ArgoCDKeycloakExternalSpec{
+					URL:               server.URL,
+					CredentialsSecret: "keycloak-admin",
+					Realm:             "argocd-test",
+				},
+			},
+		}
+	})
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "keycloak-admin", Namespace: a.Namespace},
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte("admin"),
+			corev1.BasicAuthPasswordKey: []byte("admin"),
+		},
+	}
+	argoCDSecret := util.NewSecretWithName(a, common.ArgoCDSecretName)
+	cm := newConfigMapWithName(common.ArgoCDConfigMapName, a)
+	cm.Data = map[string]string{common.ArgoCDKeyAdminEnabled: "true"}
+	rbacCM := newConfigMapWithName(common.ArgoCDRBACConfigMapName, a)
+	rbacCM.Data = map[string]string{common.ArgoCDKeyRBACPolicyDefault: "role:readonly"}
+
+	r := makeTestReconciler(t, a, credentials, argoCDSecret, cm, rbacCM)
+	assert.NoError(t, r.reconcileKeycloakConfiguration(a))
+
+	// The SSO status reports the result of the realm reconciliation.
+	ssoConfigLegalStatus = ssoLegalSuccess
+	assert.NoError(t, r.reconcileStatusSSO(a))
+	assert.Equal(t, "Running", a.Status.SSO)
+
+	// The generated client secret is kept in argocd-secret and configured in keycloak.
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: argoCDSecret.Name, Namespace: a.Namespace}, argoCDSecret))
+	clientSecret := string(argoCDSecret.Data[common.ArgoCDKeycloakSecretKey])
+	assert.NotEmpty(t, clientSecret)
+	assert.Equal(t, clientSecret, fake.client.Secret)
+	assert.Equal(t, "argocd-test", fake.realm.Realm)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cm.Name, Namespace: a.Namespace}, cm))
+	assert.Contains(t, cm.Data[common.ArgoCDKeyOIDCConfig], fmt.Sprintf("issuer: %s/realms/argocd-test", server.URL))
+
+	// The client secret is kept on further reconciliations.
+	assert.NoError(t, r.reconcileKeycloakConfiguration(a))
+	assert.Equal(t, clientSecret, fake.client.Secret)
+
+	// No keycloak is deployed.
+	assert.False(t, util.IsObjectFound(r.Client, a.Namespace, defaultKeycloakIdentifier, newKeycloakDeployment(a)))
+
+	// The realm is removed on deletion.
+	assert.NoError(t, r.deleteKeycloakConfiguration(a))
+	assert.Nil(t, fake.realm)
+}
+
+func TestKeycloak_reconcileExternalKeycloak_failure(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeKeycloak,
+			Keycloak: &argoproj.ArgoCDKeycloakSpec{
+				External: &argoproj.ArgoCDKeycloakExternalSpec{
+					URL:               "https://keycloak.example.com",
+					CredentialsSecret: "missing",
+				},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+	defer delete(externalKeycloakRealmStatus, getExternalKeycloakRealmStatusKey(a))
+
+	// A failed realm reconciliation is reported in the SSO status.
+	assert.Error(t, r.reconcileKeycloakConfiguration(a))
+	ssoConfigLegalStatus = ssoLegalSuccess
+	assert.NoError(t, r.reconcileStatusSSO(a))
+	assert.Equal(t, "Failed", a.Status.SSO)
+
+	// The status of another instance in the same namespace is tracked separately.
+	other := a.DeepCopy()
+	other.Name = "other-argocd"
+	setExternalKeycloakRealmStatus(other, nil)
+	defer delete(externalKeycloakRealmStatus, getExternalKeycloakRealmStatusKey(other))
+	assert.Equal(t, "Failed", externalKeycloakRealmStatus[getExternalKeycloakRealmStatusKey(a)])
+	assert.Equal(t, "Running", externalKeycloakRealmStatus[getExternalKeycloakRealmStatusKey(other)])
+}
@@ -0,0 +1,446 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+)
+
+const (
+	// managedClusterConnectionTimeout is the timeout for checking the connection to the API server of a managed cluster.
+	managedClusterConnectionTimeout = 5 * time.Second
+	// managedClusterProbeInterval is the interval at which the connection to a connected managed cluster is checked.
+	managedClusterProbeInterval = 5 * time.Minute
+	// managedClusterRetryInterval is the interval at which the connection to a failed managed cluster is checked.
+	managedClusterRetryInterval = 30 * time.Second
+
+	managedClusterPhaseConnected = "Connected"
+	managedClusterPhaseFailed    = "Failed"
+	managedClusterPhaseUnknown   = "Unknown"
+)
+
+// managedClusterProbe is the last result of the connection check of a managed cluster, which runs in the background
+// so that unreachable clusters do not block the reconciliation.
+type managedClusterProbe struct {
+	// fingerprint identifies the server and credentials the check was made with.
+	fingerprint string
+	version     string
+	err         error
+	checkedAt   time.Time
+	inFlight    bool
+}
+
+// managedClusterProbes holds the connection checks of the managed clusters, keyed by getManagedClusterProbeKey.
+var (
+	managedClusterProbes      = make(map[string]*managedClusterProbe)
+	managedClusterProbesMutex sync.Mutex
+)
+
+// managedClusterTLSClientConfig represents the TLS configuration of an Argo CD cluster Secret.
+type managedClusterTLSClientConfig struct {
+	Insecure   bool   `json:"insecure"`
+	ServerName string `json:"serverName,omitempty"`
+	CAData     []byte `json:"caData,omitempty"`
+	CertData   []byte `json:"certData,omitempty"`
+	KeyData    []byte `json:"keyData,omitempty"`
+}
+
+// managedClusterConfig represents the config of an Argo CD cluster Secret.
+type managedClusterConfig struct {
+	Username        string                        `json:"username,omitempty"`
+	Password        string                        `json:"password,omitempty"`
+	BearerToken     string                        `json:"bearerToken,omitempty"`
+	TLSClientConfig managedClusterTLSClientConfig `json:"tlsClientConfig"`
+}
+
+// getManagedClusterSecretName returns the name of the generated cluster Secret for the given managed cluster.
+func getManagedClusterSecretName(cr *argoproj.ArgoCD, cluster argoproj.ArgoCDClusterSpec) string {
+	return fmt.Sprintf("%s-cluster-%s", cr.Name, cluster.Name)
+}
+
+// getManagedClusterSecretNames returns the names of the kubeconfig and bearer token Secrets referenced by the managed
+// clusters of the given ArgoCD.
+func getManagedClusterSecretNames(cr *argoproj.ArgoCD) []string {
+	names := []string{}
+	for _, cluster := range cr.Spec.Clusters {
+		name := ""
+		if cluster.KubeconfigSecret != nil {
+			name = cluster.KubeconfigSecret.Name
+		} else if cluster.BearerTokenSecret != nil {
+			name = cluster.BearerTokenSecret.Name
+		}
+		if len(name) > 0 && !util.ContainsString(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// validateManagedClusters ensures that the managed clusters of the given ArgoCD have unique names.
+func validateManagedClusters(cr *argoproj.ArgoCD) error {
+	names := map[string]bool{}
+	for _, cluster := range cr.Spec.Clusters {
+		if len(cluster.Name) <= 0 {
+			return fmt.Errorf(".spec.clusters must not contain a cluster without a name")
+		}
+		if names[cluster.Name] {
+			return fmt.Errorf(".spec.clusters must not contain more than one cluster named %s", cluster.Name)
+		}
+		names[cluster.Name] = true
+	}
+	return nil
+}
+
+// getManagedClusterRESTConfig returns the REST config for the given managed cluster, built from the referenced
+// kubeconfig or bearer token Secret.
+func (r *ArgoCDReconciler) getManagedClusterRESTConfig(cr *argoproj.ArgoCD, cluster argoproj.ArgoCDClusterSpec) (*rest.Config, error) {
+	if (cluster.KubeconfigSecret == nil) == (cluster.BearerTokenSecret == nil) {
+		return nil, fmt.Errorf("exactly one of kubeconfigSecret and bearerTokenSecret must be set")
+	}
+
+	if cluster.KubeconfigSecret != nil {
+		secret := util.NewSecretWithName(cr, cluster.KubeconfigSecret.Name)
+		if !util.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+			return nil, fmt.Errorf("secret %s not found", cluster.KubeconfigSecret.Name)
+		}
+		kubeconfig, ok := secret.Data[cluster.KubeconfigSecret.Key]
+		if !ok {
+			return nil, fmt.Errorf("secret %s has no key %s", cluster.KubeconfigSecret.Name, cluster.KubeconfigSecret.Key)
+		}
+
+		config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kubeconfig in secret %s: %w", cluster.KubeconfigSecret.Name, err)
+		}
+		if config.ExecProvider != nil || config.AuthProvider != nil {
+			return nil, fmt.Errorf("kubeconfig in secret %s uses an exec or auth provider, which is not supported", cluster.KubeconfigSecret.Name)
+		}
+		if len(config.CAFile) > 0 || len(config.CertFile) > 0 || len(config.KeyFile) > 0 || len(config.BearerTokenFile) > 0 {
+			return nil, fmt.Errorf("kubeconfig in secret %s refers to files, certificates and tokens must be embedded", cluster.KubeconfigSecret.Name)
+		}
+		if len(cluster.Server) > 0 {
+			config.Host = cluster.Server
+		}
+		return config, nil
+	}
+
+	if len(cluster.Server) <= 0 {
+		return nil, fmt.Errorf("server must be set with bearerTokenSecret")
+	}
+	secret := util.NewSecretWithName(cr, cluster.BearerTokenSecret.Name)
+	if !util.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+		return nil, fmt.Errorf("secret %s not found", cluster.BearerTokenSecret.Name)
+	}
+	token, ok := secret.Data[corev1.ServiceAccountTokenKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s has no key %s", cluster.BearerTokenSecret.Name, corev1.ServiceAccountTokenKey)
+	}
+	return &rest.Config{
+		Host:        cluster.Server,
+		BearerToken: string(token),
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: secret.Data[corev1.ServiceAccountRootCAKey],
+		},
+	}, nil
+}
+
+// getManagedClusterServerVersion checks the connection to the API server of a managed cluster, and returns the
+// Kubernetes version it reports.
+func getManagedClusterServerVersion(config *rest.Config) (string, error) {
+	config = rest.CopyConfig(config)
+	config.Timeout = managedClusterConnectionTimeout
+	client, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return "", err
+	}
+	version, err := client.ServerVersion()
+	if err != nil {
+		return "", err
+	}
+	return version.GitVersion, nil
+}
+
+// getDesiredManagedClusterSecret returns the labelled Argo CD cluster Secret for the given managed cluster.
+func getDesiredManagedClusterSecret(cr *argoproj.ArgoCD, cluster argoproj.ArgoCDClusterSpec, config *rest.Config) (*corev1.Secret, error) {
+	secret := util.NewSecretWithName(cr, getManagedClusterSecretName(cr, cluster))
+	for key, value := range cluster.Labels {
+		secret.Labels[key] = value
+	}
+	secret.Labels[common.ArgoCDArgoprojKeySecretType] = "cluster"
+
+	clusterConfig, err := json.Marshal(managedClusterConfig{
+		Username:    config.Username,
+		Password:    config.Password,
+		BearerToken: config.BearerToken,
+		TLSClientConfig: managedClusterTLSClientConfig{
+			Insecure:   config.Insecure,
+			ServerName: config.ServerName,
+			CAData:     config.CAData,
+			CertData:   config.CertData,
+			KeyData:    config.KeyData,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	secret.Data = map[string][]byte{
+		"name":   []byte(cluster.Name),
+		"server": []byte(config.Host),
+		"config": clusterConfig,
+	}
+	if len(cluster.Namespaces) > 0 {
+		secret.Data["namespaces"] = []byte(strings.Join(cluster.Namespaces, ","))
+	}
+	if cluster.Shard != nil {
+		secret.Data["shard"] = []byte(strconv.Itoa(int(*cluster.Shard)))
+	}
+	if len(cluster.Project) > 0 {
+		secret.Data["project"] = []byte(cluster.Project)
+	}
+	return secret, nil
+}
+
+// reconcileManagedCluster will ensure that the cluster Secret for the given managed cluster is present, and returns
+// the status of the cluster.
+func (r *ArgoCDReconciler) reconcileManagedCluster(cr *argoproj.ArgoCD, cluster argoproj.ArgoCDClusterSpec) (argoproj.ArgoCDClusterStatus, error) {
+	status := argoproj.ArgoCDClusterStatus{Name: cluster.Name, Phase: managedClusterPhaseFailed}
+
+	config, err := r.getManagedClusterRESTConfig(cr, cluster)
+	if err != nil {
+		status.Message = err.Error()
+		return status, nil
+	}
+	status.Server = config.Host
+	if config.Host == common.ArgoCDDefaultServer {
+		status.Message = fmt.Sprintf("server %s is reserved for the in-cluster cluster", config.Host)
+		return status, nil
+	}
+
+	desired, err := getDesiredManagedClusterSecret(cr, cluster, config)
+	if err != nil {
+		return status, err
+	}
+
+	existing := &corev1.Secret{}
+	if !util.IsObjectFound(r.Client, cr.Namespace, desired.Name, existing) {
+		if err := controllerutil.SetControllerReference(cr, desired, r.Scheme); err != nil {
+			return status, err
+		}
+		log.Info(fmt.Sprintf("creating cluster secret %s for ArgoCD %s in namespace %s", desired.Name, cr.Name, cr.Namespace))
+		if err := r.Client.Create(context.TODO(), desired); err != nil {
+			return status, err
+		}
+	} else if !reflect.DeepEqual(existing.Data, desired.Data) || !reflect.DeepEqual(existing.Labels, desired.Labels) {
+		existing.Data = desired.Data
+		existing.Labels = desired.Labels
+		log.Info(fmt.Sprintf("updating cluster secret %s for ArgoCD %s in namespace %s", existing.Name, cr.Name, cr.Namespace))
+		if err := r.Client.Update(context.TODO(), existing); err != nil {
+			return status, err
+		}
+	}
+
+	probe := probeManagedCluster(getManagedClusterProbeKey(cr, cluster.Name), getManagedClusterFingerprint(desired), config)
+	if probe == nil {
+		status.Phase = managedClusterPhaseUnknown
+		status.Message = "the connection to the API server has not been checked yet"
+		return status, nil
+	}
+	if probe.err != nil {
+		status.Message = fmt.Sprintf("failed to connect to the API server: %v", probe.err)
+		return status, nil
+	}
+	status.Phase = managedClusterPhaseConnected
+	status.ServerVersion = probe.version
+	return status, nil
+}
+
+// getManagedClusterProbeKey returns the key of the connection check of the given managed cluster.
+func getManagedClusterProbeKey(cr *argoproj.ArgoCD, clusterName string) string {
+	return fmt.Sprintf("%s/%s/%s", cr.Namespace, cr.Name, clusterName)
+}
+
+// getManagedClusterFingerprint returns a hash of the server and credentials of the given cluster Secret.
+func getManagedClusterFingerprint(secret *corev1.Secret) string {
+	return fmt.Sprintf("%x", sha256.Sum256(append(append([]byte{}, secret.Data["server"]...), secret.Data["config"]...)))
+}
+
+// probeManagedCluster returns the last completed connection check of a managed cluster made with the given
+// fingerprint, or nil if there is none yet. A new check is started in the background when the last one is due, or
+// when the server or credentials of the cluster changed.
+func probeManagedCluster(key, fingerprint string, config *rest.Config) *managedClusterProbe {
+	managedClusterProbesMutex.Lock()
+	defer managedClusterProbesMutex.Unlock()
+
+	probe, ok := managedClusterProbes[key]
+	if !ok || probe.fingerprint != fingerprint {
+		probe = &managedClusterProbe{fingerprint: fingerprint}
+		managedClusterProbes[key] = probe
+	}
+
+	if !probe.inFlight && (probe.checkedAt.IsZero() || time.Since(probe.checkedAt) >= probe.interval()) {
+		probe.inFlight = true
+		go func() {
+			version, err := getManagedClusterServerVersion(config)
+
+			managedClusterProbesMutex.Lock()
+			defer managedClusterProbesMutex.Unlock()
+			probe.version, probe.err, probe.checkedAt, probe.inFlight = version, err, time.Now(), false
+		}()
+	}
+
+	if probe.checkedAt.IsZero() {
+		return nil
+	}
+	result := *probe
+	return &result
+}
+
+// interval returns the interval after which the connection to the cluster should be checked again.
+func (p *managedClusterProbe) interval() time.Duration {
+	if p.err != nil {
+		return managedClusterRetryInterval
+	}
+	return managedClusterProbeInterval
+}
+
+// getManagedClusterRequeueAfter returns the duration after which the given ArgoCD should be reconciled again to
+// report the pending connection checks of its managed clusters, or 0 if it has no managed clusters.
+func getManagedClusterRequeueAfter(cr *argoproj.ArgoCD) time.Duration {
+	managedClusterProbesMutex.Lock()
+	defer managedClusterProbesMutex.Unlock()
+
+	var requeueAfter time.Duration
+	for _, cluster := range cr.Spec.Clusters {
+		probe, ok := managedClusterProbes[getManagedClusterProbeKey(cr, cluster.Name)]
+		if !ok {
+			continue
+		}
+		after := managedClusterConnectionTimeout
+		if !probe.inFlight {
+			after = probe.interval() - time.Since(probe.checkedAt)
+			if after < time.Second {
+				after = time.Second
+			}
+		}
+		if requeueAfter == 0 || after < requeueAfter {
+			requeueAfter = after
+		}
+	}
+	return requeueAfter
+}
+
+// deleteManagedClusterProbes removes the connection checks of the given ArgoCD, except those of the given clusters.
+func deleteManagedClusterProbes(cr *argoproj.ArgoCD, keep map[string]bool) {
+	managedClusterProbesMutex.Lock()
+	defer managedClusterProbesMutex.Unlock()
+
+	prefix := getManagedClusterProbeKey(cr, "")
+	for key := range managedClusterProbes {
+		if strings.HasPrefix(key, prefix) && !keep[strings.TrimPrefix(key, prefix)] {
+			delete(managedClusterProbes, key)
+		}
+	}
+}
+
+// reconcileManagedClusters will ensure that a labelled cluster Secret is present for each managed cluster of the given
+// ArgoCD, that the Secrets of clusters dropped from the spec are removed, and that the status of each cluster is
+// reported. The generated Secrets are counted by the dynamic sharding of the Application Controller.
+func (r *ArgoCDReconciler) reconcileManagedClusters(cr *argoproj.ArgoCD) error {
+	if err := validateManagedClusters(cr); err != nil {
+		return err
+	}
+
+	statuses := []argoproj.ArgoCDClusterStatus{}
+	desiredNames := map[string]bool{}
+	clusterNames := map[string]bool{}
+	for _, cluster := range cr.Spec.Clusters {
+		clusterNames[cluster.Name] = true
+		status, err := r.reconcileManagedCluster(cr, cluster)
+		if err != nil {
+			return err
+		}
+		if status.Phase == managedClusterPhaseFailed {
+			log.Info(fmt.Sprintf("managed cluster %s of ArgoCD %s in namespace %s failed: %s", cluster.Name, cr.Name, cr.Namespace, status.Message))
+		}
+		// the Secret of a cluster that failed is kept, it is only removed once the cluster is dropped from the spec
+		desiredNames[getManagedClusterSecretName(cr, cluster)] = true
+		statuses = append(statuses, status)
+	}
+
+	clusterSecrets, err := r.getClusterSecrets(cr)
+	if err != nil {
+		return err
+	}
+	for i := range clusterSecrets.Items {
+		secret := &clusterSecrets.Items[i]
+		if desiredNames[secret.Name] || !metav1.IsControlledBy(secret, cr) || secret.Name == util.NameWithSuffix(cr.Name, "default-cluster-config") {
+			continue
+		}
+		log.Info(fmt.Sprintf("deleting cluster secret %s for ArgoCD %s in namespace %s", secret.Name, cr.Name, cr.Namespace))
+		if err := r.Client.Delete(context.TODO(), secret); err != nil {
+			return err
+		}
+	}
+	deleteManagedClusterProbes(cr, clusterNames)
+
+	// the Application Controller falls back to the first shard for a cluster pinned to a shard that is not run, which
+	// happens when the shard is above the maximum number of shards
+	shards := r.getApplicationControllerReplicaCount(cr)
+	for i, cluster := range cr.Spec.Clusters {
+		if cluster.Shard != nil && *cluster.Shard >= shards && len(statuses[i].Message) == 0 {
+			statuses[i].Message = fmt.Sprintf("the cluster is pinned to shard %d, but the Application Controller runs %d shard(s), it is managed by shard 0", *cluster.Shard, shards)
+		}
+	}
+
+	if len(statuses) == 0 {
+		statuses = nil
+	}
+	if !reflect.DeepEqual(cr.Status.Clusters, statuses) {
+		cr.Status.Clusters = statuses
+		return r.Client.Status().Update(context.TODO(), cr)
+	}
+	return nil
+}
+
+// getManagedClusterMaxShard returns the highest shard that a managed cluster of the given ArgoCD is pinned to, or -1.
+func getManagedClusterMaxShard(cr *argoproj.ArgoCD) int32 {
+	var maxShard int32 = -1
+	for _, cluster := range cr.Spec.Clusters {
+		if cluster.Shard != nil && *cluster.Shard > maxShard {
+			maxShard = *cluster.Shard
+		}
+	}
+	return maxShard
+}
@@ -0,0 +1,277 @@
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+)
+
+// newFakeAPIServer returns a TLS server answering version requests that carry the given bearer token.
+func newFakeAPIServer(token string) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if req.URL.Path != "/version" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"gitVersion": "v1.27.1"})
+	}))
+}
+
+// reconcileManagedClustersUntilChecked reconciles the managed clusters of the given ArgoCD until the connection to
+// each of them has been checked.
+func reconcileManagedClustersUntilChecked(t *testing.T, r *ArgoCDReconciler, a *argoproj.ArgoCD) {
+	t.Cleanup(func() { deleteManagedClusterProbes(a, nil) })
+	assert.Eventually(t, func() bool {
+		assert.NoError(t, r.reconcileManagedClusters(a))
+		for _, status := range a.Status.Clusters {
+			if status.Phase == managedClusterPhaseUnknown {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestReconcileManagedClusters_bearerToken(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	server := newFakeAPIServer("t0k3n")
+	defer server.Close()
+
+	var shard int32 = 2
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Clusters = []argoproj.ArgoCDClusterSpec{
+			{
+				Name:              "prod",
+				Server:            server.URL,
+				BearerTokenSecret: &corev1.LocalObjectReference{Name: "prod-token"},
+				Namespaces:        []string{"team-a", "team-b"},
+				Labels:            map[string]string{"env": "prod"},
+				Shard:             &shard,
+				Project:           "team-a",
+			},
+		}
+		cr.Spec.Controller.Sharding = argoproj.ArgoCDApplicationControllerShardSpec{Enabled: true, Replicas: 3}
+	})
+	token := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-token", Namespace: a.Namespace},
+		Data: map[string][]byte{
+			"token":  []byte("t0k3n"),
+			"ca.crt": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+		},
+	}
+	r := makeTestReconciler(t, a, token)
+	t.Cleanup(func() { deleteManagedClusterProbes(a, nil) })
+
+	// the connection is checked in the background
+	assert.NoError(t, r.reconcileManagedClusters(a))
+	assert.Equal(t, "Unknown", a.Status.Clusters[0].Phase)
+	assert.Equal(t, managedClusterConnectionTimeout, getManagedClusterRequeueAfter(a))
+	reconcileManagedClustersUntilChecked(t, r, a)
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster-prod", Namespace: a.Namespace}, secret))
+	assert.Equal(t, "cluster", secret.Labels[common.ArgoCDArgoprojKeySecretType])
+	assert.Equal(t, "prod", secret.Labels["env"])
+	assert.Equal(t, "prod", string(secret.Data["name"]))
+	assert.Equal(t, server.URL, string(secret.Data["server"]))
+	assert.Equal(t, "team-a,team-b", string(secret.Data["namespaces"]))
+	assert.Equal(t, "2", string(secret.Data["shard"]))
+	assert.Equal(t, "team-a", string(secret.Data["project"]))
+
+	config := managedClusterConfig{}
+	assert.NoError(t, json.Unmarshal(secret.Data["config"], &config))
+	assert.Equal(t, "t0k3n", config.BearerToken)
+	assert.Equal(t, token.Data["ca.crt"], config.TLSClientConfig.CAData)
+
+	assert.Equal(t, []argoproj.ArgoCDClusterStatus{
+		{Name: "prod", Server: server.URL, Phase: "Connected", ServerVersion: "v1.27.1"},
+	}, a.Status.Clusters)
+
+	// a connected cluster is checked again after the probe interval
+	requeueAfter := getManagedClusterRequeueAfter(a)
+	assert.True(t, requeueAfter > managedClusterRetryInterval && requeueAfter <= managedClusterProbeInterval)
+
+	// a change of credentials is checked right away
+	token.Data["token"] = []byte("r0t4t3d")
+	assert.NoError(t, r.Client.Update(context.TODO(), token))
+	assert.NoError(t, r.reconcileManagedClusters(a))
+	assert.Equal(t, "Unknown", a.Status.Clusters[0].Phase)
+	reconcileManagedClustersUntilChecked(t, r, a)
+	assert.Equal(t, "Failed", a.Status.Clusters[0].Phase)
+}
+
+func TestReconcileManagedClusters_kubeconfig(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	server := newFakeAPIServer("k8s-t0k3n")
+	defer server.Close()
+
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Clusters = []argoproj.ArgoCDClusterSpec{
+			{
+				Name: "staging",
+				KubeconfigSecret: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "staging-kubeconfig"},
+					Key:                  "kubeconfig",
+				},
+			},
+		}
+	})
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: staging
+  cluster:
+    server: %s
+    insecure-skip-tls-verify: true
+users:
+- name: argocd
+  user:
+    token: k8s-t0k3n
+contexts:
+- name: staging
+  context:
+    cluster: staging
+    user: argocd
+current-context: staging
+`, server.URL)
+	r := makeTestReconciler(t, a, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "staging-kubeconfig", Namespace: a.Namespace},
+		Data:       map[string][]byte{"kubeconfig": []byte(kubeconfig)},
+	})
+
+	reconcileManagedClustersUntilChecked(t, r, a)
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster-staging", Namespace: a.Namespace}, secret))
+	config := managedClusterConfig{}
+	assert.NoError(t, json.Unmarshal(secret.Data["config"], &config))
+	assert.Equal(t, "k8s-t0k3n", config.BearerToken)
+	assert.True(t, config.TLSClientConfig.Insecure)
+	assert.Equal(t, "Connected", a.Status.Clusters[0].Phase)
+}
+
+func TestReconcileManagedClusters_failures(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	server := newFakeAPIServer("t0k3n")
+	server.Close()
+
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Clusters = []argoproj.ArgoCDClusterSpec{
+			{
+				Name:              "unreachable",
+				Server:            server.URL,
+				BearerTokenSecret: &corev1.LocalObjectReference{Name: "token"},
+			},
+			{
+				Name:              "missing-secret",
+				Server:            "https://cluster.example.com",
+				BearerTokenSecret: &corev1.LocalObjectReference{Name: "missing"},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: a.Namespace},
+		Data:       map[string][]byte{"token": []byte("t0k3n")},
+	})
+
+	reconcileManagedClustersUntilChecked(t, r, a)
+
+	// an unreachable cluster is still registered
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster-unreachable", Namespace: a.Namespace}, secret))
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster-missing-secret", Namespace: a.Namespace}, secret))
+
+	assert.Len(t, a.Status.Clusters, 2)
+	assert.Equal(t, "Failed", a.Status.Clusters[0].Phase)
+	assert.Contains(t, a.Status.Clusters[0].Message, "failed to connect to the API server")
+	assert.Equal(t, "Failed", a.Status.Clusters[1].Phase)
+	assert.Equal(t, "secret missing not found", a.Status.Clusters[1].Message)
+
+	// an unreachable cluster is checked again after the retry interval
+	requeueAfter := getManagedClusterRequeueAfter(a)
+	assert.True(t, requeueAfter > 0 && requeueAfter <= managedClusterRetryInterval)
+
+	// the Secret of a cluster dropped from the spec is removed
+	a.Spec.Clusters = nil
+	assert.NoError(t, r.reconcileManagedClusters(a))
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cluster-unreachable", Namespace: a.Namespace}, secret))
+	assert.Nil(t, a.Status.Clusters)
+	assert.Equal(t, time.Duration(0), getManagedClusterRequeueAfter(a))
+}
+
+func TestGetApplicationControllerReplicaCount_managedClusterShard(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	var shard int32 = 2
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Controller.Sharding = argoproj.ArgoCDApplicationControllerShardSpec{
+			DynamicScalingEnabled: util.BoolPtr(true),
+			MinShards:             1,
+			MaxShards:             4,
+			ClustersPerShard:      5,
+		}
+		cr.Spec.Clusters = []argoproj.ArgoCDClusterSpec{
+			{Name: "prod", Shard: &shard},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.Equal(t, int32(3), r.getApplicationControllerReplicaCount(a))
+}
+
+func TestReconcileManagedClusters_shardNotRun(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	server := newFakeAPIServer("t0k3n")
+	defer server.Close()
+
+	var shard int32 = 5
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Controller.Sharding = argoproj.ArgoCDApplicationControllerShardSpec{
+			DynamicScalingEnabled: util.BoolPtr(true),
+			MinShards:             1,
+			MaxShards:             4,
+			ClustersPerShard:      5,
+		}
+		cr.Spec.Clusters = []argoproj.ArgoCDClusterSpec{
+			{
+				Name:              "prod",
+				Server:            server.URL,
+				BearerTokenSecret: &corev1.LocalObjectReference{Name: "prod-token"},
+				Shard:             &shard,
+			},
+		}
+	})
+	r := makeTestReconciler(t, a, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-token", Namespace: a.Namespace},
+		Data: map[string][]byte{
+			"token":  []byte("t0k3n"),
+			"ca.crt": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+		},
+	})
+
+	reconcileManagedClustersUntilChecked(t, r, a)
+
+	// the shard is capped by MaxShards, and the status reports it
+	assert.Equal(t, int32(4), r.getApplicationControllerReplicaCount(a))
+	assert.Equal(t, "Connected", a.Status.Clusters[0].Phase)
+	assert.Equal(t, "the cluster is pinned to shard 5, but the Application Controller runs 4 shard(s), it is managed by shard 0", a.Status.Clusters[0].Message)
+}
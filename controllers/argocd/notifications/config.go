@@ -0,0 +1,466 @@
+package notifications
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template/parse"
+
+	"github.com/antonmedv/expr"
+	exprstrings "github.com/argoproj/argo-cd/v2/util/notification/expression/strings"
+	exprtime "github.com/argoproj/argo-cd/v2/util/notification/expression/time"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocd/argocdcommon"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+	"github.com/argoproj-labs/argocd-operator/pkg/workloads"
+)
+
+const (
+	serviceTypeSlack   = "slack"
+	serviceTypeEmail   = "email"
+	serviceTypeWebhook = "webhook"
+	serviceTypeTeams   = "teams"
+)
+
+// GetNotificationsSecretNames returns the names of the Secrets referenced by the typed notifications config of the
+// given ArgoCD.
+func GetNotificationsSecretNames(cr *argoproj.ArgoCD) []string {
+	names := []string{}
+	if cr.Spec.Notifications.Config == nil {
+		return names
+	}
+	for _, service := range cr.Spec.Notifications.Config.Services {
+		for _, ref := range getServiceSecretRefs(service) {
+			if !util.ContainsString(names, ref.selector.Name) {
+				names = append(names, ref.selector.Name)
+			}
+		}
+	}
+	return names
+}
+
+// getServiceType returns the type of the given service, or an empty string if not exactly one type is set.
+func getServiceType(service argoproj.ArgoCDNotificationsService) string {
+	types := []string{}
+	if service.Slack != nil {
+		types = append(types, serviceTypeSlack)
+	}
+	if service.Email != nil {
+		types = append(types, serviceTypeEmail)
+	}
+	if service.Webhook != nil {
+		types = append(types, serviceTypeWebhook)
+	}
+	if service.Teams != nil {
+		types = append(types, serviceTypeTeams)
+	}
+	if len(types) != 1 {
+		return ""
+	}
+	return types[0]
+}
+
+// getServiceConfigKey returns the key of the given service in the notifications ConfigMap.
+func getServiceConfigKey(service argoproj.ArgoCDNotificationsService) string {
+	serviceType := getServiceType(service)
+	if service.Name == serviceType {
+		return fmt.Sprintf("service.%s", serviceType)
+	}
+	return fmt.Sprintf("service.%s.%s", serviceType, service.Name)
+}
+
+// serviceSecretRef is a Secret key referenced by a service, along with the key it is copied to in the notifications
+// Secret.
+type serviceSecretRef struct {
+	key      string
+	selector corev1.SecretKeySelector
+}
+
+// getServiceSecretRefs returns the Secret keys referenced by the given service.
+func getServiceSecretRefs(service argoproj.ArgoCDNotificationsService) []serviceSecretRef {
+	refs := []serviceSecretRef{}
+	switch {
+	case service.Slack != nil:
+		refs = append(refs, serviceSecretRef{key: fmt.Sprintf("%s-token", service.Name), selector: service.Slack.TokenSecret})
+	case service.Email != nil:
+		if service.Email.PasswordSecret != nil {
+			refs = append(refs, serviceSecretRef{key: fmt.Sprintf("%s-password", service.Name), selector: *service.Email.PasswordSecret})
+		}
+	case service.Webhook != nil:
+		for i, header := range service.Webhook.Headers {
+			if header.ValueSecret != nil {
+				refs = append(refs, serviceSecretRef{key: fmt.Sprintf("%s-header-%d", service.Name, i), selector: *header.ValueSecret})
+			}
+		}
+		if service.Webhook.BasicAuth != nil {
+			refs = append(refs, serviceSecretRef{key: fmt.Sprintf("%s-password", service.Name), selector: service.Webhook.BasicAuth.PasswordSecret})
+		}
+	case service.Teams != nil:
+		for i, recipient := range service.Teams.Recipients {
+			refs = append(refs, serviceSecretRef{key: fmt.Sprintf("%s-recipient-%d", service.Name, i), selector: recipient.URLSecret})
+		}
+	}
+	return refs
+}
+
+// getServiceConfig returns the configuration of the given service, referring to the keys of the notifications Secret.
+func getServiceConfig(service argoproj.ArgoCDNotificationsService) (string, error) {
+	config := map[string]interface{}{}
+	switch {
+	case service.Slack != nil:
+		config["token"] = fmt.Sprintf("$%s-token", service.Name)
+		if len(service.Slack.Username) > 0 {
+			config["username"] = service.Slack.Username
+		}
+		if len(service.Slack.Icon) > 0 {
+			config["icon"] = service.Slack.Icon
+		}
+		if len(service.Slack.APIURL) > 0 {
+			config["apiURL"] = service.Slack.APIURL
+		}
+	case service.Email != nil:
+		config["host"] = service.Email.Host
+		config["port"] = service.Email.Port
+		config["from"] = service.Email.From
+		if len(service.Email.Username) > 0 {
+			config["username"] = service.Email.Username
+		}
+		if service.Email.PasswordSecret != nil {
+			config["password"] = fmt.Sprintf("$%s-password", service.Name)
+		}
+		if service.Email.InsecureSkipVerify {
+			config["insecure_skip_verify"] = true
+		}
+	case service.Webhook != nil:
+		config["url"] = service.Webhook.URL
+		headers := []map[string]string{}
+		for i, header := range service.Webhook.Headers {
+			value := header.Value
+			if header.ValueSecret != nil {
+				value = fmt.Sprintf("$%s-header-%d", service.Name, i)
+			}
+			headers = append(headers, map[string]string{"name": header.Name, "value": value})
+		}
+		if len(headers) > 0 {
+			config["headers"] = headers
+		}
+		if service.Webhook.BasicAuth != nil {
+			config["basicAuth"] = map[string]string{
+				"username": service.Webhook.BasicAuth.Username,
+				"password": fmt.Sprintf("$%s-password", service.Name),
+			}
+		}
+		if service.Webhook.InsecureSkipVerify {
+			config["insecureSkipVerify"] = true
+		}
+	case service.Teams != nil:
+		recipientURLs := map[string]string{}
+		for i, recipient := range service.Teams.Recipients {
+			recipientURLs[recipient.Name] = fmt.Sprintf("$%s-recipient-%d", service.Name, i)
+		}
+		config["recipientUrls"] = recipientURLs
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// getTemplateConfig returns the configuration of the given template.
+func getTemplateConfig(tmpl argoproj.ArgoCDNotificationsTemplate) (string, error) {
+	data, err := yaml.Marshal(struct {
+		Message string                                                 `json:"message,omitempty"`
+		Email   *argoproj.ArgoCDNotificationsEmailTemplate             `json:"email,omitempty"`
+		Slack   *argoproj.ArgoCDNotificationsSlackTemplate             `json:"slack,omitempty"`
+		Teams   *argoproj.ArgoCDNotificationsTeamsTemplate             `json:"teams,omitempty"`
+		Webhook map[string]argoproj.ArgoCDNotificationsWebhookTemplate `json:"webhook,omitempty"`
+	}{tmpl.Message, tmpl.Email, tmpl.Slack, tmpl.Teams, tmpl.Webhook})
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// getNotificationsConfigMapData returns the data of the notifications ConfigMap for the given typed config, merged
+// with the built-in triggers and templates.
+func getNotificationsConfigMapData(config *argoproj.ArgoCDNotificationsConfigSpec) (map[string]string, error) {
+	data := GetDefaultNotificationsConfig()
+
+	for _, service := range config.Services {
+		serviceConfig, err := getServiceConfig(service)
+		if err != nil {
+			return nil, err
+		}
+		data[getServiceConfigKey(service)] = serviceConfig
+	}
+
+	for _, trigger := range config.Triggers {
+		triggerConfig, err := yaml.Marshal(trigger.Conditions)
+		if err != nil {
+			return nil, err
+		}
+		data[fmt.Sprintf("trigger.%s", trigger.Name)] = string(triggerConfig)
+	}
+
+	for _, tmpl := range config.Templates {
+		templateConfig, err := getTemplateConfig(tmpl)
+		if err != nil {
+			return nil, err
+		}
+		data[fmt.Sprintf("template.%s", tmpl.Name)] = templateConfig
+	}
+
+	if len(config.Subscriptions) > 0 {
+		subscriptions, err := yaml.Marshal(config.Subscriptions)
+		if err != nil {
+			return nil, err
+		}
+		data["subscriptions"] = string(subscriptions)
+	}
+	return data, nil
+}
+
+// getNotificationsSecretData returns the data of the notifications Secret for the given typed config, copied from
+// the Secrets referenced by the services.
+func (nr *NotificationsReconciler) getNotificationsSecretData(config *argoproj.ArgoCDNotificationsConfigSpec) (map[string][]byte, error) {
+	data := map[string][]byte{}
+	for _, service := range config.Services {
+		for _, ref := range getServiceSecretRefs(service) {
+			secret, err := workloads.GetSecret(ref.selector.Name, nr.Instance.Namespace, nr.Client)
+			if err != nil {
+				return nil, fmt.Errorf("failed to retrieve secret %s referenced by service %s: %w", ref.selector.Name, service.Name, err)
+			}
+			value, ok := secret.Data[ref.selector.Key]
+			if !ok {
+				return nil, fmt.Errorf("secret %s referenced by service %s has no key %s", ref.selector.Name, service.Name, ref.selector.Key)
+			}
+			data[ref.key] = value
+		}
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return data, nil
+}
+
+// getManagedKeys returns the keys of the data of the given notifications ConfigMap or Secret that were written by
+// the operator.
+func getManagedKeys(obj metav1.Object) []string {
+	value := obj.GetAnnotations()[common.ArgoCDArgoprojKeyManagedNotificationsKeys]
+	if len(value) == 0 {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// setManagedKeys records the given keys as written by the operator in the annotations of the given notifications
+// ConfigMap or Secret, and returns whether the annotations changed.
+func setManagedKeys(obj metav1.Object, keys []string) bool {
+	sorted := append([]string{}, keys...)
+	sort.Strings(sorted)
+	value := strings.Join(sorted, ",")
+
+	annotations := obj.GetAnnotations()
+	if annotations[common.ArgoCDArgoprojKeyManagedNotificationsKeys] == value {
+		return false
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if len(value) == 0 {
+		delete(annotations, common.ArgoCDArgoprojKeyManagedNotificationsKeys)
+	} else {
+		annotations[common.ArgoCDArgoprojKeyManagedNotificationsKeys] = value
+	}
+	obj.SetAnnotations(annotations)
+	return true
+}
+
+// validateTriggerExpression compiles the given trigger expression against the variables and functions that Argo CD
+// Notifications provides to trigger conditions, so that syntax errors and unknown identifiers are reported.
+func validateTriggerExpression(expression string) error {
+	if len(strings.TrimSpace(expression)) == 0 {
+		return fmt.Errorf("expression is empty")
+	}
+	if _, err := expr.Compile(expression, expr.Env(getTriggerExpressionEnv())); err != nil {
+		// the first line holds the message and position, the following ones point at it in the expression
+		return fmt.Errorf("%s", strings.SplitN(err.Error(), "\n", 2)[0])
+	}
+	return nil
+}
+
+// getTriggerExpressionEnv returns the environment trigger conditions are evaluated in by Argo CD Notifications. The
+// repo functions query the Argo CD API server at runtime, only their signatures matter here.
+func getTriggerExpressionEnv() map[string]interface{} {
+	return map[string]interface{}{
+		"app":     map[string]interface{}{},
+		"context": map[string]string{},
+		"time":    exprtime.NewExprs(),
+		"strings": exprstrings.NewExprs(),
+		"repo": map[string]interface{}{
+			"RepoURLToHTTPS":    func(url string) string { return url },
+			"FullNameByRepoURL": func(url string) string { return url },
+			"GetCommitMetadata": func(commitSHA string) interface{} { return nil },
+			"GetAppDetails":     func() interface{} { return nil },
+		},
+	}
+}
+
+// validateTemplateText ensures that the given text is a well formed Go template. Functions are not checked, as they
+// are provided by Argo CD Notifications.
+func validateTemplateText(text string) error {
+	tree := parse.New("template")
+	tree.Mode = parse.SkipFuncCheck
+	_, err := tree.Parse(text, "", "", map[string]*parse.Tree{})
+	return err
+}
+
+// validateTemplate ensures that the fields of the given template are well formed Go templates, and that its webhook
+// requests refer to webhook services.
+func validateTemplate(tmpl argoproj.ArgoCDNotificationsTemplate, webhooks map[string]bool) error {
+	fields := map[string]string{"message": tmpl.Message}
+	if tmpl.Email != nil {
+		fields["email.subject"] = tmpl.Email.Subject
+	}
+	if tmpl.Slack != nil {
+		fields["slack.attachments"] = tmpl.Slack.Attachments
+		fields["slack.blocks"] = tmpl.Slack.Blocks
+	}
+	if tmpl.Teams != nil {
+		fields["teams.title"] = tmpl.Teams.Title
+		fields["teams.facts"] = tmpl.Teams.Facts
+		fields["teams.sections"] = tmpl.Teams.Sections
+		fields["teams.potentialAction"] = tmpl.Teams.PotentialAction
+	}
+	for name, request := range tmpl.Webhook {
+		if !webhooks[name] {
+			return fmt.Errorf("webhook %s is not a webhook service", name)
+		}
+		fields[fmt.Sprintf("webhook.%s.path", name)] = request.Path
+		fields[fmt.Sprintf("webhook.%s.body", name)] = request.Body
+	}
+
+	for field, text := range fields {
+		if err := validateTemplateText(text); err != nil {
+			return fmt.Errorf("invalid %s: %w", field, err)
+		}
+	}
+	return nil
+}
+
+// validateNotificationsConfig ensures that the given typed config is consistent: names are unique, services have
+// exactly one type, templates and trigger expressions are well formed, and triggers, templates and services
+// referenced by triggers and subscriptions exist.
+func validateNotificationsConfig(config *argoproj.ArgoCDNotificationsConfigSpec) error {
+	defaults := GetDefaultNotificationsConfig()
+
+	services := map[string]bool{}
+	webhooks := map[string]bool{}
+	for _, service := range config.Services {
+		if services[service.Name] {
+			return fmt.Errorf("more than one service named %s", service.Name)
+		}
+		services[service.Name] = true
+		serviceType := getServiceType(service)
+		if len(serviceType) == 0 {
+			return fmt.Errorf("service %s: exactly one of slack, email, webhook and teams must be set", service.Name)
+		}
+		if serviceType == serviceTypeWebhook {
+			webhooks[service.Name] = true
+			for _, header := range service.Webhook.Headers {
+				if (len(header.Value) > 0) == (header.ValueSecret != nil) {
+					return fmt.Errorf("service %s: header %s: exactly one of value and valueSecret must be set", service.Name, header.Name)
+				}
+			}
+		}
+	}
+
+	templates := map[string]bool{}
+	for _, tmpl := range config.Templates {
+		if templates[tmpl.Name] {
+			return fmt.Errorf("more than one template named %s", tmpl.Name)
+		}
+		templates[tmpl.Name] = true
+		if err := validateTemplate(tmpl, webhooks); err != nil {
+			return fmt.Errorf("template %s: %w", tmpl.Name, err)
+		}
+	}
+
+	triggers := map[string]bool{}
+	for _, trigger := range config.Triggers {
+		if triggers[trigger.Name] {
+			return fmt.Errorf("more than one trigger named %s", trigger.Name)
+		}
+		triggers[trigger.Name] = true
+		if len(trigger.Conditions) == 0 {
+			return fmt.Errorf("trigger %s: no conditions", trigger.Name)
+		}
+		for i, condition := range trigger.Conditions {
+			if err := validateTriggerExpression(condition.When); err != nil {
+				return fmt.Errorf("trigger %s: condition %d: invalid expression %q: %w", trigger.Name, i+1, condition.When, err)
+			}
+			if len(condition.Send) == 0 {
+				return fmt.Errorf("trigger %s: condition %d: no templates to send", trigger.Name, i+1)
+			}
+			for _, name := range condition.Send {
+				if _, ok := defaults[fmt.Sprintf("template.%s", name)]; !ok && !templates[name] {
+					return fmt.Errorf("trigger %s: condition %d: template %s not found", trigger.Name, i+1, name)
+				}
+			}
+		}
+	}
+
+	for i, subscription := range config.Subscriptions {
+		for _, name := range subscription.Triggers {
+			if _, ok := defaults[fmt.Sprintf("trigger.%s", name)]; !ok && !triggers[name] {
+				return fmt.Errorf("subscription %d: trigger %s not found", i+1, name)
+			}
+		}
+		for _, recipient := range subscription.Recipients {
+			service := strings.SplitN(recipient, ":", 2)[0]
+			if !services[service] {
+				return fmt.Errorf("subscription %d: service %s of recipient %s not found", i+1, service, recipient)
+			}
+		}
+	}
+	return nil
+}
+
+// reconcileConfigValidation validates the typed notifications config, and reports the outcome in the
+// NotificationsConfigValid condition of the ArgoCD. The condition is removed when there is no typed config.
+func (nr *NotificationsReconciler) reconcileConfigValidation() error {
+	config := nr.Instance.Spec.Notifications.Config
+	if config == nil {
+		return argocdcommon.RemoveStatusCondition(nr.Instance, common.ArgoCDConditionNotificationsConfigValid, nr.Client)
+	}
+
+	err := validateNotificationsConfig(config)
+	condition := metav1.Condition{
+		Type:               common.ArgoCDConditionNotificationsConfigValid,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: nr.Instance.Generation,
+		Reason:             "Valid",
+		Message:            "notifications config is valid",
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Invalid"
+		condition.Message = fmt.Sprintf("notifications config is invalid: %v", err)
+	}
+
+	if updateErr := argocdcommon.SetStatusCondition(nr.Instance, condition, nr.Client); updateErr != nil {
+		return updateErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid notifications config: %w", err)
+	}
+	return nil
+}
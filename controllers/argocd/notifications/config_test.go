@@ -0,0 +1,313 @@
+package notifications
+
+import (
+	"context"
+	"testing"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocd/argocdcommon"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func makeTestNotificationsConfig() *argoproj.ArgoCDNotificationsConfigSpec {
+	return &argoproj.ArgoCDNotificationsConfigSpec{
+		Services: []argoproj.ArgoCDNotificationsService{
+			{
+				Name: "slack",
+				Slack: &argoproj.ArgoCDNotificationsSlackService{
+					TokenSecret: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "notifications-creds"},
+						Key:                  "slack-token",
+					},
+					Username: "argocd",
+				},
+			},
+			{
+				Name: "github",
+				Webhook: &argoproj.ArgoCDNotificationsWebhookService{
+					URL: "https://api.github.com",
+					Headers: []argoproj.ArgoCDNotificationsWebhookHeader{
+						{Name: "Accept", Value: "application/vnd.github+json"},
+						{Name: "Authorization", ValueSecret: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "notifications-creds"},
+							Key:                  "github-token",
+						}},
+					},
+				},
+			},
+		},
+		Triggers: []argoproj.ArgoCDNotificationsTrigger{
+			{
+				Name: "on-prod-degraded",
+				Conditions: []argoproj.ArgoCDNotificationsTriggerCondition{
+					{
+						When: "app.status.health.status == 'Degraded' and app.metadata.labels['env'] == 'prod'",
+						Send: []string{"app-health-degraded", "commit-status"},
+					},
+				},
+			},
+		},
+		Templates: []argoproj.ArgoCDNotificationsTemplate{
+			{
+				Name:    "commit-status",
+				Message: "{{.app.metadata.name}} is {{.app.status.health.status}}",
+				Webhook: map[string]argoproj.ArgoCDNotificationsWebhookTemplate{
+					"github": {Method: "POST", Path: "/repos/{{call .repo.FullNameByRepoURL .app.spec.source.repoURL}}/statuses/{{.app.status.sync.revision}}"},
+				},
+			},
+		},
+		Subscriptions: []argoproj.ArgoCDNotificationsSubscription{
+			{
+				Recipients: []string{"slack:prod-alerts"},
+				Triggers:   []string{"on-prod-degraded", "on-sync-failed"},
+			},
+		},
+	}
+}
+
+func makeTestNotificationsCredsSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "notifications-creds", Namespace: argocdcommon.TestNamespace},
+		Data: map[string][]byte{
+			"slack-token":  []byte("xoxb-token"),
+			"github-token": []byte("token ghp_secret"),
+		},
+	}
+}
+
+func TestValidateTriggerExpression(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		wantErr    string
+	}{
+		{name: "valid expression", expression: "app.status.operationState.phase in ['Error', 'Failed']"},
+		{name: "valid expression with escaped quote", expression: `app.metadata.name == 'it\'s'`},
+		{name: "valid expression with functions", expression: "app.status.operationState.phase in ['Running'] and time.Now().Sub(time.Parse(app.status.operationState.startedAt)).Minutes() >= 5"},
+		{name: "valid expression with repo functions", expression: "repo.FullNameByRepoURL(app.spec.source.repoURL) == 'argoproj/argo-cd' and context.env == 'prod'"},
+		{name: "empty expression", expression: " ", wantErr: "expression is empty"},
+		{name: "assignment", expression: "app.status.health.status = 'Degraded'", wantErr: "unexpected token"},
+		{name: "unknown identifier", expression: "application.status.health.status == 'Degraded'", wantErr: "unknown name application"},
+		{name: "unclosed bracket", expression: "app.status.operationState.phase in ['Error', 'Failed'", wantErr: "unexpected token EOF"},
+		{name: "unterminated string", expression: "app.status.health.status == 'Degraded", wantErr: "literal not terminated"},
+		{name: "trailing operator", expression: "app.status.health.status ==", wantErr: "unexpected token EOF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTriggerExpression(tt.expression)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateNotificationsConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*argoproj.ArgoCDNotificationsConfigSpec)
+		wantErr string
+	}{
+		{
+			name:   "valid config",
+			mutate: func(c *argoproj.ArgoCDNotificationsConfigSpec) {},
+		},
+		{
+			name: "duplicate service",
+			mutate: func(c *argoproj.ArgoCDNotificationsConfigSpec) {
+				c.Services = append(c.Services, c.Services[0])
+			},
+			wantErr: "more than one service named slack",
+		},
+		{
+			name: "service without type",
+			mutate: func(c *argoproj.ArgoCDNotificationsConfigSpec) {
+				c.Services[0].Slack = nil
+			},
+			wantErr: "service slack: exactly one of slack, email, webhook and teams must be set",
+		},
+		{
+			name: "invalid trigger expression",
+			mutate: func(c *argoproj.ArgoCDNotificationsConfigSpec) {
+				c.Triggers[0].Conditions[0].When = "app.status.health.status == 'Degraded' and"
+			},
+			wantErr: `trigger on-prod-degraded: condition 1: invalid expression "app.status.health.status == 'Degraded' and": unexpected token EOF (1:42)`,
+		},
+		{
+			name: "unknown template",
+			mutate: func(c *argoproj.ArgoCDNotificationsConfigSpec) {
+				c.Triggers[0].Conditions[0].Send = []string{"missing"}
+			},
+			wantErr: "trigger on-prod-degraded: condition 1: template missing not found",
+		},
+		{
+			name: "invalid template",
+			mutate: func(c *argoproj.ArgoCDNotificationsConfigSpec) {
+				c.Templates[0].Message = "{{.app.metadata.name"
+			},
+			wantErr: "template commit-status: invalid message: template: template:1: unclosed action",
+		},
+		{
+			name: "template webhook of unknown service",
+			mutate: func(c *argoproj.ArgoCDNotificationsConfigSpec) {
+				c.Templates[0].Webhook = map[string]argoproj.ArgoCDNotificationsWebhookTemplate{"slack": {}}
+			},
+			wantErr: "template commit-status: webhook slack is not a webhook service",
+		},
+		{
+			name: "unknown subscription trigger",
+			mutate: func(c *argoproj.ArgoCDNotificationsConfigSpec) {
+				c.Subscriptions[0].Triggers = []string{"on-missing"}
+			},
+			wantErr: "subscription 1: trigger on-missing not found",
+		},
+		{
+			name: "unknown subscription service",
+			mutate: func(c *argoproj.ArgoCDNotificationsConfigSpec) {
+				c.Subscriptions[0].Recipients = []string{"email:admin@example.com"}
+			},
+			wantErr: "subscription 1: service email of recipient email:admin@example.com not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := makeTestNotificationsConfig()
+			tt.mutate(config)
+			err := validateNotificationsConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotificationsReconciler_reconcileConfigMap_config(t *testing.T) {
+	ns := argocdcommon.MakeTestNamespace()
+	nr := makeTestNotificationsReconciler(t, ns, makeTestNotificationsCredsSecret())
+	nr.Instance.Spec.Notifications.Config = makeTestNotificationsConfig()
+	assert.NoError(t, nr.Client.Create(context.TODO(), nr.Instance))
+
+	assert.NoError(t, nr.reconcileConfigMap())
+	assert.NoError(t, nr.reconcileSecret())
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, nr.Client.Get(context.TODO(), types.NamespacedName{Name: NotificationsConfigMapName, Namespace: argocdcommon.TestNamespace}, cm))
+	// the built-in triggers and templates are kept
+	assert.Contains(t, cm.Data, "trigger.on-sync-failed")
+	assert.Contains(t, cm.Data, "template.app-health-degraded")
+	assert.Equal(t, "token: $slack-token\nusername: argocd\n", cm.Data["service.slack"])
+	assert.Equal(t, `headers:
+- name: Accept
+  value: application/vnd.github+json
+- name: Authorization
+  value: $github-header-1
+url: https://api.github.com
+`, cm.Data["service.webhook.github"])
+	assert.Equal(t, `- send:
+  - app-health-degraded
+  - commit-status
+  when: app.status.health.status == 'Degraded' and app.metadata.labels['env'] == 'prod'
+`, cm.Data["trigger.on-prod-degraded"])
+	assert.Contains(t, cm.Data["template.commit-status"], "message: '{{.app.metadata.name}} is {{.app.status.health.status}}'")
+	assert.Equal(t, `- recipients:
+  - slack:prod-alerts
+  triggers:
+  - on-prod-degraded
+  - on-sync-failed
+`, cm.Data["subscriptions"])
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, nr.Client.Get(context.TODO(), types.NamespacedName{Name: NotificationsSecretName, Namespace: argocdcommon.TestNamespace}, secret))
+	assert.Equal(t, map[string][]byte{
+		"slack-token":     []byte("xoxb-token"),
+		"github-header-1": []byte("token ghp_secret"),
+	}, secret.Data)
+
+	condition := meta.FindStatusCondition(nr.Instance.Status.Conditions, common.ArgoCDConditionNotificationsConfigValid)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+
+	// an invalid config is reported, and leaves the ConfigMap alone
+	nr.Instance.Spec.Notifications.Config.Triggers[0].Conditions[0].When = "app.status.health.status =="
+	nr.Instance.Spec.Notifications.Config.Services[0].Slack.Username = "changed"
+	assert.Error(t, nr.reconcileConfigMap())
+	condition = meta.FindStatusCondition(nr.Instance.Status.Conditions, common.ArgoCDConditionNotificationsConfigValid)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Contains(t, condition.Message, `unexpected token EOF (1:27)`)
+	assert.NoError(t, nr.Client.Get(context.TODO(), types.NamespacedName{Name: NotificationsConfigMapName, Namespace: argocdcommon.TestNamespace}, cm))
+	assert.Equal(t, "token: $slack-token\nusername: argocd\n", cm.Data["service.slack"])
+
+	// the condition is removed along with the config
+	nr.Instance.Spec.Notifications.Config = nil
+	assert.NoError(t, nr.reconcileConfigMap())
+	assert.Nil(t, meta.FindStatusCondition(nr.Instance.Status.Conditions, common.ArgoCDConditionNotificationsConfigValid))
+}
+
+func TestNotificationsReconciler_reconcileConfigMap_managedKeys(t *testing.T) {
+	ns := argocdcommon.MakeTestNamespace()
+	existingConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: NotificationsConfigMapName, Namespace: argocdcommon.TestNamespace},
+		Data:       map[string]string{"service.opsgenie": "apiUrl: https://api.opsgenie.com"},
+	}
+	existingSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: NotificationsSecretName, Namespace: argocdcommon.TestNamespace},
+		Data:       map[string][]byte{"opsgenie-key": []byte("added by hand")},
+	}
+	nr := makeTestNotificationsReconciler(t, ns, makeTestNotificationsCredsSecret(), existingConfigMap, existingSecret)
+	nr.Instance.Spec.Notifications.Config = makeTestNotificationsConfig()
+	assert.NoError(t, nr.Client.Create(context.TODO(), nr.Instance))
+
+	assert.NoError(t, nr.reconcileConfigMap())
+	assert.NoError(t, nr.reconcileSecret())
+
+	// keys added by hand are kept next to the keys of the config
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, nr.Client.Get(context.TODO(), types.NamespacedName{Name: NotificationsConfigMapName, Namespace: argocdcommon.TestNamespace}, cm))
+	assert.Equal(t, "apiUrl: https://api.opsgenie.com", cm.Data["service.opsgenie"])
+	assert.Contains(t, cm.Data, "subscriptions")
+	assert.NotContains(t, cm.Annotations[common.ArgoCDArgoprojKeyManagedNotificationsKeys], "service.opsgenie")
+	secret := &corev1.Secret{}
+	assert.NoError(t, nr.Client.Get(context.TODO(), types.NamespacedName{Name: NotificationsSecretName, Namespace: argocdcommon.TestNamespace}, secret))
+	assert.Equal(t, map[string][]byte{
+		"opsgenie-key":    []byte("added by hand"),
+		"slack-token":     []byte("xoxb-token"),
+		"github-header-1": []byte("token ghp_secret"),
+	}, secret.Data)
+	assert.Equal(t, "github-header-1,slack-token", secret.Annotations[common.ArgoCDArgoprojKeyManagedNotificationsKeys])
+
+	// only the keys written for the config are removed once dropped from it
+	nr.Instance.Spec.Notifications.Config.Subscriptions = nil
+	nr.Instance.Spec.Notifications.Config.Services[1].Webhook.Headers[1] = argoproj.ArgoCDNotificationsWebhookHeader{Name: "Authorization", Value: "token public"}
+	assert.NoError(t, nr.reconcileConfigMap())
+	assert.NoError(t, nr.reconcileSecret())
+
+	assert.NoError(t, nr.Client.Get(context.TODO(), types.NamespacedName{Name: NotificationsConfigMapName, Namespace: argocdcommon.TestNamespace}, cm))
+	assert.NotContains(t, cm.Data, "subscriptions")
+	assert.Contains(t, cm.Data, "service.opsgenie")
+	assert.NoError(t, nr.Client.Get(context.TODO(), types.NamespacedName{Name: NotificationsSecretName, Namespace: argocdcommon.TestNamespace}, secret))
+	assert.Equal(t, map[string][]byte{
+		"opsgenie-key": []byte("added by hand"),
+		"slack-token":  []byte("xoxb-token"),
+	}, secret.Data)
+	assert.Equal(t, "slack-token", secret.Annotations[common.ArgoCDArgoprojKeyManagedNotificationsKeys])
+}
+
+func TestNotificationsReconciler_reconcileSecret_missingSecret(t *testing.T) {
+	ns := argocdcommon.MakeTestNamespace()
+	nr := makeTestNotificationsReconciler(t, ns)
+	nr.Instance.Spec.Notifications.Config = makeTestNotificationsConfig()
+
+	err := nr.reconcileSecret()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to retrieve secret notifications-creds referenced by service slack")
+}
@@ -1,6 +1,8 @@
 package notifications
 
 import (
+	"reflect"
+
 	"github.com/argoproj-labs/argocd-operator/pkg/cluster"
 	"github.com/argoproj-labs/argocd-operator/pkg/workloads"
 
@@ -13,6 +15,20 @@ func (nr *NotificationsReconciler) reconcileConfigMap() error {
 
 	nr.Logger.Info("reconciling configMaps")
 
+	if err := nr.reconcileConfigValidation(); err != nil {
+		nr.Logger.Error(err, "reconcileConfigMap: failed to validate notifications config")
+		return err
+	}
+
+	data := GetDefaultNotificationsConfig()
+	if nr.Instance.Spec.Notifications.Config != nil {
+		var err error
+		if data, err = getNotificationsConfigMapData(nr.Instance.Spec.Notifications.Config); err != nil {
+			nr.Logger.Error(err, "reconcileConfigMap: failed to render notifications config")
+			return err
+		}
+	}
+
 	configMapRequest := workloads.ConfigMapRequest{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        NotificationsConfigMapName,
@@ -20,7 +36,7 @@ func (nr *NotificationsReconciler) reconcileConfigMap() error {
 			Labels:      resourceLabels,
 			Annotations: nr.Instance.Annotations,
 		},
-		Data: GetDefaultNotificationsConfig(),
+		Data: data,
 	}
 
 	desiredConfigMap, err := workloads.RequestConfigMap(configMapRequest)
@@ -43,7 +59,7 @@ func (nr *NotificationsReconciler) reconcileConfigMap() error {
 		return err
 	}
 
-	_, err = workloads.GetConfigMap(desiredConfigMap.Name, desiredConfigMap.Namespace, nr.Client)
+	existingConfigMap, err := workloads.GetConfigMap(desiredConfigMap.Name, desiredConfigMap.Namespace, nr.Client)
 	if err != nil {
 		if !errors.IsNotFound(err) {
 			nr.Logger.Error(err, "reconcileConfigMap: failed to retrieve configMap", "name", desiredConfigMap.Name, "namespace", desiredConfigMap.Namespace)
@@ -53,6 +69,9 @@ func (nr *NotificationsReconciler) reconcileConfigMap() error {
 		if err = controllerutil.SetControllerReference(nr.Instance, desiredConfigMap, nr.Scheme); err != nil {
 			nr.Logger.Error(err, "reconcileConfigMap: failed to set owner reference for configMap", "name", desiredConfigMap.Name, "namespace", desiredConfigMap.Namespace)
 		}
+		if nr.Instance.Spec.Notifications.Config != nil {
+			setManagedKeys(desiredConfigMap, getConfigMapDataKeys(desiredConfigMap.Data))
+		}
 
 		if err = workloads.CreateConfigMap(desiredConfigMap, nr.Client); err != nil {
 			nr.Logger.Error(err, "reconcileConfigMap: failed to create configMap", "name", desiredConfigMap.Name, "namespace", desiredConfigMap.Namespace)
@@ -62,6 +81,33 @@ func (nr *NotificationsReconciler) reconcileConfigMap() error {
 		return nil
 	}
 
+	// only the keys written for the typed config, if any, are kept in sync, to leave changes made by hand to other
+	// keys alone
+	if nr.Instance.Spec.Notifications.Config == nil {
+		return nil
+	}
+	merged := map[string]string{}
+	for key, value := range existingConfigMap.Data {
+		merged[key] = value
+	}
+	for _, key := range getManagedKeys(existingConfigMap) {
+		if _, ok := desiredConfigMap.Data[key]; !ok {
+			delete(merged, key)
+		}
+	}
+	for key, value := range desiredConfigMap.Data {
+		merged[key] = value
+	}
+	changed := setManagedKeys(existingConfigMap, getConfigMapDataKeys(desiredConfigMap.Data))
+	if !changed && reflect.DeepEqual(existingConfigMap.Data, merged) {
+		return nil
+	}
+	existingConfigMap.Data = merged
+	if err = workloads.UpdateConfigMap(existingConfigMap, nr.Client); err != nil {
+		nr.Logger.Error(err, "reconcileConfigMap: failed to update configMap", "name", existingConfigMap.Name, "namespace", existingConfigMap.Namespace)
+		return err
+	}
+	nr.Logger.V(0).Info("reconcileConfigMap: configMap updated", "name", existingConfigMap.Name, "namespace", existingConfigMap.Namespace)
 	return nil
 }
 
@@ -73,3 +119,12 @@ func (nr *NotificationsReconciler) deleteConfigMap(namespace string) error {
 	nr.Logger.V(0).Info("DeleteConfigMap: configMap deleted", "name", NotificationsConfigMapName, "namespace", namespace)
 	return nil
 }
+
+// getConfigMapDataKeys returns the keys of the given ConfigMap data.
+func getConfigMapDataKeys(data map[string]string) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	return keys
+}
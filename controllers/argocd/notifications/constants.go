@@ -5,4 +5,5 @@ const (
 	NotificationsControllerComponent = "notifications-controller"
 	NotificationsSecretName          = "argocd-notifications-secret"
 	NotificationsConfigMapName       = "argocd-notifications-cm"
+	NotificationsMetricsPort         = 9001
 )
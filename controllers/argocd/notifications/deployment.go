@@ -76,6 +76,7 @@ func (nr *NotificationsReconciler) reconcileDeployment() error {
 		{&existingDeployment.Spec.Template.Spec.Containers[0].Command, &desiredDeployment.Spec.Template.Spec.Containers[0].Command, nil},
 		{&existingDeployment.Spec.Template.Spec.Containers[0].Env, &desiredDeployment.Spec.Template.Spec.Containers[0].Env, nil},
 		{&existingDeployment.Spec.Template.Spec.Containers[0].Resources, &desiredDeployment.Spec.Template.Spec.Containers[0].Resources, nil},
+		{&existingDeployment.Spec.Template.Spec.Containers[0].Ports, &desiredDeployment.Spec.Template.Spec.Containers[0].Ports, nil},
 		{&existingDeployment.Spec.Template.Spec.Volumes, &desiredDeployment.Spec.Template.Spec.Volumes, nil},
 		{&existingDeployment.Spec.Template.Spec.NodeSelector, &desiredDeployment.Spec.Template.Spec.NodeSelector, nil},
 		{&existingDeployment.Spec.Template.Spec.Tolerations, &desiredDeployment.Spec.Template.Spec.Tolerations, nil},
@@ -152,11 +153,18 @@ func (nr *NotificationsReconciler) getDesiredDeployment() *appsv1.Deployment {
 			Name:            NotificationsControllerComponent,
 			Env:             notificationEnv,
 			Resources:       nr.GetNotificationsResources(),
+			Ports: []corev1.ContainerPort{
+				{
+					Name:          common.ArgoCDMetrics,
+					ContainerPort: NotificationsMetricsPort,
+					Protocol:      corev1.ProtocolTCP,
+				},
+			},
 			LivenessProbe: &corev1.Probe{
 				ProbeHandler: corev1.ProbeHandler{
 					TCPSocket: &corev1.TCPSocketAction{
 						Port: intstr.IntOrString{
-							IntVal: int32(9001),
+							IntVal: int32(NotificationsMetricsPort),
 						},
 					},
 				},
@@ -11,10 +11,11 @@ import (
 )
 
 type NotificationsReconciler struct {
-	Client   client.Client
-	Scheme   *runtime.Scheme
-	Instance *argoproj.ArgoCD
-	Logger   logr.Logger
+	Client                client.Client
+	Scheme                *runtime.Scheme
+	Instance              *argoproj.ArgoCD
+	Logger                logr.Logger
+	SelfServiceNamespaces map[string]string
 }
 
 var (
@@ -59,6 +60,21 @@ func (nr *NotificationsReconciler) Reconcile() error {
 		return err
 	}
 
+	if err := nr.reconcileMetricsService(); err != nil {
+		nr.Logger.Info("reconciling notifications metrics service")
+		return err
+	}
+
+	if err := nr.reconcileServiceMonitor(); err != nil {
+		nr.Logger.Info("reconciling notifications serviceMonitor")
+		return err
+	}
+
+	if err := nr.reconcileSelfServiceNamespaces(); err != nil {
+		nr.Logger.Info("reconciling notifications self-service namespaces")
+		return err
+	}
+
 	return nil
 }
 
@@ -66,6 +82,21 @@ func (nr *NotificationsReconciler) DeleteResources() error {
 
 	var deletionError error = nil
 
+	if err := nr.deleteSelfServiceResources(nil); err != nil {
+		nr.Logger.Error(err, "DeleteResources: failed to delete self-service namespace resources")
+		deletionError = err
+	}
+
+	if err := nr.deleteServiceMonitor(getMetricsServiceName(), nr.Instance.Namespace); err != nil {
+		nr.Logger.Error(err, "DeleteResources: failed to delete serviceMonitor")
+		deletionError = err
+	}
+
+	if err := nr.deleteService(getMetricsServiceName(), nr.Instance.Namespace); err != nil {
+		nr.Logger.Error(err, "DeleteResources: failed to delete metrics service")
+		deletionError = err
+	}
+
 	if err := nr.deleteDeployment(resourceName, nr.Instance.Namespace); err != nil {
 		nr.Logger.Error(err, "DeleteResources: failed to delete deployment")
 		deletionError = err
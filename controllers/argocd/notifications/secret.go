@@ -1,6 +1,8 @@
 package notifications
 
 import (
+	"reflect"
+
 	"github.com/argoproj-labs/argocd-operator/pkg/cluster"
 	"github.com/argoproj-labs/argocd-operator/pkg/mutation"
 	"github.com/argoproj-labs/argocd-operator/pkg/workloads"
@@ -14,12 +16,22 @@ func (nr *NotificationsReconciler) reconcileSecret() error {
 
 	nr.Logger.Info("reconciling secrets")
 
+	var data map[string][]byte
+	if nr.Instance.Spec.Notifications.Config != nil {
+		var err error
+		if data, err = nr.getNotificationsSecretData(nr.Instance.Spec.Notifications.Config); err != nil {
+			nr.Logger.Error(err, "reconcileSecret: failed to read secrets referenced by notifications config")
+			return err
+		}
+	}
+
 	secretRequest := workloads.SecretRequest{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      NotificationsSecretName,
 			Namespace: nr.Instance.Namespace,
 			Labels:    resourceLabels,
 		},
+		Data: data,
 
 		Client:    nr.Client,
 		Mutations: []mutation.MutateFunc{mutation.ApplyReconcilerMutation},
@@ -44,7 +56,7 @@ func (nr *NotificationsReconciler) reconcileSecret() error {
 		return err
 	}
 
-	_, err = workloads.GetSecret(desiredSecret.Name, desiredSecret.Namespace, nr.Client)
+	existingSecret, err := workloads.GetSecret(desiredSecret.Name, desiredSecret.Namespace, nr.Client)
 	if err != nil {
 		if !errors.IsNotFound(err) {
 			nr.Logger.Error(err, "reconcileSecret: failed to retrieve secret", "name", desiredSecret.Name, "namespace", desiredSecret.Namespace)
@@ -54,6 +66,9 @@ func (nr *NotificationsReconciler) reconcileSecret() error {
 		if err = controllerutil.SetControllerReference(nr.Instance, desiredSecret, nr.Scheme); err != nil {
 			nr.Logger.Error(err, "reconcileSecret: failed to set owner reference for secret", "name", desiredSecret.Name, "namespace", desiredSecret.Namespace)
 		}
+		if nr.Instance.Spec.Notifications.Config != nil {
+			setManagedKeys(desiredSecret, getSecretDataKeys(desiredSecret.Data))
+		}
 
 		if err = workloads.CreateSecret(desiredSecret, nr.Client); err != nil {
 			nr.Logger.Error(err, "reconcileSecret: failed to create secret", "name", desiredSecret.Name, "namespace", desiredSecret.Namespace)
@@ -63,6 +78,35 @@ func (nr *NotificationsReconciler) reconcileSecret() error {
 		return nil
 	}
 
+	// only the keys written for the typed config, if any, are kept in sync, to leave secrets added by hand alone
+	if nr.Instance.Spec.Notifications.Config == nil {
+		return nil
+	}
+	merged := map[string][]byte{}
+	for key, value := range existingSecret.Data {
+		merged[key] = value
+	}
+	for _, key := range getManagedKeys(existingSecret) {
+		if _, ok := desiredSecret.Data[key]; !ok {
+			delete(merged, key)
+		}
+	}
+	for key, value := range desiredSecret.Data {
+		merged[key] = value
+	}
+	if len(merged) == 0 {
+		merged = nil
+	}
+	changed := setManagedKeys(existingSecret, getSecretDataKeys(desiredSecret.Data))
+	if !changed && reflect.DeepEqual(existingSecret.Data, merged) {
+		return nil
+	}
+	existingSecret.Data = merged
+	if err = workloads.UpdateSecret(existingSecret, nr.Client); err != nil {
+		nr.Logger.Error(err, "reconcileSecret: failed to update secret", "name", existingSecret.Name, "namespace", existingSecret.Namespace)
+		return err
+	}
+	nr.Logger.V(0).Info("reconcileSecret: secret updated", "name", existingSecret.Name, "namespace", existingSecret.Namespace)
 	return nil
 }
 
@@ -74,3 +118,12 @@ func (nr *NotificationsReconciler) deleteSecret(namespace string) error {
 	nr.Logger.V(0).Info("DeleteSecret: secret deleted", "name", NotificationsSecretName, "namespace", namespace)
 	return nil
 }
+
+// getSecretDataKeys returns the keys of the given Secret data.
+func getSecretDataKeys(data map[string][]byte) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,98 @@
+package notifications
+
+import (
+	"github.com/argoproj-labs/argocd-operator/controllers/argocd/argocdcommon"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// reconcileSelfServiceNamespaces ensures that the notifications controller can read Applications and the
+// notifications ConfigMap and Secret in each self-service namespace, and removes the Roles and RoleBindings of the
+// namespaces no longer listed.
+func (nr *NotificationsReconciler) reconcileSelfServiceNamespaces() error {
+
+	nr.Logger.Info("reconciling self-service namespaces")
+
+	return argocdcommon.ReconcileNamespacePermissions(nr.getSelfServicePermissionsRequest(), nr.getSelfServiceNamespaces())
+}
+
+// deleteSelfServiceResources removes the Roles and RoleBindings of the notifications controller from the namespaces
+// other than the namespace of the ArgoCD and the given ones.
+func (nr *NotificationsReconciler) deleteSelfServiceResources(keep []string) error {
+	return argocdcommon.DeleteNamespacePermissions(nr.getSelfServicePermissionsRequest(), keep)
+}
+
+func (nr *NotificationsReconciler) getSelfServicePermissionsRequest() argocdcommon.NamespacePermissionsRequest {
+	return argocdcommon.NamespacePermissionsRequest{
+		Instance:  nr.Instance,
+		Name:      resourceName,
+		Component: NotificationsControllerComponent,
+		Labels:    resourceLabels,
+		Rules:     getSelfServicePolicyRules(),
+		Client:    nr.Client,
+		Logger:    nr.Logger,
+	}
+}
+
+// getSelfServicePolicyRules returns the rules the notifications controller needs in a self-service namespace: access
+// to the Applications, and read access to the notifications ConfigMap and Secret.
+func getSelfServicePolicyRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{
+				"argoproj.io",
+			},
+			Resources: []string{
+				"applications",
+			},
+			Verbs: []string{
+				"get",
+				"list",
+				"patch",
+				"update",
+				"watch",
+			},
+		},
+		{
+			APIGroups: []string{
+				"",
+			},
+			Resources: []string{
+				"configmaps",
+				"secrets",
+			},
+			Verbs: []string{
+				"list",
+				"watch",
+			},
+		},
+		{
+			APIGroups: []string{
+				"",
+			},
+			ResourceNames: []string{
+				NotificationsConfigMapName,
+			},
+			Resources: []string{
+				"configmaps",
+			},
+			Verbs: []string{
+				"get",
+			},
+		},
+		{
+			APIGroups: []string{
+				"",
+			},
+			ResourceNames: []string{
+				NotificationsSecretName,
+			},
+			Resources: []string{
+				"secrets",
+			},
+			Verbs: []string{
+				"get",
+			},
+		},
+	}
+}
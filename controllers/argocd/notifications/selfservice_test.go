@@ -0,0 +1,75 @@
+package notifications
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argoproj-labs/argocd-operator/controllers/argocd/argocdcommon"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestNotificationsReconciler_GetNotificationsCommand(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespaces map[string]string
+		want       []string
+	}{
+		{
+			name:       "no self-service namespaces",
+			namespaces: nil,
+			want:       []string{"argocd-notifications", "--loglevel", "info"},
+		},
+		{
+			name:       "self-service namespaces",
+			namespaces: map[string]string{"team-b": "", argocdcommon.TestNamespace: "", "team-a": ""},
+			want: []string{"argocd-notifications", "--loglevel", "info",
+				"--application-namespaces", "team-a,team-b", "--self-service-notification-enabled"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nr := makeTestNotificationsReconciler(t)
+			nr.SelfServiceNamespaces = tt.namespaces
+			assert.Equal(t, tt.want, nr.GetNotificationsCommand())
+		})
+	}
+}
+
+func TestNotificationsReconciler_reconcileSelfServiceNamespaces(t *testing.T) {
+	resourceName = argocdcommon.TestArgoCDName
+	resourceLabels = testExpectedLabels
+	ns := argocdcommon.MakeTestNamespace()
+	teamA := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}
+	teamB := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}}
+
+	nr := makeTestNotificationsReconciler(t, ns, teamA, teamB)
+	nr.SelfServiceNamespaces = map[string]string{"team-a": "", "team-b": "", "missing": ""}
+
+	assert.NoError(t, nr.reconcileSelfServiceNamespaces())
+	for _, namespace := range []string{"team-a", "team-b"} {
+		role := &rbacv1.Role{}
+		assert.NoError(t, nr.Client.Get(context.TODO(), types.NamespacedName{Name: resourceName, Namespace: namespace}, role))
+		assert.Equal(t, getSelfServicePolicyRules(), role.Rules)
+
+		roleBinding := &rbacv1.RoleBinding{}
+		assert.NoError(t, nr.Client.Get(context.TODO(), types.NamespacedName{Name: resourceName, Namespace: namespace}, roleBinding))
+		assert.Equal(t, resourceName, roleBinding.RoleRef.Name)
+		assert.Equal(t, argocdcommon.TestNamespace, roleBinding.Subjects[0].Namespace)
+	}
+
+	// dropping a namespace removes its role and roleBinding
+	nr.SelfServiceNamespaces = map[string]string{"team-a": ""}
+	assert.NoError(t, nr.reconcileSelfServiceNamespaces())
+
+	assert.NoError(t, nr.Client.Get(context.TODO(), types.NamespacedName{Name: resourceName, Namespace: "team-a"}, &rbacv1.Role{}))
+	err := nr.Client.Get(context.TODO(), types.NamespacedName{Name: resourceName, Namespace: "team-b"}, &rbacv1.Role{})
+	assert.True(t, errors.IsNotFound(err))
+	err = nr.Client.Get(context.TODO(), types.NamespacedName{Name: resourceName, Namespace: "team-b"}, &rbacv1.RoleBinding{})
+	assert.True(t, errors.IsNotFound(err))
+}
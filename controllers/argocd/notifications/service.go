@@ -0,0 +1,131 @@
+package notifications
+
+import (
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocd/argocdcommon"
+	"github.com/argoproj-labs/argocd-operator/pkg/cluster"
+	"github.com/argoproj-labs/argocd-operator/pkg/mutation"
+	"github.com/argoproj-labs/argocd-operator/pkg/networking"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func (nr *NotificationsReconciler) reconcileMetricsService() error {
+
+	nr.Logger.Info("reconciling metrics service")
+
+	serviceRequest := networking.ServiceRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        getMetricsServiceName(),
+			Namespace:   nr.Instance.Namespace,
+			Labels:      getMetricsServiceLabels(),
+			Annotations: nr.Instance.Annotations,
+		},
+		Spec:      getMetricsServiceSpec(),
+		Client:    nr.Client,
+		Mutations: []mutation.MutateFunc{mutation.ApplyReconcilerMutation},
+	}
+
+	desiredService, err := networking.RequestService(serviceRequest)
+	if err != nil {
+		nr.Logger.Error(err, "reconcileMetricsService: failed to request service", "name", desiredService.Name, "namespace", desiredService.Namespace)
+		nr.Logger.V(1).Info("reconcileMetricsService: one or more mutations could not be applied")
+		return err
+	}
+
+	namespace, err := cluster.GetNamespace(nr.Instance.Namespace, nr.Client)
+	if err != nil {
+		nr.Logger.Error(err, "reconcileMetricsService: failed to retrieve namespace", "name", nr.Instance.Namespace)
+		return err
+	}
+	if namespace.DeletionTimestamp != nil {
+		if err := nr.deleteService(desiredService.Name, desiredService.Namespace); err != nil {
+			nr.Logger.Error(err, "reconcileMetricsService: failed to delete service", "name", desiredService.Name, "namespace", desiredService.Namespace)
+		}
+		return err
+	}
+
+	existingService, err := networking.GetService(desiredService.Name, desiredService.Namespace, nr.Client)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			nr.Logger.Error(err, "reconcileMetricsService: failed to retrieve service", "name", desiredService.Name, "namespace", desiredService.Namespace)
+			return err
+		}
+
+		if err = controllerutil.SetControllerReference(nr.Instance, desiredService, nr.Scheme); err != nil {
+			nr.Logger.Error(err, "reconcileMetricsService: failed to set owner reference for service", "name", desiredService.Name, "namespace", desiredService.Namespace)
+		}
+
+		if err = networking.CreateService(desiredService, nr.Client); err != nil {
+			nr.Logger.Error(err, "reconcileMetricsService: failed to create service", "name", desiredService.Name, "namespace", desiredService.Namespace)
+			return err
+		}
+		nr.Logger.V(0).Info("reconcileMetricsService: service created", "name", desiredService.Name, "namespace", desiredService.Namespace)
+		return nil
+	}
+
+	serviceChanged := false
+	fieldsToCompare := []struct {
+		existing, desired interface{}
+	}{
+		{&existingService.Spec.Ports, &desiredService.Spec.Ports},
+		{&existingService.Spec.Selector, &desiredService.Spec.Selector},
+		{&existingService.Labels, &desiredService.Labels},
+	}
+
+	for _, field := range fieldsToCompare {
+		argocdcommon.UpdateIfChanged(field.existing, field.desired, nil, &serviceChanged)
+	}
+
+	if serviceChanged {
+		if err = networking.UpdateService(existingService, nr.Client); err != nil {
+			nr.Logger.Error(err, "reconcileMetricsService: failed to update service", "name", existingService.Name, "namespace", existingService.Namespace)
+			return err
+		}
+		nr.Logger.V(0).Info("reconcileMetricsService: service updated", "name", existingService.Name, "namespace", existingService.Namespace)
+	}
+
+	return nil
+}
+
+func (nr *NotificationsReconciler) deleteService(name, namespace string) error {
+	if err := networking.DeleteService(name, namespace, nr.Client); err != nil {
+		nr.Logger.Error(err, "DeleteService: failed to delete service", "name", name, "namespace", namespace)
+		return err
+	}
+	nr.Logger.V(0).Info("DeleteService: service deleted", "name", name, "namespace", namespace)
+	return nil
+}
+
+// getMetricsServiceName returns the name of the Service exposing the metrics of the notifications controller.
+func getMetricsServiceName() string {
+	return util.NameWithSuffix(resourceName, common.ArgoCDMetrics)
+}
+
+// getMetricsServiceLabels returns the labels of the metrics Service, selected by the ServiceMonitor.
+func getMetricsServiceLabels() map[string]string {
+	labels := util.MergeMaps(map[string]string{}, resourceLabels)
+	labels[common.AppK8sKeyName] = getMetricsServiceName()
+	return labels
+}
+
+func getMetricsServiceSpec() corev1.ServiceSpec {
+	return corev1.ServiceSpec{
+		Ports: []corev1.ServicePort{
+			{
+				Name:       common.ArgoCDMetrics,
+				Port:       NotificationsMetricsPort,
+				Protocol:   corev1.ProtocolTCP,
+				TargetPort: intstr.FromInt(NotificationsMetricsPort),
+			},
+		},
+		Selector: map[string]string{
+			common.AppK8sKeyName: resourceName,
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package notifications
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocd/argocdcommon"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestNotificationsReconciler_reconcileMetricsService(t *testing.T) {
+	resourceName = argocdcommon.TestArgoCDName
+	resourceLabels = testExpectedLabels
+	ns := argocdcommon.MakeTestNamespace()
+
+	tests := []struct {
+		name        string
+		setupClient func() *NotificationsReconciler
+		wantErr     bool
+	}{
+		{
+			name: "create a metrics service",
+			setupClient: func() *NotificationsReconciler {
+				return makeTestNotificationsReconciler(t, ns)
+			},
+			wantErr: false,
+		},
+		{
+			name: "update a metrics service",
+			setupClient: func() *NotificationsReconciler {
+				outdatedService := &corev1.Service{}
+				outdatedService.Name = getMetricsServiceName()
+				outdatedService.Namespace = argocdcommon.TestNamespace
+				outdatedService.Labels = argocdcommon.TestKVP
+				return makeTestNotificationsReconciler(t, outdatedService, ns)
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nr := tt.setupClient()
+			err := nr.reconcileMetricsService()
+			if (err != nil) != tt.wantErr {
+				if tt.wantErr {
+					t.Errorf("Expected error but did not get one")
+				} else {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			}
+
+			updatedService := &corev1.Service{}
+			err = nr.Client.Get(context.TODO(), types.NamespacedName{Name: getMetricsServiceName(), Namespace: argocdcommon.TestNamespace}, updatedService)
+			if err != nil {
+				t.Fatalf("Could not get updated Service: %v", err)
+			}
+			assert.Equal(t, getMetricsServiceLabels(), updatedService.Labels)
+			assert.Equal(t, common.ArgoCDMetrics, updatedService.Spec.Ports[0].Name)
+			assert.Equal(t, int32(NotificationsMetricsPort), updatedService.Spec.Ports[0].Port)
+			assert.Equal(t, resourceName, updatedService.Spec.Selector[common.AppK8sKeyName])
+		})
+	}
+}
+
+func TestNotificationsReconciler_DeleteService(t *testing.T) {
+	ns := argocdcommon.MakeTestNamespace()
+	resourceName = argocdcommon.TestArgoCDName
+	tests := []struct {
+		name        string
+		setupClient func() *NotificationsReconciler
+		wantErr     bool
+	}{
+		{
+			name: "successful delete",
+			setupClient: func() *NotificationsReconciler {
+				return makeTestNotificationsReconciler(t, ns)
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nr := tt.setupClient()
+			if err := nr.deleteService(getMetricsServiceName(), ns.Name); (err != nil) != tt.wantErr {
+				if tt.wantErr {
+					t.Errorf("Expected error but did not get one")
+				} else {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package notifications
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/argoproj-labs/argocd-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -546,6 +549,19 @@ teams:
 	return notificationsConfig
 }
 
+// getSelfServiceNamespaces returns the sorted self-service namespaces, other than the namespace of the ArgoCD, the
+// notifications controller is allowed to watch.
+func (nr *NotificationsReconciler) getSelfServiceNamespaces() []string {
+	namespaces := []string{}
+	for namespace := range nr.SelfServiceNamespaces {
+		if namespace != nr.Instance.Namespace {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
 func (nr *NotificationsReconciler) GetNotificationsCommand() []string {
 
 	cmd := make([]string, 0)
@@ -554,6 +570,11 @@ func (nr *NotificationsReconciler) GetNotificationsCommand() []string {
 	cmd = append(cmd, "--loglevel")
 	cmd = append(cmd, util.GetLogLevel(nr.Instance.Spec.Notifications.LogLevel))
 
+	if namespaces := nr.getSelfServiceNamespaces(); len(namespaces) > 0 {
+		cmd = append(cmd, "--application-namespaces", strings.Join(namespaces, ","))
+		cmd = append(cmd, "--self-service-notification-enabled")
+	}
+
 	return cmd
 }
 
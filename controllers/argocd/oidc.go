@@ -0,0 +1,158 @@
+package argocd
+
+import (
+	"fmt"
+	"net/url"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+)
+
+// oidcProviderClaim represents a claim requested in the ID token in the oidc.config.
+type oidcProviderClaim struct {
+	Essential bool     `yaml:"essential,omitempty"`
+	Value     string   `yaml:"value,omitempty"`
+	Values    []string `yaml:"values,omitempty"`
+}
+
+// oidcProviderConfig represents the oidc.config of Argo CD for an existing OIDC provider.
+type oidcProviderConfig struct {
+	Name                   string                       `yaml:"name"`
+	Issuer                 string                       `yaml:"issuer"`
+	ClientID               string                       `yaml:"clientID"`
+	ClientSecret           string                       `yaml:"clientSecret,omitempty"`
+	RequestedScopes        []string                     `yaml:"requestedScopes,omitempty"`
+	RequestedIDTokenClaims map[string]oidcProviderClaim `yaml:"requestedIDTokenClaims,omitempty"`
+	LogoutURL              string                       `yaml:"logoutURL,omitempty"`
+	RootCA                 string                       `yaml:"rootCA,omitempty"`
+}
+
+// UseOIDC determines whether Argo CD should be configured with an existing OIDC provider or not
+func UseOIDC(cr *argoproj.ArgoCD) bool {
+	if cr.Spec.SSO != nil {
+		return cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeOIDC
+	}
+
+	return false
+}
+
+// validateOIDCSpec returns an error if the given OIDC provider configuration is not valid.
+func validateOIDCSpec(spec *argoproj.ArgoCDOIDCSpec) error {
+	if spec == nil {
+		return fmt.Errorf("must supply .spec.sso.oidc when requested SSO provider is oidc")
+	}
+
+	issuer, err := url.Parse(spec.Issuer)
+	if err != nil {
+		return fmt.Errorf("invalid oidc issuer %q: %w", spec.Issuer, err)
+	}
+	if (issuer.Scheme != "https" && issuer.Scheme != "http") || issuer.Host == "" {
+		return fmt.Errorf("invalid oidc issuer %q: must be an absolute http(s) URL", spec.Issuer)
+	}
+	if issuer.RawQuery != "" || issuer.Fragment != "" {
+		return fmt.Errorf("invalid oidc issuer %q: must not contain a query or fragment", spec.Issuer)
+	}
+
+	if spec.ClientID == "" {
+		return fmt.Errorf("must supply .spec.sso.oidc.clientID")
+	}
+	if spec.ClientSecret != nil && (spec.ClientSecret.Name == "" || spec.ClientSecret.Key == "") {
+		return fmt.Errorf("must supply name and key of .spec.sso.oidc.clientSecret")
+	}
+	if spec.RootCA != nil && (spec.RootCA.Name == "" || spec.RootCA.Key == "") {
+		return fmt.Errorf("must supply name and key of .spec.sso.oidc.rootCA")
+	}
+	return nil
+}
+
+// getOIDCProviderConfig will return the oidc.config for the OIDC provider of the given ArgoCD. The client secret is
+// copied into the Argo CD secret and referenced with the $<key> syntax.
+func (r *ArgoCDReconciler) getOIDCProviderConfig(cr *argoproj.ArgoCD) (string, error) {
+	spec := cr.Spec.SSO.OIDC
+	if err := validateOIDCSpec(spec); err != nil {
+		return "", err
+	}
+
+	config := oidcProviderConfig{
+		Name:            "OIDC",
+		Issuer:          spec.Issuer,
+		ClientID:        spec.ClientID,
+		RequestedScopes: []string{"openid", "profile", "email"},
+		LogoutURL:       spec.LogoutURL,
+	}
+	if spec.Name != "" {
+		config.Name = spec.Name
+	}
+	if len(spec.RequestedScopes) > 0 {
+		config.RequestedScopes = spec.RequestedScopes
+	}
+	if len(spec.RequestedIDTokenClaims) > 0 {
+		config.RequestedIDTokenClaims = map[string]oidcProviderClaim{}
+		for name, claim := range spec.RequestedIDTokenClaims {
+			config.RequestedIDTokenClaims[name] = oidcProviderClaim(claim)
+		}
+	}
+
+	if spec.ClientSecret != nil {
+		if _, err := r.getOIDCClientSecret(cr); err != nil {
+			return "", err
+		}
+		config.ClientSecret = "$" + common.ArgoCDOIDCClientSecretKey
+	}
+
+	if spec.RootCA != nil {
+		cm := newConfigMapWithName(spec.RootCA.Name, cr)
+		if err := util.FetchObject(r.Client, cr.Namespace, cm.Name, cm); err != nil {
+			return "", fmt.Errorf("unable to fetch oidc root CA configmap %s: %w", cm.Name, err)
+		}
+		rootCA, ok := cm.Data[spec.RootCA.Key]
+		if !ok {
+			return "", fmt.Errorf("oidc root CA configmap %s has no key %s", cm.Name, spec.RootCA.Key)
+		}
+		config.RootCA = rootCA
+	}
+
+	bytes, err := yaml.Marshal(config)
+	return string(bytes), err
+}
+
+// getOIDCClientSecret returns the client secret of the OIDC provider of the given ArgoCD, read from the referenced
+// Secret, or nil if no client secret is referenced.
+func (r *ArgoCDReconciler) getOIDCClientSecret(cr *argoproj.ArgoCD) ([]byte, error) {
+	if !UseOIDC(cr) || cr.Spec.SSO.OIDC == nil || cr.Spec.SSO.OIDC.ClientSecret == nil {
+		return nil, nil
+	}
+	ref := cr.Spec.SSO.OIDC.ClientSecret
+
+	secret := util.NewSecretWithName(cr, ref.Name)
+	if err := util.FetchObject(r.Client, cr.Namespace, secret.Name, secret); err != nil {
+		return nil, fmt.Errorf("unable to fetch oidc client secret %s: %w", ref.Name, err)
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("oidc client secret %s has no key %s", ref.Name, ref.Key)
+	}
+	return value, nil
+}
+
+// syncOIDCClientSecret copies the given OIDC client secret into the Argo CD secret, or removes it when nil. It
+// returns true if the Argo CD secret has changed.
+func syncOIDCClientSecret(secret *corev1.Secret, value []byte) bool {
+	current, ok := secret.Data[common.ArgoCDOIDCClientSecretKey]
+	if value == nil {
+		if !ok {
+			return false
+		}
+		delete(secret.Data, common.ArgoCDOIDCClientSecretKey)
+		return true
+	}
+	if ok && string(current) == string(value) {
+		return false
+	}
+	secret.Data[common.ArgoCDOIDCClientSecretKey] = value
+	return true
+}
@@ -0,0 +1,176 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+)
+
+func TestArgoCDReconciler_reconcileArgoConfigMap_withOIDC(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeOIDC,
+			OIDC: &argoproj.ArgoCDOIDCSpec{
+				Name:     "Okta",
+				Issuer:   "https://example.okta.com",
+				ClientID: "argocd",
+				ClientSecret: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "okta-oidc"},
+					Key:                  "clientSecret",
+				},
+				RequestedScopes: []string{"openid", "profile", "email", "groups"},
+				RequestedIDTokenClaims: map[string]argoproj.ArgoCDOIDCClaim{
+					"groups": {Essential: true},
+				},
+				RootCA: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "okta-ca"},
+					Key:                  "ca.crt",
+				},
+				LogoutURL: "https://example.okta.com/logout",
+			},
+		}
+	})
+	clientSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "okta-oidc", Namespace: a.Namespace},
+		Data:       map[string][]byte{"clientSecret": []byte("s3cr3t")},
+	}
+	rootCA := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "okta-ca", Namespace: a.Namespace},
+		Data:       map[string]string{"ca.crt": "test-ca"},
+	}
+
+	r := makeTestReconciler(t, a, clientSecret, rootCA)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+
+	want := `name: Okta
+issuer: https://example.okta.com
+clientID: argocd
+clientSecret: $oidc.clientSecret
+requestedScopes:
+- openid
+- profile
+- email
+- groups
+requestedIDTokenClaims:
+  groups:
+    essential: true
+logoutURL: https://example.okta.com/logout
+rootCA: test-ca
+`
+	assert.Equal(t, want, cm.Data[common.ArgoCDKeyOIDCConfig])
+
+	// The referenced Secret is left untouched.
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: clientSecret.Name, Namespace: a.Namespace}, clientSecret))
+	assert.Empty(t, clientSecret.Labels)
+}
+
+func TestArgoCDReconciler_reconcileArgoSecret_withOIDC(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeOIDC,
+			OIDC: &argoproj.ArgoCDOIDCSpec{
+				Issuer:   "https://example.okta.com",
+				ClientID: "argocd",
+				ClientSecret: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "okta-oidc"},
+					Key:                  "clientSecret",
+				},
+			},
+		}
+	})
+	clientSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "okta-oidc", Namespace: a.Namespace},
+		Data:       map[string][]byte{"clientSecret": []byte("s3cr3t")},
+	}
+	clusterSecret := util.NewSecretWithSuffix(a, "cluster")
+	clusterSecret.Data = map[string][]byte{common.ArgoCDKeyAdminPassword: []byte("something")}
+	tlsSecret := util.NewSecretWithSuffix(a, "tls")
+
+	r := makeTestReconciler(t, a, clientSecret, clusterSecret, tlsSecret)
+	argoSecret := &corev1.Secret{}
+	getArgoSecret := func() {
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: a.Namespace}, argoSecret))
+	}
+
+	// the client secret is copied into the Argo CD secret
+	assert.NoError(t, r.reconcileArgoSecret(a))
+	getArgoSecret()
+	assert.Equal(t, "s3cr3t", string(argoSecret.Data[common.ArgoCDOIDCClientSecretKey]))
+
+	// a rotated client secret is copied again
+	clientSecret.Data["clientSecret"] = []byte("r0t4t3d")
+	assert.NoError(t, r.Client.Update(context.TODO(), clientSecret))
+	assert.NoError(t, r.reconcileArgoSecret(a))
+	getArgoSecret()
+	assert.Equal(t, "r0t4t3d", string(argoSecret.Data[common.ArgoCDOIDCClientSecretKey]))
+
+	// the client secret is removed along with the oidc provider
+	a.Spec.SSO = nil
+	assert.NoError(t, r.reconcileArgoSecret(a))
+	getArgoSecret()
+	assert.NotContains(t, argoSecret.Data, common.ArgoCDOIDCClientSecretKey)
+}
+
+func TestArgoCDReconciler_reconcileArgoConfigMap_withOIDCMissingSecret(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeOIDC,
+			OIDC: &argoproj.ArgoCDOIDCSpec{
+				Issuer:   "https://example.okta.com",
+				ClientID: "argocd",
+				ClientSecret: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "okta-oidc"},
+					Key:                  "clientSecret",
+				},
+			},
+		}
+	})
+
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+	assert.Error(t, r.reconcileArgoConfigMap(a))
+}
+
+func TestValidateOIDCSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *argoproj.ArgoCDOIDCSpec
+		wantErr bool
+	}{
+		{"valid", &argoproj.ArgoCDOIDCSpec{Issuer: "https://idp.example.com/realms/argocd", ClientID: "argocd"}, false},
+		{"missing spec", nil, true},
+		{"missing scheme", &argoproj.ArgoCDOIDCSpec{Issuer: "idp.example.com", ClientID: "argocd"}, true},
+		{"unsupported scheme", &argoproj.ArgoCDOIDCSpec{Issuer: "ftp://idp.example.com", ClientID: "argocd"}, true},
+		{"query", &argoproj.ArgoCDOIDCSpec{Issuer: "https://idp.example.com?tenant=1", ClientID: "argocd"}, true},
+		{"missing client id", &argoproj.ArgoCDOIDCSpec{Issuer: "https://idp.example.com"}, true},
+		{"missing secret key", &argoproj.ArgoCDOIDCSpec{Issuer: "https://idp.example.com", ClientID: "argocd",
+			ClientSecret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oidc"}}}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateOIDCSpec(test.spec)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
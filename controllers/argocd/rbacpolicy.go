@@ -0,0 +1,263 @@
+package argocd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v2/util/assets"
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocd/argocdcommon"
+)
+
+const (
+	rbacRolePrefix         = "role:"
+	rbacEffectAllow        = "allow"
+	rbacEffectDeny         = "deny"
+	rbacPolicyMatcherRegex = "regex"
+)
+
+// rbacResources are the resources known to the Argo CD RBAC enforcer.
+var rbacResources = map[string]bool{
+	"applications":    true,
+	"applicationsets": true,
+	"clusters":        true,
+	"projects":        true,
+	"repositories":    true,
+	"certificates":    true,
+	"accounts":        true,
+	"gpgkeys":         true,
+	"logs":            true,
+	"exec":            true,
+	"extensions":      true,
+}
+
+// rbacBuiltinRoles are the roles defined by the built-in policy of Argo CD.
+var rbacBuiltinRoles = map[string]bool{
+	"role:admin":    true,
+	"role:readonly": true,
+}
+
+// getRBACRoleName returns the given role name with the role: prefix.
+func getRBACRoleName(name string) string {
+	if strings.HasPrefix(name, rbacRolePrefix) {
+		return name
+	}
+	return rbacRolePrefix + name
+}
+
+// getRBACPolicyField returns the given value quoted for a policy CSV line, if needed.
+func getRBACPolicyField(value string) string {
+	if strings.ContainsAny(value, ",\"\n") || strings.TrimSpace(value) != value {
+		return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+	}
+	return value
+}
+
+// getRBACPolicyLine returns a policy CSV line for the given tokens.
+func getRBACPolicyLine(tokens ...string) string {
+	fields := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		fields = append(fields, getRBACPolicyField(token))
+	}
+	return strings.Join(fields, ", ")
+}
+
+// getRBACPolicyRules will return the policy CSV lines for the typed roles and group bindings of the given ArgoCD.
+func getRBACPolicyRules(cr *argoproj.ArgoCD) string {
+	lines := make([]string, 0)
+	for _, role := range cr.Spec.RBAC.Roles {
+		for _, policy := range role.Policies {
+			effect := policy.Effect
+			if effect == "" {
+				effect = rbacEffectAllow
+			}
+			lines = append(lines, getRBACPolicyLine("p", getRBACRoleName(role.Name), policy.Resource, policy.Action, policy.Object, effect))
+		}
+	}
+	for _, binding := range cr.Spec.RBAC.GroupBindings {
+		lines = append(lines, getRBACPolicyLine("g", binding.Group, getRBACRoleName(binding.Role)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hasRBACPolicyRules returns true if the given ArgoCD defines typed roles or group bindings.
+func hasRBACPolicyRules(cr *argoproj.ArgoCD) bool {
+	return len(cr.Spec.RBAC.Roles) > 0 || len(cr.Spec.RBAC.GroupBindings) > 0
+}
+
+// validateRBACSpec will ensure that the typed roles and group bindings of the given ArgoCD are valid.
+func validateRBACSpec(cr *argoproj.ArgoCD) error {
+	roles := make(map[string]bool)
+	for i, role := range cr.Spec.RBAC.Roles {
+		name := strings.TrimPrefix(role.Name, rbacRolePrefix)
+		if name == "" {
+			return fmt.Errorf("role %d must have a name", i)
+		}
+		if roles[getRBACRoleName(name)] {
+			return fmt.Errorf("role %s is defined more than once", getRBACRoleName(name))
+		}
+		roles[getRBACRoleName(name)] = true
+
+		for j, policy := range role.Policies {
+			if policy.Resource == "" || policy.Action == "" || policy.Object == "" {
+				return fmt.Errorf("policy %d of role %s must have a resource, an action and an object", j, getRBACRoleName(name))
+			}
+		}
+	}
+
+	for i, binding := range cr.Spec.RBAC.GroupBindings {
+		if binding.Group == "" {
+			return fmt.Errorf("group binding %d must have a group", i)
+		}
+		role := getRBACRoleName(binding.Role)
+		if !roles[role] && !rbacBuiltinRoles[role] {
+			return fmt.Errorf("group binding %d refers to undefined role %s", i, role)
+		}
+	}
+	return nil
+}
+
+// parseRBACPolicy will return the tokens of each policy line in the given policy CSV.
+func parseRBACPolicy(policy string, matcherMode string) ([][]string, error) {
+	rules := make([][]string, 0)
+	for i, line := range strings.Split(policy, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		reader := csv.NewReader(strings.NewReader(line))
+		reader.TrimLeadingSpace = true
+		tokens, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		switch tokens[0] {
+		case "p":
+			if len(tokens) != 6 {
+				return nil, fmt.Errorf("line %d: policy rules must have 6 fields, got %d", i+1, len(tokens))
+			}
+			if tokens[5] != rbacEffectAllow && tokens[5] != rbacEffectDeny {
+				return nil, fmt.Errorf("line %d: unknown effect %s, must be allow or deny", i+1, tokens[5])
+			}
+			if matcherMode != rbacPolicyMatcherRegex && !rbacResources[tokens[2]] && !strings.ContainsAny(tokens[2], "*?[{") {
+				return nil, fmt.Errorf("line %d: unknown resource %s", i+1, tokens[2])
+			}
+			if matcherMode == rbacPolicyMatcherRegex {
+				for _, pattern := range tokens[2:5] {
+					if _, err := regexp.Compile(pattern); err != nil {
+						return nil, fmt.Errorf("line %d: %w", i+1, err)
+					}
+				}
+			}
+		case "g":
+			if len(tokens) != 3 {
+				return nil, fmt.Errorf("line %d: role bindings must have 3 fields, got %d", i+1, len(tokens))
+			}
+		default:
+			return nil, fmt.Errorf("line %d: unknown policy type %s, must be p or g", i+1, tokens[0])
+		}
+		rules = append(rules, tokens)
+	}
+	return rules, nil
+}
+
+// rbacPolicyAdapter loads policy rules into the casbin model, satisfying the persist.Adapter interface.
+type rbacPolicyAdapter struct {
+	rules [][]string
+}
+
+func (a *rbacPolicyAdapter) LoadPolicy(m model.Model) error {
+	for _, tokens := range a.rules {
+		m[tokens[0]][tokens[0]].Policy = append(m[tokens[0]][tokens[0]].Policy, tokens[1:])
+	}
+	return nil
+}
+
+func (a *rbacPolicyAdapter) SavePolicy(m model.Model) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (a *rbacPolicyAdapter) AddPolicy(sec string, ptype string, rule []string) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (a *rbacPolicyAdapter) RemovePolicy(sec string, ptype string, rule []string) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (a *rbacPolicyAdapter) RemoveFilteredPolicy(sec string, ptype string, fieldIndex int, fieldValues ...string) error {
+	return fmt.Errorf("not implemented")
+}
+
+// validateRBACPolicy will ensure that the given policy CSV, together with the built-in policy, can be loaded by the
+// casbin model of Argo CD.
+func validateRBACPolicy(policy string, matcherMode string) (err error) {
+	rules, err := parseRBACPolicy(policy, matcherMode)
+	if err != nil {
+		return err
+	}
+	builtin, err := parseRBACPolicy(assets.BuiltinPolicyCSV, "")
+	if err != nil {
+		return err
+	}
+
+	m, err := model.NewModelFromString(assets.ModelConf)
+	if err != nil {
+		return err
+	}
+
+	// casbin panics on policies which do not match the model
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	_, err = casbin.NewEnforcer(m, &rbacPolicyAdapter{rules: append(builtin, rules...)})
+	return err
+}
+
+// reconcileRBACPolicyValidation will validate the RBAC policy of the given ArgoCD and record the result as the
+// RBACPolicyValid condition.
+func (r *ArgoCDReconciler) reconcileRBACPolicyValidation(cr *argoproj.ArgoCD) error {
+	matcherMode := ""
+	if cr.Spec.RBAC.PolicyMatcherMode != nil {
+		matcherMode = *cr.Spec.RBAC.PolicyMatcherMode
+	}
+
+	err := validateRBACSpec(cr)
+	if err == nil {
+		err = validateRBACPolicy(getRBACPolicy(cr), matcherMode)
+	}
+
+	condition := metav1.Condition{
+		Type:               common.ArgoCDConditionRBACPolicyValid,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cr.Generation,
+		Reason:             "Valid",
+		Message:            "RBAC policy is valid",
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Invalid"
+		condition.Message = fmt.Sprintf("RBAC policy is invalid: %v", err)
+	}
+
+	if updateErr := argocdcommon.SetStatusCondition(cr, condition, r.Client); updateErr != nil {
+		return updateErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid RBAC policy: %w", err)
+	}
+	return nil
+}
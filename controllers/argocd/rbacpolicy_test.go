@@ -0,0 +1,172 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestGetRBACPolicy_withRoles(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		policy := "g, platform-team, role:admin"
+		a.Spec.RBAC.Policy = &policy
+		a.Spec.RBAC.Roles = []argoproj.ArgoCDRBACRole{
+			{
+				Name: "developer",
+				Policies: []argoproj.ArgoCDRBACPolicy{
+					{Resource: "applications", Action: "get", Object: "*/*"},
+					{Resource: "applications", Action: "sync", Object: "team-a/*", Effect: "allow"},
+					{Resource: "applications", Action: "delete", Object: "*/*", Effect: "deny"},
+				},
+			},
+		}
+		a.Spec.RBAC.GroupBindings = []argoproj.ArgoCDRBACGroupBinding{
+			{Group: "team-a", Role: "developer"},
+			{Group: "auditors", Role: "role:readonly"},
+		}
+	})
+
+	want := `g, platform-team, role:admin
+p, role:developer, applications, get, */*, allow
+p, role:developer, applications, sync, team-a/*, allow
+p, role:developer, applications, delete, */*, deny
+g, team-a, role:developer
+g, auditors, role:readonly`
+	assert.Equal(t, want, getRBACPolicy(a))
+}
+
+func TestValidateRBACPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      string
+		matcherMode string
+		wantErr     string
+	}{
+		{
+			name:   "valid policy",
+			policy: "# comment\np, role:dev, applications, get, */*, allow\n\ng, my-group, role:dev",
+		},
+		{
+			name:   "quoted object",
+			policy: `p, role:dev, applications, get, "team-a/*", allow`,
+		},
+		{
+			name:    "missing effect",
+			policy:  "p, role:dev, applications, get, */*",
+			wantErr: "line 1: policy rules must have 6 fields, got 5",
+		},
+		{
+			name:    "unknown effect",
+			policy:  "g, my-group, role:dev\np, role:dev, applications, get, */*, maybe",
+			wantErr: "line 2: unknown effect maybe, must be allow or deny",
+		},
+		{
+			name:    "unknown resource",
+			policy:  "p, role:dev, application, get, */*, allow",
+			wantErr: "line 1: unknown resource application",
+		},
+		{
+			name:    "invalid binding",
+			policy:  "g, my-group",
+			wantErr: "line 1: role bindings must have 3 fields, got 2",
+		},
+		{
+			name:    "unknown policy type",
+			policy:  "x, role:dev, applications, get, */*, allow",
+			wantErr: "line 1: unknown policy type x, must be p or g",
+		},
+		{
+			name:        "valid regex",
+			policy:      "p, role:dev, applications, get, team-a/.*, allow",
+			matcherMode: "regex",
+		},
+		{
+			name:        "invalid regex",
+			policy:      "p, role:dev, applications, get, team-a/(, allow",
+			matcherMode: "regex",
+			wantErr:     "line 1: error parsing regexp",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateRBACPolicy(test.policy, test.matcherMode)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestValidateRBACSpec(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.RBAC.Roles = []argoproj.ArgoCDRBACRole{
+			{
+				Name:     "developer",
+				Policies: []argoproj.ArgoCDRBACPolicy{{Resource: "applications", Action: "get", Object: "*/*"}},
+			},
+		}
+		a.Spec.RBAC.GroupBindings = []argoproj.ArgoCDRBACGroupBinding{
+			{Group: "team-a", Role: "developer"},
+			{Group: "auditors", Role: "role:readonly"},
+		}
+	})
+	assert.NoError(t, validateRBACSpec(a))
+
+	a.Spec.RBAC.GroupBindings = append(a.Spec.RBAC.GroupBindings, argoproj.ArgoCDRBACGroupBinding{Group: "team-b", Role: "tester"})
+	assert.EqualError(t, validateRBACSpec(a), "group binding 2 refers to undefined role role:tester")
+
+	a = makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.RBAC.Roles = []argoproj.ArgoCDRBACRole{{Name: "developer"}, {Name: "role:developer"}}
+	})
+	assert.EqualError(t, validateRBACSpec(a), "role role:developer is defined more than once")
+}
+
+func TestReconcileRBAC_withRoles(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.RBAC.Roles = []argoproj.ArgoCDRBACRole{
+			{
+				Name:     "developer",
+				Policies: []argoproj.ArgoCDRBACPolicy{{Resource: "applications", Action: "sync", Object: "team-a/*"}},
+			},
+		}
+		a.Spec.RBAC.GroupBindings = []argoproj.ArgoCDRBACGroupBinding{{Group: "team-a", Role: "developer"}}
+	})
+	r := makeTestReconciler(t, a)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	assert.NoError(t, r.reconcileRBAC(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRBACConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, getRBACPolicy(a), cm.Data[common.ArgoCDKeyRBACPolicyCSV])
+	assert.True(t, meta.IsStatusConditionTrue(a.Status.Conditions, common.ArgoCDConditionRBACPolicyValid))
+
+	// an invalid policy is not written and is reported as a condition
+	policy := "p, role:dev, applications, get"
+	a.Spec.RBAC.Policy = &policy
+	assert.ErrorContains(t, r.reconcileRBAC(a), "line 1: policy rules must have 6 fields")
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRBACConfigMapName, Namespace: a.Namespace}, cm))
+	assert.NotContains(t, cm.Data[common.ArgoCDKeyRBACPolicyCSV], policy)
+
+	cr := &argoproj.ArgoCD{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, cr))
+	condition := meta.FindStatusCondition(cr.Status.Conditions, common.ArgoCDConditionRBACPolicyValid)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, "Invalid", condition.Reason)
+	}
+}
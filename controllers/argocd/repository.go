@@ -0,0 +1,216 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+)
+
+const (
+	// repositorySecretType is the Argo CD secret type of a repository Secret.
+	repositorySecretType = "repository"
+
+	// repositoryCredentialsSecretType is the Argo CD secret type of a repository credential template Secret.
+	repositoryCredentialsSecretType = "repo-creds"
+
+	repositoryTypeGit  = "git"
+	repositoryTypeHelm = "helm"
+	repositoryTypeOCI  = "oci"
+)
+
+// repositoryCredentialKeys are the keys of a repository Secret that may be copied from a referenced credentials Secret.
+var repositoryCredentialKeys = []string{
+	"username",
+	"password",
+	"sshPrivateKey",
+	"tlsClientCertData",
+	"tlsClientCertKey",
+	"githubAppID",
+	"githubAppInstallationID",
+	"githubAppEnterpriseBaseUrl",
+	"githubAppPrivateKey",
+	"gcpServiceAccountKey",
+}
+
+// getRepositories returns the typed repositories of the given ArgoCD for the given Argo CD secret type.
+func getRepositories(cr *argoproj.ArgoCD, secretType string) []argoproj.ArgoCDRepository {
+	if secretType == repositoryCredentialsSecretType {
+		return cr.Spec.RepositoryCredentialTemplates
+	}
+	return cr.Spec.Repositories
+}
+
+// getRepositorySecretName returns the name of the generated Secret for the given repository.
+func getRepositorySecretName(cr *argoproj.ArgoCD, secretType string, repo argoproj.ArgoCDRepository) string {
+	if secretType == repositoryCredentialsSecretType {
+		return fmt.Sprintf("%s-repo-creds-%s", cr.Name, repo.Name)
+	}
+	return fmt.Sprintf("%s-repo-%s", cr.Name, repo.Name)
+}
+
+// getRepositoryCredentialsSecretNames returns the names of the credentials Secrets referenced by the typed
+// repositories and repository credential templates of the given ArgoCD.
+func getRepositoryCredentialsSecretNames(cr *argoproj.ArgoCD) []string {
+	names := []string{}
+	for _, secretType := range []string{repositorySecretType, repositoryCredentialsSecretType} {
+		for _, repo := range getRepositories(cr, secretType) {
+			if repo.CredentialsSecret != nil && !util.ContainsString(names, repo.CredentialsSecret.Name) {
+				names = append(names, repo.CredentialsSecret.Name)
+			}
+		}
+	}
+	return names
+}
+
+// validateRepositories ensures that the typed repositories of the given Argo CD secret type have unique names and a URL.
+func validateRepositories(cr *argoproj.ArgoCD, secretType string) error {
+	field := ".spec.repositories"
+	if secretType == repositoryCredentialsSecretType {
+		field = ".spec.repositoryCredentialTemplates"
+	}
+
+	names := map[string]bool{}
+	for _, repo := range getRepositories(cr, secretType) {
+		if len(repo.Name) <= 0 {
+			return fmt.Errorf("%s must not contain a repository without a name", field)
+		}
+		if names[repo.Name] {
+			return fmt.Errorf("%s must not contain more than one repository named %s", field, repo.Name)
+		}
+		names[repo.Name] = true
+		if len(repo.URL) <= 0 {
+			return fmt.Errorf("%s must not contain a repository without a url, found %s", field, repo.Name)
+		}
+	}
+	return nil
+}
+
+// getDesiredRepositorySecret returns the labelled Argo CD repository Secret for the given repository. Credentials are
+// copied from the referenced credentials Secret.
+func (r *ArgoCDReconciler) getDesiredRepositorySecret(cr *argoproj.ArgoCD, secretType string, repo argoproj.ArgoCDRepository) (*corev1.Secret, error) {
+	secret := util.NewSecretWithName(cr, getRepositorySecretName(cr, secretType, repo))
+	secret.Labels[common.ArgoCDArgoprojKeySecretType] = secretType
+
+	secret.Data = map[string][]byte{
+		"url": []byte(repo.URL),
+	}
+	if secretType == repositorySecretType {
+		secret.Data["name"] = []byte(repo.Name)
+	}
+
+	switch repo.Type {
+	case repositoryTypeHelm:
+		secret.Data["type"] = []byte(repositoryTypeHelm)
+	case repositoryTypeOCI:
+		secret.Data["type"] = []byte(repositoryTypeHelm)
+		secret.Data["enableOCI"] = []byte("true")
+	default:
+		secret.Data["type"] = []byte(repositoryTypeGit)
+	}
+
+	if len(repo.Project) > 0 {
+		secret.Data["project"] = []byte(repo.Project)
+	}
+
+	if repo.CredentialsSecret != nil {
+		credentials := util.NewSecretWithName(cr, repo.CredentialsSecret.Name)
+		if !util.IsObjectFound(r.Client, cr.Namespace, credentials.Name, credentials) {
+			return nil, fmt.Errorf("secret %s referenced by repository %s not found", repo.CredentialsSecret.Name, repo.Name)
+		}
+		for _, key := range repositoryCredentialKeys {
+			if value, ok := credentials.Data[key]; ok {
+				secret.Data[key] = value
+			}
+		}
+	}
+	return secret, nil
+}
+
+// reconcileRepositorySecrets will ensure that a labelled Secret of the given Argo CD secret type is present for each
+// typed repository of the given ArgoCD, and that the Secrets of repositories dropped from the spec are removed.
+func (r *ArgoCDReconciler) reconcileRepositorySecrets(cr *argoproj.ArgoCD, secretType string) error {
+	if err := validateRepositories(cr, secretType); err != nil {
+		return err
+	}
+
+	desiredNames := map[string]bool{}
+	for _, repo := range getRepositories(cr, secretType) {
+		desired, err := r.getDesiredRepositorySecret(cr, secretType, repo)
+		if err != nil {
+			return err
+		}
+		desiredNames[desired.Name] = true
+
+		existing := &corev1.Secret{}
+		if !util.IsObjectFound(r.Client, cr.Namespace, desired.Name, existing) {
+			if err := controllerutil.SetControllerReference(cr, desired, r.Scheme); err != nil {
+				return err
+			}
+			log.Info(fmt.Sprintf("creating %s secret %s for ArgoCD %s in namespace %s", secretType, desired.Name, cr.Name, cr.Namespace))
+			if err := r.Client.Create(context.TODO(), desired); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if reflect.DeepEqual(existing.Data, desired.Data) && reflect.DeepEqual(existing.Labels, desired.Labels) {
+			continue
+		}
+		existing.Data = desired.Data
+		existing.Labels = desired.Labels
+		log.Info(fmt.Sprintf("updating %s secret %s for ArgoCD %s in namespace %s", secretType, existing.Name, cr.Name, cr.Namespace))
+		if err := r.Client.Update(context.TODO(), existing); err != nil {
+			return err
+		}
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := r.Client.List(context.TODO(), secrets, client.InNamespace(cr.Namespace), client.MatchingLabels{
+		common.ArgoCDArgoprojKeySecretType: secretType,
+	}); err != nil {
+		return err
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if desiredNames[secret.Name] || !metav1.IsControlledBy(secret, cr) {
+			continue
+		}
+		log.Info(fmt.Sprintf("deleting %s secret %s for ArgoCD %s in namespace %s", secretType, secret.Name, cr.Name, cr.Namespace))
+		if err := r.Client.Delete(context.TODO(), secret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileRepositories will ensure that the Secrets for the typed repositories and repository credential templates
+// of the given ArgoCD are present.
+func (r *ArgoCDReconciler) reconcileRepositories(cr *argoproj.ArgoCD) error {
+	if err := r.reconcileRepositorySecrets(cr, repositorySecretType); err != nil {
+		return err
+	}
+	return r.reconcileRepositorySecrets(cr, repositoryCredentialsSecretType)
+}
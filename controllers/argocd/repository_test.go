@@ -0,0 +1,189 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func makeTestRepositoryCredentialsSecret(cr *argoproj.ArgoCD) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-credentials", Namespace: cr.Namespace},
+		Data: map[string][]byte{
+			"username": []byte("bot"),
+			"password": []byte("s3cr3t"),
+			"unused":   []byte("ignored"),
+		},
+	}
+}
+
+func TestReconcileRepositories(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repositories = []argoproj.ArgoCDRepository{
+			{
+				Name:    "guestbook",
+				URL:     "https://github.com/example/guestbook.git",
+				Project: "team-a",
+				CredentialsSecret: &corev1.LocalObjectReference{
+					Name: "github-credentials",
+				},
+			},
+			{
+				Name: "charts",
+				URL:  "registry.example.com/charts",
+				Type: "oci",
+			},
+		}
+		a.Spec.RepositoryCredentialTemplates = []argoproj.ArgoCDRepository{
+			{
+				Name: "github",
+				URL:  "https://github.com/example",
+				CredentialsSecret: &corev1.LocalObjectReference{
+					Name: "github-credentials",
+				},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a, makeTestRepositoryCredentialsSecret(a))
+
+	assert.NoError(t, r.reconcileRepositories(a))
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-guestbook", Namespace: a.Namespace}, secret))
+	assert.Equal(t, "repository", secret.Labels[common.ArgoCDArgoprojKeySecretType])
+	assert.True(t, metav1.IsControlledBy(secret, a))
+	assert.Equal(t, map[string][]byte{
+		"name":     []byte("guestbook"),
+		"url":      []byte("https://github.com/example/guestbook.git"),
+		"type":     []byte("git"),
+		"project":  []byte("team-a"),
+		"username": []byte("bot"),
+		"password": []byte("s3cr3t"),
+	}, secret.Data)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-charts", Namespace: a.Namespace}, secret))
+	assert.Equal(t, "helm", string(secret.Data["type"]))
+	assert.Equal(t, "true", string(secret.Data["enableOCI"]))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-creds-github", Namespace: a.Namespace}, secret))
+	assert.Equal(t, "repo-creds", secret.Labels[common.ArgoCDArgoprojKeySecretType])
+	assert.Equal(t, "https://github.com/example", string(secret.Data["url"]))
+	assert.Equal(t, "s3cr3t", string(secret.Data["password"]))
+	assert.NotContains(t, secret.Data, "name")
+}
+
+func TestReconcileRepositories_update(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repositories = []argoproj.ArgoCDRepository{
+			{
+				Name:    "guestbook",
+				URL:     "https://github.com/example/guestbook.git",
+				Project: "team-a",
+				CredentialsSecret: &corev1.LocalObjectReference{
+					Name: "github-credentials",
+				},
+			},
+		}
+	})
+	credentials := makeTestRepositoryCredentialsSecret(a)
+	r := makeTestReconciler(t, a, credentials)
+	assert.NoError(t, r.reconcileRepositories(a))
+
+	// rotated credentials are copied into the repository Secrets
+	credentials.Data["password"] = []byte("r0t4t3d")
+	assert.NoError(t, r.Client.Update(context.TODO(), credentials))
+	assert.NoError(t, r.reconcileRepositories(a))
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-guestbook", Namespace: a.Namespace}, secret))
+	assert.Equal(t, "r0t4t3d", string(secret.Data["password"]))
+}
+
+func TestReconcileRepositories_removed(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repositories = []argoproj.ArgoCDRepository{
+			{
+				Name:    "guestbook",
+				URL:     "https://github.com/example/guestbook.git",
+				Project: "team-a",
+				CredentialsSecret: &corev1.LocalObjectReference{
+					Name: "github-credentials",
+				},
+			},
+			{
+				Name: "charts",
+				URL:  "registry.example.com/charts",
+				Type: "oci",
+			},
+		}
+		a.Spec.RepositoryCredentialTemplates = []argoproj.ArgoCDRepository{
+			{
+				Name: "github",
+				URL:  "https://github.com/example",
+				CredentialsSecret: &corev1.LocalObjectReference{
+					Name: "github-credentials",
+				},
+			},
+		}
+	})
+	unmanaged := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "private-repo",
+			Namespace: a.Namespace,
+			Labels:    map[string]string{common.ArgoCDArgoprojKeySecretType: "repository"},
+		},
+		Data: map[string][]byte{"url": []byte("https://github.com/example/private.git")},
+	}
+	r := makeTestReconciler(t, a, makeTestRepositoryCredentialsSecret(a), unmanaged)
+	assert.NoError(t, r.reconcileRepositories(a))
+
+	a.Spec.Repositories = a.Spec.Repositories[:1]
+	a.Spec.RepositoryCredentialTemplates = nil
+	assert.NoError(t, r.reconcileRepositories(a))
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-guestbook", Namespace: a.Namespace}, secret))
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-charts", Namespace: a.Namespace}, secret))
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-creds-github", Namespace: a.Namespace}, secret))
+
+	// Secrets not generated by the operator are left alone
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "private-repo", Namespace: a.Namespace}, secret))
+}
+
+func TestReconcileRepositories_invalid(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repositories = []argoproj.ArgoCDRepository{
+			{
+				Name:    "guestbook",
+				URL:     "https://github.com/example/guestbook.git",
+				Project: "team-a",
+				CredentialsSecret: &corev1.LocalObjectReference{
+					Name: "github-credentials",
+				},
+			},
+			{
+				Name: "charts",
+				URL:  "registry.example.com/charts",
+				Type: "oci",
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.EqualError(t, r.reconcileRepositories(a), "secret github-credentials referenced by repository guestbook not found")
+
+	a.Spec.Repositories = append(a.Spec.Repositories, argoproj.ArgoCDRepository{Name: "charts", URL: "https://charts.example.com"})
+	assert.EqualError(t, r.reconcileRepositories(a), ".spec.repositories must not contain more than one repository named charts")
+}
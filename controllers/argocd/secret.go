@@ -16,7 +16,7 @@ package argocd
 
 import (
 	"context"
-	"crypto/rsa"
+	"crypto"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/json"
@@ -31,10 +31,12 @@ import (
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocd/argocdcommon"
 	util "github.com/argoproj-labs/argocd-operator/pkg/util"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -82,12 +84,18 @@ func nowNano() string {
 func newCASecret(cr *argoproj.ArgoCD) (*corev1.Secret, error) {
 	secret := util.NewTLSSecret(cr, "ca")
 
-	key, err := util.NewPrivateKey()
+	profile := getCertificateProfile(cr)
+	key, err := util.NewPrivateKeyForProfile(profile)
 	if err != nil {
 		return nil, err
 	}
 
-	cert, err := util.NewSelfSignedCACertificate(cr.Name, key)
+	cert, err := util.NewSelfSignedCACertificateForProfile(cr.Name, key, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedKey, err := util.EncodePrivateKeyPEM(key)
 	if err != nil {
 		return nil, err
 	}
@@ -96,17 +104,18 @@ func newCASecret(cr *argoproj.ArgoCD) (*corev1.Secret, error) {
 	secret.Data = map[string][]byte{
 		corev1.TLSCertKey:              util.EncodeCertificatePEM(cert),
 		corev1.ServiceAccountRootCAKey: util.EncodeCertificatePEM(cert),
-		corev1.TLSPrivateKeyKey:        util.EncodePrivateKeyPEM(key),
+		corev1.TLSPrivateKeyKey:        encodedKey,
 	}
 
 	return secret, nil
 }
 
 // newCertificateSecret creates a new secret using the given name suffix for the given TLS certificate.
-func newCertificateSecret(suffix string, caCert *x509.Certificate, caKey *rsa.PrivateKey, cr *argoproj.ArgoCD) (*corev1.Secret, error) {
+func newCertificateSecret(suffix string, caCert *x509.Certificate, caKey crypto.Signer, cr *argoproj.ArgoCD) (*corev1.Secret, error) {
 	secret := util.NewTLSSecret(cr, suffix)
 
-	key, err := util.NewPrivateKey()
+	profile := getCertificateProfile(cr)
+	key, err := util.NewPrivateKeyForProfile(profile)
 	if err != nil {
 		return nil, err
 	}
@@ -131,14 +140,19 @@ func newCertificateSecret(suffix string, caCert *x509.Certificate, caKey *rsa.Pr
 		dnsNames = append(dnsNames, getPrometheusHost(cr))
 	}
 
-	cert, err := util.NewSignedCertificate(cfg, dnsNames, key, caCert, caKey)
+	cert, err := util.NewSignedCertificateForProfile(cfg, dnsNames, key, caCert, caKey, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedKey, err := util.EncodePrivateKeyPEM(key)
 	if err != nil {
 		return nil, err
 	}
 
 	secret.Data = map[string][]byte{
 		corev1.TLSCertKey:       util.EncodeCertificatePEM(cert),
-		corev1.TLSPrivateKeyKey: util.EncodePrivateKeyPEM(key),
+		corev1.TLSPrivateKeyKey: encodedKey,
 	}
 
 	return secret, nil
@@ -189,6 +203,13 @@ func (r *ArgoCDReconciler) reconcileArgoSecret(cr *argoproj.ArgoCD) error {
 			return nil
 		}
 		secret.Data[common.ArgoCDDexSecretKey] = []byte(*dexOIDCClientSecret)
+		syncDexConnectorSecrets(secret, r.getDexConnectorSecretData(cr))
+	}
+
+	if oidcClientSecret, err := r.getOIDCClientSecret(cr); err != nil {
+		log.Info(err.Error())
+	} else {
+		syncOIDCClientSecret(secret, oidcClientSecret)
 	}
 
 	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
@@ -219,13 +240,110 @@ func (r *ArgoCDReconciler) reconcileClusterMainSecret(cr *argoproj.ArgoCD) error
 	return r.Client.Create(context.TODO(), secret)
 }
 
-// reconcileClusterTLSSecret ensures the TLS Secret is created for the ArgoCD cluster.
-func (r *ArgoCDReconciler) reconcileClusterTLSSecret(cr *argoproj.ArgoCD) error {
-	secret := util.NewTLSSecret(cr, "tls")
-	if util.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
-		return nil // Secret found, do nothing
+// getCertificateRenewBefore returns how long before their expiry the operator generated certificates are re-issued.
+// The renewal window is capped to a third of the certificate validity, to avoid re-issuing the certificates on every
+// reconciliation.
+func getCertificateRenewBefore(cr *argoproj.ArgoCD) time.Duration {
+	renewBefore := common.ArgoCDDuration30Days
+	if cr.Spec.TLS.RenewBefore != nil && cr.Spec.TLS.RenewBefore.Duration > 0 {
+		renewBefore = cr.Spec.TLS.RenewBefore.Duration
+	}
+	if validity := getCertificateProfile(cr).GetValidity(); renewBefore >= validity {
+		return validity / 3
+	}
+	return renewBefore
+}
+
+// getCertificateProfile returns the key algorithm, key size, validity and extra SANs of the certificates generated by
+// the operator.
+func getCertificateProfile(cr *argoproj.ArgoCD) util.CertificateProfile {
+	profile := util.CertificateProfile{
+		KeyAlgorithm: cr.Spec.TLS.KeyAlgorithm,
+		KeySize:      cr.Spec.TLS.KeySize,
+		ExtraSANs:    cr.Spec.TLS.ExtraSANs,
+	}
+	if cr.Spec.TLS.Validity != nil {
+		profile.Validity = cr.Spec.TLS.Validity.Duration
+	}
+	return profile
+}
+
+// reconcileCertificateProfileValidation will validate the certificate profile of the given ArgoCD and record the
+// result as the CertificateProfileValid condition, before any certificate is generated with it.
+func (r *ArgoCDReconciler) reconcileCertificateProfileValidation(cr *argoproj.ArgoCD) error {
+	err := getCertificateProfile(cr).Validate()
+
+	condition := metav1.Condition{
+		Type:               common.ArgoCDConditionCertificateProfileValid,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cr.Generation,
+		Reason:             "Valid",
+		Message:            "certificate profile is valid",
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Invalid"
+		condition.Message = fmt.Sprintf("certificate profile is invalid: %v", err)
+	}
+
+	if updateErr := argocdcommon.SetStatusCondition(cr, condition, r.Client); updateErr != nil {
+		return updateErr
 	}
 
+	if err != nil {
+		return fmt.Errorf("invalid certificate profile: %w", err)
+	}
+	return nil
+}
+
+// certificateNeedsRenewal returns true if the given certificate expires within the renewal window, or if its key does
+// not use the configured algorithm or key size anymore.
+func certificateNeedsRenewal(cert *x509.Certificate, renewBefore time.Duration, profile util.CertificateProfile) bool {
+	return time.Now().Add(renewBefore).After(cert.NotAfter) || util.GetKeyAlgorithm(cert.PublicKey) != profile.GetKeyAlgorithm() ||
+		util.GetKeySize(cert.PublicKey) != profile.GetKeySize()
+}
+
+// certificateHasSANs returns true if the given certificate is valid for all the given DNS names and IP addresses.
+func certificateHasSANs(cert *x509.Certificate, sans []string) bool {
+	for _, san := range sans {
+		if err := cert.VerifyHostname(san); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// recordCertificateExpiry exports the expiry time of an operator generated certificate as a metric.
+func recordCertificateExpiry(cr *argoproj.ArgoCD, name string, cert *x509.Certificate) {
+	CertificateExpiry.WithLabelValues(cr.Namespace, cr.Name, name).Set(float64(cert.NotAfter.Unix()))
+}
+
+// getCABundle returns the PEM encoded CA bundle made of the given CA certificate and the certificates of the
+// previous bundle that have not expired yet, so that certificates signed by a rotated CA remain trusted.
+func getCABundle(caCert []byte, previousBundle []byte) []byte {
+	bundle := append([]byte{}, caCert...)
+	if len(previousBundle) <= 0 {
+		return bundle
+	}
+
+	previous, err := util.ParsePEMEncodedCerts(previousBundle)
+	if err != nil {
+		log.Info(fmt.Sprintf("dropping unreadable CA bundle: %s", err))
+		return bundle
+	}
+	for _, cert := range previous {
+		encoded := util.EncodeCertificatePEM(cert)
+		if time.Now().After(cert.NotAfter) || strings.Contains(string(bundle), string(encoded)) {
+			continue
+		}
+		bundle = append(bundle, encoded...)
+	}
+	return bundle
+}
+
+// reconcileClusterTLSSecret ensures the TLS Secret is created for the ArgoCD cluster, and re-issued before it expires,
+// once the CA that signed it has been rotated or when the certificate profile has changed.
+func (r *ArgoCDReconciler) reconcileClusterTLSSecret(cr *argoproj.ArgoCD) error {
 	caSecret := util.NewSecretWithSuffix(cr, "ca")
 	caSecret, err := util.FetchSecret(r.Client, cr.ObjectMeta, caSecret.Name)
 	if err != nil {
@@ -242,7 +360,43 @@ func (r *ArgoCDReconciler) reconcileClusterTLSSecret(cr *argoproj.ArgoCD) error
 		return err
 	}
 
-	secret, err = newCertificateSecret("tls", caCert, caKey, cr)
+	existing := util.NewTLSSecret(cr, "tls")
+	if util.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+		cert, err := util.ParsePEMEncodedCert(existing.Data[corev1.TLSCertKey])
+		if err == nil {
+			recordCertificateExpiry(cr, existing.Name, cert)
+			if !certificateNeedsRenewal(cert, getCertificateRenewBefore(cr), getCertificateProfile(cr)) &&
+				certificateHasSANs(cert, cr.Spec.TLS.ExtraSANs) && cert.CheckSignatureFrom(caCert) == nil {
+				return nil // Secret found and valid, do nothing
+			}
+		}
+
+		secret, err := newCertificateSecret("tls", caCert, caKey, cr)
+		if err != nil {
+			return err
+		}
+
+		log.Info(fmt.Sprintf("re-issuing TLS certificate in secret %s", existing.Name))
+		existing.Data = secret.Data
+		if err := r.Client.Update(context.TODO(), existing); err != nil {
+			return err
+		}
+		if cert, err := util.ParsePEMEncodedCert(existing.Data[corev1.TLSCertKey]); err == nil {
+			recordCertificateExpiry(cr, existing.Name, cert)
+		}
+
+		if err := r.reconcileArgoSecret(cr); err != nil {
+			return err
+		}
+		for _, workload := range getClusterTLSWorkloads(cr) {
+			if err := r.triggerRollout(workload, "tls.cert.rotated"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	secret, err := newCertificateSecret("tls", caCert, caKey, cr)
 	if err != nil {
 		return err
 	}
@@ -254,24 +408,424 @@ func (r *ArgoCDReconciler) reconcileClusterTLSSecret(cr *argoproj.ArgoCD) error
 	return r.Client.Create(context.TODO(), secret)
 }
 
-// reconcileClusterCASecret ensures the CA Secret is created for the ArgoCD cluster.
-func (r *ArgoCDReconciler) reconcileClusterCASecret(cr *argoproj.ArgoCD) error {
-	secret := util.NewSecretWithSuffix(cr, "ca")
-	if util.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
-		return nil // Secret found, do nothing
+// mutualTLSClient is an Argo CD workload that is issued a client certificate when mutual TLS is enabled.
+type mutualTLSClient struct {
+	component string
+	workload  interface{}
+}
+
+// getMutualTLSClients returns the Argo CD workloads that are issued a client certificate when mutual TLS is enabled.
+func getMutualTLSClients(cr *argoproj.ArgoCD) []mutualTLSClient {
+	return []mutualTLSClient{
+		{component: "server", workload: newDeploymentWithSuffix("server", "server", cr)},
+		{component: "repo-server", workload: newDeploymentWithSuffix("repo-server", "repo-server", cr)},
+		{component: "application-controller", workload: newStatefulSetWithSuffix("application-controller", "application-controller", cr)},
 	}
+}
 
-	secret, err := newCASecret(cr)
+// getClientTLSSecretName returns the name of the Secret holding the mutual TLS client certificate of a component.
+func getClientTLSSecretName(cr *argoproj.ArgoCD, component string) string {
+	return util.NameWithSuffix(cr.Name, fmt.Sprintf("%s-client-tls", component))
+}
+
+// newClientCertificateSecret creates a new secret holding a client certificate for the given component, signed by the
+// given CA.
+func newClientCertificateSecret(component string, caCert *x509.Certificate, caKey crypto.Signer, cr *argoproj.ArgoCD) (*corev1.Secret, error) {
+	secret := util.NewTLSSecret(cr, fmt.Sprintf("%s-client-tls", component))
+
+	profile := getCertificateProfile(cr)
+	profile.ExtraSANs = nil
+	key, err := util.NewPrivateKeyForProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &tlsutil.CertConfig{
+		CertName:     secret.Name,
+		CertType:     tlsutil.ClientCert,
+		CommonName:   util.NameWithSuffix(cr.Name, component),
+		Organization: []string{cr.ObjectMeta.Namespace},
+	}
+
+	cert, err := util.NewSignedCertificateForProfile(cfg, nil, key, caCert, caKey, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedKey, err := util.EncodePrivateKeyPEM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:       util.EncodeCertificatePEM(cert),
+		corev1.TLSPrivateKeyKey: encodedKey,
+	}
+
+	return secret, nil
+}
+
+// reconcileClientTLSSecrets ensures the mutual TLS client certificates of the Argo CD components are present, and
+// re-issued before they expire or once the CA has been rotated, when mutual TLS is enabled. They are removed otherwise.
+func (r *ArgoCDReconciler) reconcileClientTLSSecrets(cr *argoproj.ArgoCD) error {
+	if !isMutualTLSEnabled(cr) {
+		for _, client := range getMutualTLSClients(cr) {
+			secret := util.NewSecretWithName(cr, getClientTLSSecretName(cr, client.component))
+			if util.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+				if err := r.Client.Delete(context.TODO(), secret); err != nil && !apierrors.IsNotFound(err) {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	caSecret, err := util.FetchSecret(r.Client, cr.ObjectMeta, util.NewSecretWithSuffix(cr, "ca").Name)
+	if err != nil {
+		return err
+	}
+
+	caCert, err := util.ParsePEMEncodedCert(caSecret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return err
+	}
+
+	caKey, err := util.ParsePEMEncodedPrivateKey(caSecret.Data[corev1.TLSPrivateKeyKey])
 	if err != nil {
 		return err
 	}
 
+	for _, client := range getMutualTLSClients(cr) {
+		existing := util.NewTLSSecret(cr, fmt.Sprintf("%s-client-tls", client.component))
+		found := util.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing)
+		if found {
+			cert, err := util.ParsePEMEncodedCert(existing.Data[corev1.TLSCertKey])
+			if err == nil {
+				recordCertificateExpiry(cr, existing.Name, cert)
+				if !certificateNeedsRenewal(cert, getCertificateRenewBefore(cr), getCertificateProfile(cr)) && cert.CheckSignatureFrom(caCert) == nil {
+					continue // Secret found and valid, do nothing
+				}
+			}
+		}
+
+		secret, err := newClientCertificateSecret(client.component, caCert, caKey, cr)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+				return err
+			}
+			log.Info(fmt.Sprintf("creating client certificate secret %s", secret.Name))
+			if err := r.Client.Create(context.TODO(), secret); err != nil {
+				return err
+			}
+			continue
+		}
+
+		log.Info(fmt.Sprintf("re-issuing client certificate in secret %s", existing.Name))
+		existing.Data = secret.Data
+		if err := r.Client.Update(context.TODO(), existing); err != nil {
+			return err
+		}
+
+		// The client certificates are loaded on startup.
+		if err := r.triggerRollout(client.workload, "tls.client.cert.rotated"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newRepoServerServingSecret creates a new secret holding a serving certificate for the repo server signed by the given
+// CA, along with the given CA bundle for its clients to verify it.
+func newRepoServerServingSecret(cr *argoproj.ArgoCD, caCert *x509.Certificate, caKey crypto.Signer, bundle []byte) (*corev1.Secret, error) {
+	secret := util.NewSecretWithName(cr, common.ArgoCDRepoServerTLSSecretName)
+	secret.Type = corev1.SecretTypeTLS
+
+	profile := getCertificateProfile(cr)
+	profile.ExtraSANs = nil
+	key, err := util.NewPrivateKeyForProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	service := util.NameWithSuffix(cr.Name, "repo-server")
+	cfg := &tlsutil.CertConfig{
+		CertName:     secret.Name,
+		CertType:     tlsutil.ServingCert,
+		CommonName:   service,
+		Organization: []string{cr.ObjectMeta.Namespace},
+	}
+
+	dnsNames := []string{
+		service,
+		fmt.Sprintf("%s.%s", service, cr.Namespace),
+		fmt.Sprintf("%s.%s.svc", service, cr.Namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", service, cr.Namespace),
+	}
+
+	cert, err := util.NewSignedCertificateForProfile(cfg, dnsNames, key, caCert, caKey, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedKey, err := util.EncodePrivateKeyPEM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:              util.EncodeCertificatePEM(cert),
+		corev1.TLSPrivateKeyKey:        encodedKey,
+		corev1.ServiceAccountRootCAKey: bundle,
+	}
+
+	return secret, nil
+}
+
+// reconcileRepoServerServingSecret ensures the repo server is issued a serving certificate signed by the operator CA
+// when mutual TLS is enabled, so that the server and the application controller can strictly verify it against the CA
+// bundle stored next to it. Certificates provided by cert-manager, the OpenShift service CA or the user are left
+// untouched.
+func (r *ArgoCDReconciler) reconcileRepoServerServingSecret(cr *argoproj.ArgoCD) error {
+	existing := util.NewSecretWithName(cr, common.ArgoCDRepoServerTLSSecretName)
+	found := util.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing)
+	if found && !metav1.IsControlledBy(existing, cr) {
+		return nil // Secret provided by another issuer, do nothing
+	}
+
+	if !isMutualTLSEnabled(cr) || usesCertManager(cr) || cr.Spec.Repo.WantsAutoTLS() {
+		// The repo server falls back to its self-signed certificate.
+		if found {
+			log.Info(fmt.Sprintf("deleting repo server certificate secret %s", existing.Name))
+			if err := r.Client.Delete(context.TODO(), existing); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	caSecret, err := util.FetchSecret(r.Client, cr.ObjectMeta, util.NewSecretWithSuffix(cr, "ca").Name)
+	if err != nil {
+		return err
+	}
+
+	caCert, err := util.ParsePEMEncodedCert(caSecret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return err
+	}
+
+	caKey, err := util.ParsePEMEncodedPrivateKey(caSecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return err
+	}
+
+	bundle := caSecret.Data[corev1.ServiceAccountRootCAKey]
+	if found {
+		cert, err := util.ParsePEMEncodedCert(existing.Data[corev1.TLSCertKey])
+		if err == nil {
+			recordCertificateExpiry(cr, existing.Name, cert)
+			if !certificateNeedsRenewal(cert, getCertificateRenewBefore(cr), getCertificateProfile(cr)) && cert.CheckSignatureFrom(caCert) == nil {
+				if string(existing.Data[corev1.ServiceAccountRootCAKey]) == string(bundle) {
+					return nil // Secret found and valid, do nothing
+				}
+				existing.Data[corev1.ServiceAccountRootCAKey] = bundle
+				return r.Client.Update(context.TODO(), existing)
+			}
+		}
+	}
+
+	secret, err := newRepoServerServingSecret(cr, caCert, caKey, bundle)
+	if err != nil {
+		return err
+	}
+
+	// The server, repo server and application controller are rolled out once the checksum of the certificate changes.
+	if found {
+		log.Info(fmt.Sprintf("re-issuing repo server certificate in secret %s", existing.Name))
+		existing.Data = secret.Data
+		return r.Client.Update(context.TODO(), existing)
+	}
+
 	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
 		return err
 	}
+	log.Info(fmt.Sprintf("creating repo server certificate secret %s", secret.Name))
 	return r.Client.Create(context.TODO(), secret)
 }
 
+// reconcileMutualTLSStatus records whether mutual TLS is in effect as the MutualTLSReady condition. Redis only requires
+// client certificates when it is managed by the operator, runs without HA and has TLS enabled.
+func (r *ArgoCDReconciler) reconcileMutualTLSStatus(cr *argoproj.ArgoCD, useTLSForRedis bool) error {
+	if !isMutualTLSEnabled(cr) {
+		return argocdcommon.RemoveStatusCondition(cr, common.ArgoCDConditionMutualTLSReady, r.Client)
+	}
+
+	condition := metav1.Condition{
+		Type:               common.ArgoCDConditionMutualTLSReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: cr.Generation,
+	}
+	switch {
+	case isRedisExternal(cr):
+		condition.Reason = "RedisExternal"
+		condition.Message = "client certificates are not presented to the external Redis, configure its authentication in spec.redis.external"
+	case cr.Spec.HA.Enabled:
+		condition.Reason = "RedisHA"
+		condition.Message = "Redis does not require client certificates when HA is enabled"
+	case !useTLSForRedis:
+		condition.Reason = "RedisTLSMissing"
+		condition.Message = fmt.Sprintf("Redis does not require client certificates until TLS is enabled for it with the %s secret", common.ArgoCDRedisServerTLSSecretName)
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Enabled"
+		condition.Message = "Redis requires client certificates and the repo server certificate is strictly verified"
+	}
+	return argocdcommon.SetStatusCondition(cr, condition, r.Client)
+}
+
+// reconcileClusterCASecret ensures the CA Secret is created for the ArgoCD cluster, and rotated before it expires.
+// A new CA is first staged in the CA bundle, next to the active one, and only promoted to sign certificates once the
+// workloads trusting the bundle have rolled out. The CA bundle keeps the previous CA until it expires, to trust
+// certificates that have not been re-signed yet.
+func (r *ArgoCDReconciler) reconcileClusterCASecret(cr *argoproj.ArgoCD) error {
+	existing := util.NewSecretWithSuffix(cr, "ca")
+	if !util.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+		secret, err := newCASecret(cr)
+		if err != nil {
+			return err
+		}
+
+		if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+			return err
+		}
+		return r.Client.Create(context.TODO(), secret)
+	}
+
+	if len(existing.Data[common.ArgoCDKeyStagedCACert]) > 0 {
+		return r.promoteStagedCA(cr, existing)
+	}
+
+	cert, err := util.ParsePEMEncodedCert(existing.Data[corev1.TLSCertKey])
+	if err != nil {
+		// Nothing can be signed with an unreadable CA, replace it right away.
+		secret, err := newCASecret(cr)
+		if err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("replacing unreadable CA certificate in secret %s", existing.Name))
+		return r.activateCA(cr, existing, secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	}
+
+	recordCertificateExpiry(cr, existing.Name, cert)
+	if certificateNeedsRenewal(cert, getCertificateRenewBefore(cr), getCertificateProfile(cr)) {
+		return r.stageCA(cr, existing)
+	}
+
+	// Drop the rotated CAs from the bundle once they have expired.
+	bundle := getCABundle(existing.Data[corev1.TLSCertKey], existing.Data[corev1.ServiceAccountRootCAKey])
+	if string(bundle) == string(existing.Data[corev1.ServiceAccountRootCAKey]) {
+		return nil // Secret found and valid, do nothing
+	}
+	existing.Data[corev1.ServiceAccountRootCAKey] = bundle
+	return r.Client.Update(context.TODO(), existing)
+}
+
+// stageCA adds a new CA to the CA bundle of the given CA secret and rolls out the workloads trusting the bundle. The
+// active CA keeps signing certificates until the new one is promoted.
+func (r *ArgoCDReconciler) stageCA(cr *argoproj.ArgoCD, existing *corev1.Secret) error {
+	secret, err := newCASecret(cr)
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("staging new CA certificate in secret %s", existing.Name))
+	existing.Data[common.ArgoCDKeyStagedCACert] = secret.Data[corev1.TLSCertKey]
+	existing.Data[common.ArgoCDKeyStagedCAKey] = secret.Data[corev1.TLSPrivateKeyKey]
+	existing.Data[corev1.ServiceAccountRootCAKey] = getCABundle(secret.Data[corev1.TLSCertKey], existing.Data[corev1.ServiceAccountRootCAKey])
+	if err := r.Client.Update(context.TODO(), existing); err != nil {
+		return err
+	}
+
+	// The repo server secret carries the bundle its clients load on startup.
+	if err := r.reconcileRepoServerServingSecret(cr); err != nil {
+		return err
+	}
+
+	for _, workload := range getCATrustingWorkloads(cr) {
+		if err := r.triggerRollout(workload, "tls.ca.staged"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// promoteStagedCA makes the staged CA of the given CA secret the active one, once the workloads trusting the CA bundle
+// have rolled out. The certificates signed by the previous CA are re-issued afterwards.
+func (r *ArgoCDReconciler) promoteStagedCA(cr *argoproj.ArgoCD, existing *corev1.Secret) error {
+	// There is nothing left to keep working once the active CA has expired.
+	if cert, err := util.ParsePEMEncodedCert(existing.Data[corev1.TLSCertKey]); err == nil && time.Now().Before(cert.NotAfter) {
+		for _, workload := range getCATrustingWorkloads(cr) {
+			complete, err := r.isRolloutComplete(workload)
+			if err != nil {
+				return err
+			}
+			if !complete {
+				log.Info(fmt.Sprintf("waiting for the workloads trusting the CA bundle in secret %s to roll out", existing.Name))
+				return nil
+			}
+		}
+	}
+
+	log.Info(fmt.Sprintf("promoting staged CA certificate in secret %s", existing.Name))
+	return r.activateCA(cr, existing, existing.Data[common.ArgoCDKeyStagedCACert], existing.Data[common.ArgoCDKeyStagedCAKey])
+}
+
+// activateCA makes the given CA certificate and key the ones signing the certificates of the given CA secret, keeping
+// the previous CAs in the bundle.
+func (r *ArgoCDReconciler) activateCA(cr *argoproj.ArgoCD, existing *corev1.Secret, caCert []byte, caKey []byte) error {
+	bundle := getCABundle(caCert, existing.Data[corev1.ServiceAccountRootCAKey])
+	existing.Data = map[string][]byte{
+		corev1.TLSCertKey:              caCert,
+		corev1.TLSPrivateKeyKey:        caKey,
+		corev1.ServiceAccountRootCAKey: bundle,
+	}
+	if err := r.Client.Update(context.TODO(), existing); err != nil {
+		return err
+	}
+	if cert, err := util.ParsePEMEncodedCert(caCert); err == nil {
+		recordCertificateExpiry(cr, existing.Name, cert)
+	}
+	return nil
+}
+
+// getCATrustingWorkloads returns the Argo CD workloads loading the CA bundle on startup.
+func getCATrustingWorkloads(cr *argoproj.ArgoCD) []interface{} {
+	workloads := make([]interface{}, 0)
+	if !isMutualTLSEnabled(cr) {
+		return workloads
+	}
+	// The server and the application controller verify the repo server certificate with the CA bundle.
+	workloads = append(workloads,
+		newDeploymentWithSuffix("server", "server", cr),
+		newStatefulSetWithSuffix("application-controller", "application-controller", cr))
+	// Redis verifies the client certificates with the CA bundle.
+	if !cr.Spec.HA.Enabled {
+		workloads = append(workloads, newDeploymentWithSuffix("redis", "redis", cr))
+	}
+	return workloads
+}
+
+// getClusterTLSWorkloads returns the Argo CD workloads serving the cluster TLS certificate.
+func getClusterTLSWorkloads(cr *argoproj.ArgoCD) []interface{} {
+	return []interface{}{
+		// The server serves the certificate from the argo secret, which is synced from the cluster TLS secret.
+		newDeploymentWithSuffix("server", "server", cr),
+	}
+}
+
 // reconcileClusterSecrets will reconcile all Secret resources for the ArgoCD cluster.
 func (r *ArgoCDReconciler) reconcileClusterSecrets(cr *argoproj.ArgoCD) error {
 	if err := r.reconcileClusterMainSecret(cr); err != nil {
@@ -286,6 +840,14 @@ func (r *ArgoCDReconciler) reconcileClusterSecrets(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	if err := r.reconcileClientTLSSecrets(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileRepoServerServingSecret(cr); err != nil {
+		return err
+	}
+
 	if err := r.reconcileClusterPermissionsSecret(cr); err != nil {
 		return err
 	}
@@ -348,6 +910,20 @@ func (r *ArgoCDReconciler) reconcileExistingArgoSecret(cr *argoproj.ArgoCD, secr
 		}
 	}
 
+	dexConnectorSecrets := map[string][]byte{}
+	if UseDex(cr) {
+		dexConnectorSecrets = r.getDexConnectorSecretData(cr)
+	}
+	dexSecretChanged := syncDexConnectorSecrets(secret, dexConnectorSecrets)
+	changed = changed || dexSecretChanged
+
+	// A client secret that cannot be read is kept until it is available again, the oidc.config reports the error.
+	if oidcClientSecret, err := r.getOIDCClientSecret(cr); err != nil {
+		log.Info(err.Error())
+	} else if syncOIDCClientSecret(secret, oidcClientSecret) {
+		changed = true
+	}
+
 	if changed {
 		log.Info("updating argo secret")
 		if err := r.Client.Update(context.TODO(), secret); err != nil {
@@ -355,6 +931,11 @@ func (r *ArgoCDReconciler) reconcileExistingArgoSecret(cr *argoproj.ArgoCD, secr
 		}
 	}
 
+	// Trigger rollout of Dex Deployment to pick up the changed connector secrets.
+	if dexSecretChanged && UseDex(cr) {
+		return r.triggerRollout(newDeploymentWithSuffix("dex-server", "dex-server", cr), "dex.secret.changed")
+	}
+
 	return nil
 }
 
@@ -674,10 +1255,18 @@ func (r *ArgoCDReconciler) reconcileSecrets(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	if err := r.reconcileManagedClusters(cr); err != nil {
+		return err
+	}
+
 	if err := r.reconcileArgoSecret(cr); err != nil {
 		return err
 	}
 
+	if err := r.reconcileRepositories(cr); err != nil {
+		return err
+	}
+
 	return nil
 }
 
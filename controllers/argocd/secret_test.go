@@ -3,15 +3,19 @@ package argocd
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/x509"
 	"fmt"
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -458,3 +462,372 @@ func Test_ArgoCDReconciler_ClusterPermissionsSecret(t *testing.T) {
 	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: testSecret.Name, Namespace: testSecret.Namespace}, testSecret))
 	assert.Nil(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: testSecret.Name, Namespace: testSecret.Namespace}, testSecret))
 }
+
+func Test_ArgoCDReconciler_RotateClusterCertificates(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	argoCD := makeTestArgoCD()
+	r := makeTestReconciler(t, argoCD)
+
+	assert.NoError(t, r.reconcileClusterSecrets(argoCD))
+	assert.NoError(t, r.reconcileCAConfigMap(argoCD))
+
+	caSecret := util.NewSecretWithSuffix(argoCD, "ca")
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: caSecret.Name, Namespace: argoCD.Namespace}, caSecret))
+	oldCA := caSecret.Data[corev1.TLSCertKey]
+
+	// certificates that are not about to expire are left untouched
+	assert.NoError(t, r.reconcileClusterCASecret(argoCD))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: caSecret.Name, Namespace: argoCD.Namespace}, caSecret))
+	assert.Equal(t, oldCA, caSecret.Data[corev1.TLSCertKey])
+
+	// the CA is rotated when it expires within the renewal window, and the previous CA is kept in the bundle
+	key, err := util.NewPrivateKey()
+	assert.NoError(t, err)
+	expiringCA, err := util.NewSelfSignedCACertificateForProfile(argoCD.Name, key, util.CertificateProfile{Validity: time.Hour * 24})
+	assert.NoError(t, err)
+	encodedKey, err := util.EncodePrivateKeyPEM(key)
+	assert.NoError(t, err)
+	oldCA = util.EncodeCertificatePEM(expiringCA)
+	caSecret.Data[corev1.TLSCertKey] = oldCA
+	caSecret.Data[corev1.ServiceAccountRootCAKey] = oldCA
+	caSecret.Data[corev1.TLSPrivateKeyKey] = encodedKey
+	assert.NoError(t, r.Client.Update(context.TODO(), caSecret))
+	assert.NoError(t, r.reconcileClusterTLSSecret(argoCD))
+
+	// the new CA is first staged in the bundle, next to the active one
+	assert.NoError(t, r.reconcileClusterCASecret(argoCD))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: caSecret.Name, Namespace: argoCD.Namespace}, caSecret))
+	assert.Equal(t, oldCA, caSecret.Data[corev1.TLSCertKey])
+	stagedCA := caSecret.Data[common.ArgoCDKeyStagedCACert]
+	assert.NotEmpty(t, stagedCA)
+	bundle, err := util.ParsePEMEncodedCerts(caSecret.Data[corev1.ServiceAccountRootCAKey])
+	assert.NoError(t, err)
+	assert.Len(t, bundle, 2)
+
+	// certificates are not re-signed while the new CA is staged
+	tlsSecret := util.NewTLSSecret(argoCD, "tls")
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: tlsSecret.Name, Namespace: argoCD.Namespace}, tlsSecret))
+	previousTLSCert := tlsSecret.Data[corev1.TLSCertKey]
+	assert.NoError(t, r.reconcileClusterTLSSecret(argoCD))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: tlsSecret.Name, Namespace: argoCD.Namespace}, tlsSecret))
+	assert.Equal(t, previousTLSCert, tlsSecret.Data[corev1.TLSCertKey])
+
+	// the staged CA is promoted on the next reconcile, and the previous CA is kept in the bundle
+	assert.NoError(t, r.reconcileClusterCASecret(argoCD))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: caSecret.Name, Namespace: argoCD.Namespace}, caSecret))
+	assert.Equal(t, stagedCA, caSecret.Data[corev1.TLSCertKey])
+	assert.NotContains(t, caSecret.Data, common.ArgoCDKeyStagedCACert)
+	assert.NotContains(t, caSecret.Data, common.ArgoCDKeyStagedCAKey)
+	bundle, err = util.ParsePEMEncodedCerts(caSecret.Data[corev1.ServiceAccountRootCAKey])
+	assert.NoError(t, err)
+	assert.Len(t, bundle, 2)
+
+	// the CA configmap follows the bundle
+	assert.NoError(t, r.reconcileCAConfigMap(argoCD))
+	cm := newConfigMapWithName(getCAConfigMapName(argoCD), argoCD)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cm.Name, Namespace: argoCD.Namespace}, cm))
+	assert.Equal(t, string(caSecret.Data[corev1.ServiceAccountRootCAKey]), cm.Data[corev1.TLSCertKey])
+
+	// the TLS certificate is re-signed by the new CA
+	assert.NoError(t, r.reconcileClusterTLSSecret(argoCD))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: tlsSecret.Name, Namespace: argoCD.Namespace}, tlsSecret))
+	cert, err := util.ParsePEMEncodedCert(tlsSecret.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	caCert, err := util.ParsePEMEncodedCert(caSecret.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	assert.NoError(t, cert.CheckSignatureFrom(caCert))
+
+	// the argo secret serves the re-signed certificate
+	argoSecret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: argoCD.Namespace}, argoSecret))
+	assert.Equal(t, tlsSecret.Data[corev1.TLSCertKey], argoSecret.Data[corev1.TLSCertKey])
+}
+
+func Test_ArgoCDReconciler_RotateClusterCertificates_waitsForRollout(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	argoCD := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.TLS.MutualTLS = true
+	})
+	r := makeTestReconciler(t, argoCD)
+
+	assert.NoError(t, r.reconcileClusterSecrets(argoCD))
+	assert.NoError(t, r.reconcileRedisDeployment(argoCD, true))
+
+	caSecret := util.NewSecretWithSuffix(argoCD, "ca")
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: caSecret.Name, Namespace: argoCD.Namespace}, caSecret))
+	activeCA := caSecret.Data[corev1.TLSCertKey]
+
+	// staging the CA rolls out Redis, which verifies the client certificates with the CA bundle
+	argoCD.Spec.TLS.KeyAlgorithm = common.ArgoCDKeyAlgorithmECDSA
+	assert.NoError(t, r.reconcileClusterCASecret(argoCD))
+
+	redis := newDeploymentWithSuffix("redis", "redis", argoCD)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: redis.Name, Namespace: argoCD.Namespace}, redis))
+	assert.Contains(t, redis.Spec.Template.Labels, "tls.ca.staged")
+
+	// the staged CA is not promoted until Redis has rolled out
+	assert.NoError(t, r.reconcileClusterCASecret(argoCD))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: caSecret.Name, Namespace: argoCD.Namespace}, caSecret))
+	assert.Equal(t, activeCA, caSecret.Data[corev1.TLSCertKey])
+	stagedCA := caSecret.Data[common.ArgoCDKeyStagedCACert]
+	assert.NotEmpty(t, stagedCA)
+
+	redis.Status.ObservedGeneration = redis.Generation
+	redis.Status.Replicas = 1
+	redis.Status.UpdatedReplicas = 1
+	redis.Status.AvailableReplicas = 1
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), redis))
+
+	assert.NoError(t, r.reconcileClusterCASecret(argoCD))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: caSecret.Name, Namespace: argoCD.Namespace}, caSecret))
+	assert.Equal(t, stagedCA, caSecret.Data[corev1.TLSCertKey])
+}
+
+func Test_ArgoCDReconciler_ClusterCertificatesProfile(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	argoCD := makeTestArgoCD()
+	r := makeTestReconciler(t, argoCD)
+
+	// certificates generated with the default RSA profile
+	assert.NoError(t, r.reconcileClusterSecrets(argoCD))
+
+	argoCD.Spec.TLS.KeyAlgorithm = common.ArgoCDKeyAlgorithmECDSA
+	argoCD.Spec.TLS.Validity = &metav1.Duration{Duration: time.Hour * 24 * 90}
+	argoCD.Spec.TLS.ExtraSANs = []string{"argocd.example.com", "10.0.0.1"}
+	// the new CA is staged, then promoted on the next reconcile
+	assert.NoError(t, r.reconcileClusterCASecret(argoCD))
+	assert.NoError(t, r.reconcileClusterCASecret(argoCD))
+	assert.NoError(t, r.reconcileClusterTLSSecret(argoCD))
+
+	caSecret := util.NewSecretWithSuffix(argoCD, "ca")
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: caSecret.Name, Namespace: argoCD.Namespace}, caSecret))
+	caCert, err := util.ParsePEMEncodedCert(caSecret.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	assert.Equal(t, common.ArgoCDKeyAlgorithmECDSA, util.GetKeyAlgorithm(caCert.PublicKey))
+	caKey, err := util.ParsePEMEncodedPrivateKey(caSecret.Data[corev1.TLSPrivateKeyKey])
+	assert.NoError(t, err)
+	assert.Equal(t, caCert.PublicKey, caKey.Public())
+
+	tlsSecret := util.NewTLSSecret(argoCD, "tls")
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: tlsSecret.Name, Namespace: argoCD.Namespace}, tlsSecret))
+	cert, err := util.ParsePEMEncodedCert(tlsSecret.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	assert.Equal(t, common.ArgoCDKeyAlgorithmECDSA, util.GetKeyAlgorithm(cert.PublicKey))
+	assert.NoError(t, cert.CheckSignatureFrom(caCert))
+	assert.NoError(t, cert.VerifyHostname("argocd.example.com"))
+	assert.NoError(t, cert.VerifyHostname("10.0.0.1"))
+	assert.WithinDuration(t, time.Now().Add(time.Hour*24*90), cert.NotAfter, time.Minute)
+
+	// an unchanged profile does not re-issue the certificate
+	assert.NoError(t, r.reconcileClusterTLSSecret(argoCD))
+	unchanged := util.NewTLSSecret(argoCD, "tls")
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: unchanged.Name, Namespace: argoCD.Namespace}, unchanged))
+	assert.Equal(t, tlsSecret.Data, unchanged.Data)
+}
+
+func Test_ArgoCDReconciler_ClusterCertificatesKeySize(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	argoCD := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.TLS.KeyAlgorithm = common.ArgoCDKeyAlgorithmECDSA
+	})
+	r := makeTestReconciler(t, argoCD)
+	assert.NoError(t, r.reconcileClusterSecrets(argoCD))
+
+	// changing the key size re-issues the certificates
+	argoCD.Spec.TLS.KeySize = 384
+	assert.NoError(t, r.reconcileClusterCASecret(argoCD))
+	assert.NoError(t, r.reconcileClusterCASecret(argoCD))
+	assert.NoError(t, r.reconcileClusterTLSSecret(argoCD))
+
+	tlsSecret := util.NewTLSSecret(argoCD, "tls")
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: tlsSecret.Name, Namespace: argoCD.Namespace}, tlsSecret))
+	cert, err := util.ParsePEMEncodedCert(tlsSecret.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	assert.Equal(t, 384, util.GetKeySize(cert.PublicKey))
+}
+
+func Test_ArgoCDReconciler_reconcileCertificateProfileValidation(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	argoCD := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.TLS.KeyAlgorithm = common.ArgoCDKeyAlgorithmECDSA
+		a.Spec.TLS.KeySize = 2048
+	})
+	r := makeTestReconciler(t, argoCD)
+
+	// an invalid profile is reported before any certificate is generated
+	assert.ErrorContains(t, r.reconcileCertificateAuthority(argoCD), "unsupported ECDSA key size 2048")
+	caSecret := util.NewSecretWithSuffix(argoCD, "ca")
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: caSecret.Name, Namespace: argoCD.Namespace}, caSecret)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	cr := &argoproj.ArgoCD{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: argoCD.Name, Namespace: argoCD.Namespace}, cr))
+	condition := meta.FindStatusCondition(cr.Status.Conditions, common.ArgoCDConditionCertificateProfileValid)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, "Invalid", condition.Reason)
+	}
+
+	cr.Spec.TLS.KeySize = 521
+	assert.NoError(t, r.reconcileCertificateProfileValidation(cr))
+	assert.True(t, meta.IsStatusConditionTrue(cr.Status.Conditions, common.ArgoCDConditionCertificateProfileValid))
+}
+
+func Test_ArgoCDReconciler_ReconcileClientTLSSecrets(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	argoCD := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.TLS.MutualTLS = true
+	})
+	r := makeTestReconciler(t, argoCD)
+
+	assert.NoError(t, r.reconcileClusterSecrets(argoCD))
+
+	caSecret := util.NewSecretWithSuffix(argoCD, "ca")
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: caSecret.Name, Namespace: argoCD.Namespace}, caSecret))
+	caCert, err := util.ParsePEMEncodedCert(caSecret.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+
+	for _, component := range []string{"server", "repo-server", "application-controller"} {
+		secret := &corev1.Secret{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: argoCD.Name + "-" + component + "-client-tls", Namespace: argoCD.Namespace}, secret))
+		assert.Equal(t, corev1.SecretTypeTLS, secret.Type)
+
+		cert, err := util.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+		assert.NoError(t, err)
+		assert.NoError(t, cert.CheckSignatureFrom(caCert))
+		assert.Equal(t, argoCD.Name+"-"+component, cert.Subject.CommonName)
+		assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, cert.ExtKeyUsage)
+	}
+
+	// disabling mutual TLS removes the client certificates
+	argoCD.Spec.TLS.MutualTLS = false
+	assert.NoError(t, r.reconcileClientTLSSecrets(argoCD))
+	secret := &corev1.Secret{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: argoCD.Name + "-server-client-tls", Namespace: argoCD.Namespace}, secret)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func Test_ArgoCDReconciler_reconcileRepoServerServingSecret(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	argoCD := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.TLS.MutualTLS = true
+	})
+	r := makeTestReconciler(t, argoCD)
+
+	assert.NoError(t, r.reconcileClusterSecrets(argoCD))
+
+	caSecret := util.NewSecretWithSuffix(argoCD, "ca")
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: caSecret.Name, Namespace: argoCD.Namespace}, caSecret))
+
+	// the repo server certificate is signed by the operator CA, and carries the CA bundle for its clients
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRepoServerTLSSecretName, Namespace: argoCD.Namespace}, secret))
+	assert.Equal(t, corev1.SecretTypeTLS, secret.Type)
+	assert.Equal(t, caSecret.Data[corev1.ServiceAccountRootCAKey], secret.Data[corev1.ServiceAccountRootCAKey])
+
+	roots, err := util.ParsePEMEncodedCerts(secret.Data[corev1.ServiceAccountRootCAKey])
+	assert.NoError(t, err)
+	pool := x509.NewCertPool()
+	for _, root := range roots {
+		pool.AddCert(root)
+	}
+	cert, err := util.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	_, err = cert.Verify(x509.VerifyOptions{
+		DNSName:   fmt.Sprintf("%s-repo-server.%s.svc.cluster.local", argoCD.Name, argoCD.Namespace),
+		Roots:     pool,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	assert.NoError(t, err)
+
+	// disabling mutual TLS removes the certificate issued by the operator
+	argoCD.Spec.TLS.MutualTLS = false
+	assert.NoError(t, r.reconcileRepoServerServingSecret(argoCD))
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRepoServerTLSSecretName, Namespace: argoCD.Namespace}, secret)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func Test_ArgoCDReconciler_reconcileRepoServerServingSecret_providedSecret(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	provided := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.ArgoCDRepoServerTLSSecretName,
+			Namespace: testNamespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte("provided"),
+			corev1.TLSPrivateKeyKey: []byte("provided"),
+		},
+	}
+	argoCD := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.TLS.MutualTLS = true
+	})
+	r := makeTestReconciler(t, argoCD, provided)
+
+	// certificates provided by another issuer are left untouched
+	assert.NoError(t, r.reconcileClusterSecrets(argoCD))
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRepoServerTLSSecretName, Namespace: argoCD.Namespace}, secret))
+	assert.Equal(t, provided.Data, secret.Data)
+}
+
+func Test_ArgoCDReconciler_reconcileMutualTLSStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           []argoCDOpt
+		useTLSForRedis bool
+		wantStatus     metav1.ConditionStatus
+		wantReason     string
+	}{
+		{
+			name:           "enabled",
+			useTLSForRedis: true,
+			wantStatus:     metav1.ConditionTrue,
+			wantReason:     "Enabled",
+		},
+		{
+			name:       "redis tls missing",
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "RedisTLSMissing",
+		},
+		{
+			name: "redis ha",
+			opts: []argoCDOpt{func(a *argoproj.ArgoCD) {
+				a.Spec.HA.Enabled = true
+			}},
+			useTLSForRedis: true,
+			wantStatus:     metav1.ConditionFalse,
+			wantReason:     "RedisHA",
+		},
+		{
+			name:           "external redis",
+			opts:           []argoCDOpt{externalRedis(&argoproj.ArgoCDRedisExternalSpec{Address: "redis.example.com:6379"})},
+			useTLSForRedis: true,
+			wantStatus:     metav1.ConditionFalse,
+			wantReason:     "RedisExternal",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := append([]argoCDOpt{func(a *argoproj.ArgoCD) {
+				a.Spec.TLS.MutualTLS = true
+			}}, test.opts...)
+			argoCD := makeTestArgoCD(opts...)
+			r := makeTestReconciler(t, argoCD)
+
+			assert.NoError(t, r.reconcileMutualTLSStatus(argoCD, test.useTLSForRedis))
+			condition := meta.FindStatusCondition(argoCD.Status.Conditions, common.ArgoCDConditionMutualTLSReady)
+			if assert.NotNil(t, condition) {
+				assert.Equal(t, test.wantStatus, condition.Status)
+				assert.Equal(t, test.wantReason, condition.Reason)
+			}
+
+			// the condition is removed once mutual TLS is disabled
+			argoCD.Spec.TLS.MutualTLS = false
+			assert.NoError(t, r.reconcileMutualTLSStatus(argoCD, test.useTLSForRedis))
+			assert.Nil(t, meta.FindStatusCondition(argoCD.Status.Conditions, common.ArgoCDConditionMutualTLSReady))
+		})
+	}
+}
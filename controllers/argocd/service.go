@@ -227,7 +227,7 @@ func (r *ArgoCDReconciler) reconcileRedisHAProxyService(cr *argoproj.ArgoCD) err
 			return r.Client.Delete(context.TODO(), svc)
 		}
 
-		if ensureAutoTLSAnnotation(svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS()) {
+		if ensureAutoTLSAnnotation(svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS() && !usesCertManager(cr)) {
 			return r.Client.Update(context.TODO(), svc)
 		}
 		return nil // Service found, do nothing
@@ -237,7 +237,7 @@ func (r *ArgoCDReconciler) reconcileRedisHAProxyService(cr *argoproj.ArgoCD) err
 		return nil //return as Ha is not enabled do nothing
 	}
 
-	ensureAutoTLSAnnotation(svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS())
+	ensureAutoTLSAnnotation(svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS() && !usesCertManager(cr))
 
 	svc.Spec.Selector = map[string]string{
 		common.AppK8sKeyName: util.NameWithSuffix(cr.Name, "redis-ha-haproxy"),
@@ -280,7 +280,7 @@ func (r *ArgoCDReconciler) reconcileRedisService(cr *argoproj.ArgoCD) error {
 	svc := newServiceWithSuffix("redis", "redis", cr)
 
 	if util.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-		if ensureAutoTLSAnnotation(svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS()) {
+		if ensureAutoTLSAnnotation(svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS() && !usesCertManager(cr)) {
 			return r.Client.Update(context.TODO(), svc)
 		}
 		if cr.Spec.HA.Enabled {
@@ -293,7 +293,7 @@ func (r *ArgoCDReconciler) reconcileRedisService(cr *argoproj.ArgoCD) error {
 		return nil //return as Ha is enabled do nothing
 	}
 
-	ensureAutoTLSAnnotation(svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS())
+	ensureAutoTLSAnnotation(svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS() && !usesCertManager(cr))
 
 	svc.Spec.Selector = map[string]string{
 		common.AppK8sKeyName: util.NameWithSuffix(cr.Name, "redis"),
@@ -359,13 +359,13 @@ func (r *ArgoCDReconciler) reconcileRepoService(cr *argoproj.ArgoCD) error {
 	svc := newServiceWithSuffix("repo-server", "repo-server", cr)
 
 	if util.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-		if ensureAutoTLSAnnotation(svc, common.ArgoCDRepoServerTLSSecretName, cr.Spec.Repo.WantsAutoTLS()) {
+		if ensureAutoTLSAnnotation(svc, common.ArgoCDRepoServerTLSSecretName, cr.Spec.Repo.WantsAutoTLS() && !usesCertManager(cr)) {
 			return r.Client.Update(context.TODO(), svc)
 		}
 		return nil // Service found, do nothing
 	}
 
-	ensureAutoTLSAnnotation(svc, common.ArgoCDRepoServerTLSSecretName, cr.Spec.Repo.WantsAutoTLS())
+	ensureAutoTLSAnnotation(svc, common.ArgoCDRepoServerTLSSecretName, cr.Spec.Repo.WantsAutoTLS() && !usesCertManager(cr))
 
 	svc.Spec.Selector = map[string]string{
 		common.AppK8sKeyName: util.NameWithSuffix(cr.Name, "repo-server"),
@@ -421,13 +421,13 @@ func (r *ArgoCDReconciler) reconcileServerMetricsService(cr *argoproj.ArgoCD) er
 func (r *ArgoCDReconciler) reconcileServerService(cr *argoproj.ArgoCD) error {
 	svc := newServiceWithSuffix("server", "server", cr)
 	if util.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-		if ensureAutoTLSAnnotation(svc, common.ArgoCDServerTLSSecretName, cr.Spec.Server.WantsAutoTLS()) {
+		if ensureAutoTLSAnnotation(svc, common.ArgoCDServerTLSSecretName, cr.Spec.Server.WantsAutoTLS() && !usesCertManager(cr)) {
 			return r.Client.Update(context.TODO(), svc)
 		}
 		return nil // Service found, do nothing
 	}
 
-	ensureAutoTLSAnnotation(svc, common.ArgoCDServerTLSSecretName, cr.Spec.Server.WantsAutoTLS())
+	ensureAutoTLSAnnotation(svc, common.ArgoCDServerTLSSecretName, cr.Spec.Server.WantsAutoTLS() && !usesCertManager(cr))
 
 	svc.Spec.Ports = []corev1.ServicePort{
 		{
@@ -472,14 +472,21 @@ func (r *ArgoCDReconciler) reconcileServices(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
-	err = r.reconcileRedisHAServices(cr)
-	if err != nil {
-		return err
-	}
+	if isRedisExternal(cr) {
+		err = r.deleteRedisServices(cr)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = r.reconcileRedisHAServices(cr)
+		if err != nil {
+			return err
+		}
 
-	err = r.reconcileRedisService(cr)
-	if err != nil {
-		return err
+		err = r.reconcileRedisService(cr)
+		if err != nil {
+			return err
+		}
 	}
 
 	err = r.reconcileRepoService(cr)
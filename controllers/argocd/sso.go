@@ -99,12 +99,40 @@ func (r *ArgoCDReconciler) reconcileSSO(cr *argoproj.ArgoCD) error {
 		}
 
 		// case 4
+		if cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeOIDC {
+			// Relevant SSO settings at play are `.spec.sso.oidc` fields, `.spec.sso.dex`, `.spec.sso.keycloak`, `.spec.oidcConfig`
+
+			if cr.Spec.SSO.Dex != nil || cr.Spec.SSO.Keycloak != nil {
+				// dex or keycloak spec fields are expressed when `.spec.sso.provider` is set to oidc ==> conflict
+				errMsg = "cannot supply dex or keycloak configuration when requested SSO provider is oidc"
+				isError = true
+			} else if cr.Spec.OIDCConfig != "" {
+				// raw oidc configuration is expressed when `.spec.sso.provider` is set to oidc ==> conflict
+				errMsg = "cannot supply .spec.oidcConfig when requested SSO provider is oidc"
+				isError = true
+			} else if err := validateOIDCSpec(cr.Spec.SSO.OIDC); err != nil {
+				errMsg = err.Error()
+				isError = true
+			}
+
+			if isError {
+				err = errors.New(illegalSSOConfiguration + errMsg)
+				log.Error(err, fmt.Sprintf("Illegal expression of SSO configuration detected for Argo CD %s in namespace %s. %s", cr.Name, cr.Namespace, errMsg))
+				ssoConfigLegalStatus = ssoLegalFailed // set global indicator that SSO config has gone wrong
+				_ = r.reconcileStatusSSO(cr)
+				return err
+			}
+		}
+
+		// case 5
 		if cr.Spec.SSO.Provider.ToLower() == "" {
 
 			if cr.Spec.SSO.Dex != nil ||
 				// `.spec.sso.dex` expressed without specifying SSO provider ==> conflict
-				cr.Spec.SSO.Keycloak != nil {
+				cr.Spec.SSO.Keycloak != nil ||
 				// `.spec.sso.keycloak` expressed without specifying SSO provider ==> conflict
+				cr.Spec.SSO.OIDC != nil {
+				// `.spec.sso.oidc` expressed without specifying SSO provider ==> conflict
 
 				errMsg = "Cannot specify SSO provider spec without specifying SSO provider type"
 				err = errors.New(illegalSSOConfiguration + errMsg)
@@ -115,11 +143,12 @@ func (r *ArgoCDReconciler) reconcileSSO(cr *argoproj.ArgoCD) error {
 			}
 		}
 
-		// case 5
-		if cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeDex && cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeKeycloak {
+		// case 6
+		if cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeDex && cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeKeycloak &&
+			cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeOIDC {
 			// `.spec.sso.provider` contains unsupported value
 
-			errMsg = fmt.Sprintf("Unsupported SSO provider type. Supported providers are %s and %s", argoproj.SSOProviderTypeDex, argoproj.SSOProviderTypeKeycloak)
+			errMsg = fmt.Sprintf("Unsupported SSO provider type. Supported providers are %s, %s and %s", argoproj.SSOProviderTypeDex, argoproj.SSOProviderTypeKeycloak, argoproj.SSOProviderTypeOIDC)
 			err = errors.New(illegalSSOConfiguration + errMsg)
 			log.Error(err, fmt.Sprintf("Unsupported SSO provider type for Argo CD %s in namespace %s.", cr.Name, cr.Namespace))
 			ssoConfigLegalStatus = ssoLegalFailed // set global indicator that SSO config has gone wrong
@@ -148,7 +177,7 @@ func (r *ArgoCDReconciler) reconcileSSO(cr *argoproj.ArgoCD) error {
 	} else if UseDex(cr) {
 		// dex
 		// Delete any lingering keycloak artifacts before Dex is configured as this is not handled by the reconcilliation loop
-		if err := deleteKeycloakConfiguration(cr); err != nil && !apiErrors.IsNotFound(err) {
+		if err := r.deleteKeycloakConfiguration(cr); err != nil && !apiErrors.IsNotFound(err) {
 			log.Error(err, "Unable to delete existing SSO configuration before configuring Dex")
 			return err
 		}
@@ -156,6 +185,18 @@ func (r *ArgoCDReconciler) reconcileSSO(cr *argoproj.ArgoCD) error {
 		if err := r.reconcileDexResources(cr); err != nil {
 			return err
 		}
+	} else if UseOIDC(cr) {
+		// oidc
+		// Delete any lingering keycloak and dex artifacts, the oidc configuration is reconciled in argocd-cm
+		if err := r.deleteKeycloakConfiguration(cr); err != nil && !apiErrors.IsNotFound(err) {
+			log.Error(err, "Unable to delete existing SSO configuration before configuring OIDC")
+			return err
+		}
+
+		if err := r.reconcileDexResources(cr); err != nil && !apiErrors.IsNotFound(err) {
+			log.Error(err, "Unable to delete existing dex resources before configuring OIDC")
+			return err
+		}
 	}
 
 	_ = r.reconcileStatusSSO(cr)
@@ -168,7 +209,7 @@ func (r *ArgoCDReconciler) deleteSSOConfiguration(newCr *argoproj.ArgoCD, oldCr
 	log.Info("uninstalling existing SSO configuration")
 
 	if oldCr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeKeycloak {
-		if err := deleteKeycloakConfiguration(newCr); err != nil {
+		if err := r.deleteKeycloakConfiguration(oldCr); err != nil {
 			log.Error(err, "Unable to delete existing keycloak configuration")
 			return err
 		}
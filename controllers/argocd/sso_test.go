@@ -181,6 +181,51 @@ func TestReconcile_illegalSSOConfiguration(t *testing.T) {
 			Err:                      errors.New("illegal SSO configuration: cannot supply dex configuration when requested SSO provider is keycloak"),
 			wantSSOConfigLegalStatus: "Failed",
 		},
+		{
+			name: "no conflict - valid oidc sso configurations",
+			argoCD: makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+				ac.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+					Provider: argoproj.SSOProviderTypeOIDC,
+					OIDC: &argoproj.ArgoCDOIDCSpec{
+						Issuer:   "https://idp.example.com",
+						ClientID: "argocd",
+					},
+				}
+			}),
+			wantErr:                  false,
+			wantSSOConfigLegalStatus: "Success",
+		},
+		{
+			name: "sso provider oidc with malformed issuer",
+			argoCD: makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+				ac.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+					Provider: argoproj.SSOProviderTypeOIDC,
+					OIDC: &argoproj.ArgoCDOIDCSpec{
+						Issuer:   "idp.example.com",
+						ClientID: "argocd",
+					},
+				}
+			}),
+			wantErr:                  true,
+			Err:                      errors.New("illegal SSO configuration: invalid oidc issuer \"idp.example.com\": must be an absolute http(s) URL"),
+			wantSSOConfigLegalStatus: "Failed",
+		},
+		{
+			name: "sso provider oidc + `.spec.oidcConfig`",
+			argoCD: makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+				ac.Spec.OIDCConfig = "test-config"
+				ac.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+					Provider: argoproj.SSOProviderTypeOIDC,
+					OIDC: &argoproj.ArgoCDOIDCSpec{
+						Issuer:   "https://idp.example.com",
+						ClientID: "argocd",
+					},
+				}
+			}),
+			wantErr:                  true,
+			Err:                      errors.New("illegal SSO configuration: cannot supply .spec.oidcConfig when requested SSO provider is oidc"),
+			wantSSOConfigLegalStatus: "Failed",
+		},
 		{
 			name: "sso provider missing but sso.dex/keycloak supplied",
 			argoCD: makeTestArgoCD(func(ac *argoproj.ArgoCD) {
@@ -210,7 +255,7 @@ func TestReconcile_illegalSSOConfiguration(t *testing.T) {
 				}
 			}),
 			wantErr:                  true,
-			Err:                      errors.New("illegal SSO configuration: Unsupported SSO provider type. Supported providers are dex and keycloak"),
+			Err:                      errors.New("illegal SSO configuration: Unsupported SSO provider type. Supported providers are dex, keycloak and oidc"),
 			wantSSOConfigLegalStatus: "Failed",
 		},
 	}
@@ -30,6 +30,7 @@ import (
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocdexport"
 	"github.com/argoproj-labs/argocd-operator/pkg/mutation/openshift"
 	"github.com/argoproj-labs/argocd-operator/pkg/util"
 )
@@ -493,6 +494,11 @@ func (r *ArgoCDReconciler) getApplicationControllerReplicaCount(cr *argoproj.Arg
 
 		replicas = int32(len(clusterSecrets.Items)) / clustersPerShard
 
+		// managed clusters pinned to a shard require the shard to exist
+		if maxShard := getManagedClusterMaxShard(cr); replicas < maxShard+1 {
+			replicas = maxShard + 1
+		}
+
 		if replicas < minShards {
 			replicas = minShards
 		}
@@ -521,6 +527,7 @@ func (r *ArgoCDReconciler) reconcileApplicationControllerStatefulSet(cr *argopro
 	controllerEnv = util.EnvMerge(controllerEnv, getArgoControllerContainerEnv(cr), true)
 	// Let user specify their own environment first
 	controllerEnv = util.EnvMerge(controllerEnv, util.ProxyEnvVars(), false)
+	controllerEnv = util.EnvMerge(controllerEnv, getExternalRedisEnv(cr), true)
 	podSpec := &ss.Spec.Template.Spec
 	podSpec.Containers = []corev1.Container{{
 		Command:         getArgoApplicationControllerCommand(cr, useTLSForRedis),
@@ -588,6 +595,17 @@ func (r *ArgoCDReconciler) reconcileApplicationControllerStatefulSet(cr *argopro
 		},
 	}
 
+	if isMutualTLSEnabled(cr) {
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts,
+			getClientTLSVolumeMount("application-controller", common.VolumeMountPathControllerClientTLS))
+		podSpec.Volumes = append(podSpec.Volumes, getClientTLSVolume(cr, "application-controller"))
+	}
+
+	if isExternalRedisCAConfigured(cr) {
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, getExternalRedisCAVolumeMount())
+		podSpec.Volumes = append(podSpec.Volumes, getExternalRedisCAVolume(cr))
+	}
+
 	ss.Spec.Template.Spec.Affinity = &corev1.Affinity{
 		PodAntiAffinity: &corev1.PodAntiAffinity{
 			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
@@ -619,6 +637,9 @@ func (r *ArgoCDReconciler) reconcileApplicationControllerStatefulSet(cr *argopro
 	export := r.getArgoCDExport(cr)
 	if export == nil {
 		log.Info("existing argocd export not found, skipping import")
+	} else if argocdexport.UsesWorkloadIdentity(export) {
+		// The import runs in the application controller pod, whose ServiceAccount does not carry the identity of the export.
+		return fmt.Errorf("cannot import ArgoCDExport %s: workload identity is not supported for imports, the storage credentials must be provided in a Secret", export.Name)
 	} else {
 		podSpec.InitContainers = []corev1.Container{{
 			Command:         getArgoImportCommand(r.Client, cr),
@@ -636,10 +657,10 @@ func (r *ArgoCDReconciler) reconcileApplicationControllerStatefulSet(cr *argopro
 				},
 				RunAsNonRoot: util.BoolPtr(true),
 			},
-			VolumeMounts: getArgoImportVolumeMounts(),
+			VolumeMounts: getArgoImportVolumeMounts(export),
 		}}
 
-		podSpec.Volumes = getArgoImportVolumes(export)
+		podSpec.Volumes = append(podSpec.Volumes, getArgoImportVolumes(export)...)
 	}
 
 	invalidImagePod := containsInvalidImage(cr, r)
@@ -717,6 +738,9 @@ func (r *ArgoCDReconciler) reconcileStatefulSets(cr *argoproj.ArgoCD, useTLSForR
 	if err := r.reconcileApplicationControllerStatefulSet(cr, useTLSForRedis); err != nil {
 		return err
 	}
+	if isRedisExternal(cr) {
+		return r.deleteRedisStatefulSet(cr)
+	}
 	if err := r.reconcileRedisStatefulSet(cr); err != nil {
 		return err
 	}
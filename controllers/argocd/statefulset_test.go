@@ -299,6 +299,35 @@ func TestArgoCDReconciler_reconcileApplicationController_withResources(t *testin
 	assert.False(t, testResources.Limits.Memory().Equal(*rsC.Limits.Memory()))
 }
 
+func TestArgoCDReconciler_reconcileApplicationController_importWithWorkloadIdentity(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Import = &argoproj.ArgoCDImportSpec{
+			Name: "testimport",
+		}
+	})
+	ex := argoprojv1alpha1.ArgoCDExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testimport",
+			Namespace: a.Namespace,
+		},
+		Spec: argoprojv1alpha1.ArgoCDExportSpec{
+			Storage: &argoprojv1alpha1.ArgoCDExportStorageSpec{
+				Backend: common.ArgoCDExportStorageBackendAzure,
+				Azure: &argoprojv1alpha1.ArgoCDExportAzureStorageSpec{
+					StorageAccount:   "backups",
+					Container:        "argocd",
+					WorkloadIdentity: &argoprojv1alpha1.ArgoCDExportWorkloadIdentitySpec{Identity: "00000000-0000-0000-0000-000000000000"},
+				},
+			},
+		},
+	}
+	r := makeTestReconciler(t, a, &ex)
+
+	err := r.reconcileApplicationControllerStatefulSet(a, false)
+	assert.EqualError(t, err, "cannot import ArgoCDExport testimport: workload identity is not supported for imports, the storage credentials must be provided in a Secret")
+}
+
 func TestArgoCDReconciler_reconcileApplicationController_withSharding(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 
@@ -122,7 +122,12 @@ func (r *ArgoCDReconciler) reconcileStatusDex(cr *argoproj.ArgoCD) error {
 func (r *ArgoCDReconciler) reconcileStatusKeycloak(cr *argoproj.ArgoCD) error {
 	status := "Unknown"
 
-	if workloads.IsTemplateAPIAvailable() {
+	if isExternalKeycloak(cr) {
+		// an external keycloak is used, report the result of its realm reconciliation.
+		if realmStatus, ok := externalKeycloakRealmStatus[getExternalKeycloakRealmStatusKey(cr)]; ok {
+			status = realmStatus
+		}
+	} else if workloads.IsTemplateAPIAvailable() {
 		// keycloak is installed using OpenShift templates.
 		dc := &oappsv1.DeploymentConfig{
 			ObjectMeta: metav1.ObjectMeta{
@@ -193,6 +198,12 @@ func (r *ArgoCDReconciler) reconcileStatusSSO(cr *argoproj.ArgoCD) error {
 			return r.reconcileStatusDex(cr)
 		} else if cr.Spec.SSO != nil && cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeKeycloak {
 			return r.reconcileStatusKeycloak(cr)
+		} else if UseOIDC(cr) {
+			// no component is deployed for an existing OIDC provider
+			if cr.Status.SSO != "Running" {
+				cr.Status.SSO = "Running"
+				return r.Client.Status().Update(context.TODO(), cr)
+			}
 		}
 	} else {
 		// illegal/unknown sso configurations
@@ -226,7 +237,13 @@ func (r *ArgoCDReconciler) reconcileStatusPhase(cr *argoproj.ArgoCD) error {
 func (r *ArgoCDReconciler) reconcileStatusRedis(cr *argoproj.ArgoCD) error {
 	status := "Unknown"
 
-	if !cr.Spec.HA.Enabled {
+	if isRedisExternal(cr) {
+		// the external Redis is not managed by the operator, only its configuration is checked
+		status = "Running"
+		if err := validateExternalRedis(cr); err != nil {
+			status = "Failed"
+		}
+	} else if !cr.Spec.HA.Enabled {
 		deploy := newDeploymentWithSuffix("redis", "redis", cr)
 		if util.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
 			status = "Pending"
@@ -27,6 +27,7 @@ import (
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/pkg/certmanager"
 	"github.com/argoproj-labs/argocd-operator/pkg/monitoring"
 	"github.com/argoproj-labs/argocd-operator/pkg/networking"
 	util "github.com/argoproj-labs/argocd-operator/pkg/util"
@@ -44,6 +45,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
@@ -94,15 +96,23 @@ func getArgoApplicationControllerCommand(cr *argoproj.ArgoCD, useTLSForRedis boo
 	cmd := []string{
 		"argocd-application-controller",
 		"--operation-processors", fmt.Sprint(getArgoServerOperationProcessors(cr)),
-		"--redis", getRedisServerAddress(cr),
 	}
 
-	if useTLSForRedis {
-		cmd = append(cmd, "--redis-use-tls")
-		if isRedisTLSVerificationDisabled(cr) {
-			cmd = append(cmd, "--redis-insecure-skip-tls-verify")
-		} else {
-			cmd = append(cmd, "--redis-ca-certificate", "/app/config/controller/tls/redis/tls.crt")
+	if isRedisExternal(cr) {
+		cmd = append(cmd, getExternalRedisArgs(cr)...)
+	} else {
+		cmd = append(cmd, "--redis", getRedisServerAddress(cr))
+
+		if useTLSForRedis {
+			cmd = append(cmd, "--redis-use-tls")
+			if isRedisTLSVerificationDisabled(cr) {
+				cmd = append(cmd, "--redis-insecure-skip-tls-verify")
+			} else {
+				cmd = append(cmd, "--redis-ca-certificate", "/app/config/controller/tls/redis/tls.crt")
+			}
+			if isRedisClientAuthRequired(cr, useTLSForRedis) {
+				cmd = append(cmd, getRedisClientCertificateArgs(common.VolumeMountPathControllerClientTLS)...)
+			}
 		}
 	}
 
@@ -192,7 +202,48 @@ func getArgoServerInsecure(cr *argoproj.ArgoCD) bool {
 }
 
 func isRepoServerTLSVerificationRequested(cr *argoproj.ArgoCD) bool {
-	return cr.Spec.Repo.VerifyTLS
+	return cr.Spec.Repo.VerifyTLS || isMutualTLSEnabled(cr)
+}
+
+// isMutualTLSEnabled returns true if mutual TLS between the Argo CD components is enabled.
+func isMutualTLSEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.TLS.MutualTLS
+}
+
+// isRedisClientAuthRequired returns true if Redis requires its clients to present a certificate signed by the operator
+// CA. Client certificates are not verified by the HAProxy in front of Redis when HA is enabled.
+func isRedisClientAuthRequired(cr *argoproj.ArgoCD, useTLSForRedis bool) bool {
+	return isMutualTLSEnabled(cr) && useTLSForRedis && !cr.Spec.HA.Enabled
+}
+
+// getRedisClientCertificateArgs returns the arguments of a Redis client to authenticate with the client certificate
+// mounted in the given directory.
+func getRedisClientCertificateArgs(dir string) []string {
+	return []string{
+		"--redis-client-certificate", fmt.Sprintf("%s/%s", dir, corev1.TLSCertKey),
+		"--redis-client-key", fmt.Sprintf("%s/%s", dir, corev1.TLSPrivateKeyKey),
+	}
+}
+
+// getClientTLSVolume returns the Volume of the mutual TLS client certificate of the given component.
+func getClientTLSVolume(cr *argoproj.ArgoCD, component string) corev1.Volume {
+	return corev1.Volume{
+		Name: fmt.Sprintf("argocd-%s-client-tls", component),
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: getClientTLSSecretName(cr, component),
+				Optional:   util.BoolPtr(true),
+			},
+		},
+	}
+}
+
+// getClientTLSVolumeMount returns the VolumeMount of the mutual TLS client certificate of the given component.
+func getClientTLSVolumeMount(component, dir string) corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      fmt.Sprintf("argocd-%s-client-tls", component),
+		MountPath: dir,
+	}
 }
 
 func isRedisTLSVerificationDisabled(cr *argoproj.ArgoCD) bool {
@@ -564,6 +615,11 @@ func getRedisServerAddress(cr *argoproj.ArgoCD) string {
 
 // reconcileCertificateAuthority will reconcile all Certificate Authority resources.
 func (r *ArgoCDReconciler) reconcileCertificateAuthority(cr *argoproj.ArgoCD) error {
+	log.Info("validating certificate profile")
+	if err := r.reconcileCertificateProfileValidation(cr); err != nil {
+		return err
+	}
+
 	log.Info("reconciling CA secret")
 	if err := r.reconcileClusterCASecret(cr); err != nil {
 		return err
@@ -640,6 +696,11 @@ func (r *ArgoCDReconciler) reconcileResources(cr *argoproj.ArgoCD) error {
 		log.Info(err.Error())
 	}
 
+	// the Argo CD components cannot be pointed to an external Redis without an address
+	if err := validateExternalRedis(cr); err != nil {
+		return err
+	}
+
 	log.Info("reconciling roles")
 	if err := r.reconcileRoles(cr); err != nil {
 		log.Info(err.Error())
@@ -668,26 +729,43 @@ func (r *ArgoCDReconciler) reconcileResources(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	log.Info("reconciling certificates")
+	certificatesReady, err := r.reconcileCertificates(cr)
+	if err != nil {
+		return err
+	}
 	useTLSForRedis := r.redisShouldUseTLS(cr)
+	if err := r.reconcileMutualTLSStatus(cr, useTLSForRedis); err != nil {
+		return err
+	}
 
 	log.Info("reconciling config maps")
 	if err := r.reconcileConfigMaps(cr, useTLSForRedis); err != nil {
 		return err
 	}
 
-	log.Info("reconciling services")
-	if err := r.reconcileServices(cr); err != nil {
+	log.Info("reconciling default project")
+	if err := r.reconcileDefaultProject(cr); err != nil {
 		return err
 	}
 
-	log.Info("reconciling deployments")
-	if err := r.reconcileDeployments(cr, useTLSForRedis); err != nil {
+	log.Info("reconciling services")
+	if err := r.reconcileServices(cr); err != nil {
 		return err
 	}
 
-	log.Info("reconciling statefulsets")
-	if err := r.reconcileStatefulSets(cr, useTLSForRedis); err != nil {
-		return err
+	// The workloads are rolled out once cert-manager has issued the certificates they use, the owned Certificates
+	// trigger a new reconciliation when their status changes.
+	if certificatesReady {
+		log.Info("reconciling deployments")
+		if err := r.reconcileDeployments(cr, useTLSForRedis); err != nil {
+			return err
+		}
+
+		log.Info("reconciling statefulsets")
+		if err := r.reconcileStatefulSets(cr, useTLSForRedis); err != nil {
+			return err
+		}
 	}
 
 	log.Info("reconciling autoscalers")
@@ -707,6 +785,13 @@ func (r *ArgoCDReconciler) reconcileResources(cr *argoproj.ArgoCD) error {
 		}
 	}
 
+	if networking.IsGatewayAPIAvailable() {
+		log.Info("reconciling gateway routes")
+		if err := r.reconcileGatewayRoutes(cr); err != nil {
+			return err
+		}
+	}
+
 	if monitoring.IsPrometheusAPIAvailable() {
 		log.Info("reconciling prometheus")
 		if err := r.reconcilePrometheus(cr); err != nil {
@@ -839,8 +924,20 @@ func (r *ArgoCDReconciler) addDeletionFinalizer(argocd *argoproj.ArgoCD) error {
 	return nil
 }
 
+// resourceMappers holds the functions mapping watch events on resources not owned by an ArgoCD back to the ArgoCD
+// objects that we want to reconcile.
+type resourceMappers struct {
+	clusterResource               handler.MapFunc
+	tlsSecret                     handler.MapFunc
+	namespaceResource             handler.MapFunc
+	clusterSecretResource         handler.MapFunc
+	applicationSetSCMTLSConfigMap handler.MapFunc
+	referencedSecret              handler.MapFunc
+	gpgKeyConfigMap               handler.MapFunc
+}
+
 // setResourceWatches will register Watches for each of the supported Resources.
-func (r *ArgoCDReconciler) setResourceWatches(bldr *builder.Builder, clusterResourceMapper, tlsSecretMapper, namespaceResourceMapper, clusterSecretResourceMapper, applicationSetGitlabSCMTLSConfigMapMapper handler.MapFunc) *builder.Builder {
+func (r *ArgoCDReconciler) setResourceWatches(bldr *builder.Builder, mappers resourceMappers) *builder.Builder {
 
 	deploymentConfigPred := predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
@@ -951,13 +1048,17 @@ func (r *ArgoCDReconciler) setResourceWatches(bldr *builder.Builder, clusterReso
 
 	bldr.Owns(&v1.RoleBinding{})
 
-	clusterResourceHandler := handler.EnqueueRequestsFromMapFunc(clusterResourceMapper)
+	clusterResourceHandler := handler.EnqueueRequestsFromMapFunc(mappers.clusterResource)
 
-	clusterSecretResourceHandler := handler.EnqueueRequestsFromMapFunc(clusterSecretResourceMapper)
+	clusterSecretResourceHandler := handler.EnqueueRequestsFromMapFunc(mappers.clusterSecretResource)
 
-	appSetGitlabSCMTLSConfigMapHandler := handler.EnqueueRequestsFromMapFunc(applicationSetGitlabSCMTLSConfigMapMapper)
+	appSetGitlabSCMTLSConfigMapHandler := handler.EnqueueRequestsFromMapFunc(mappers.applicationSetSCMTLSConfigMap)
 
-	tlsSecretHandler := handler.EnqueueRequestsFromMapFunc(tlsSecretMapper)
+	tlsSecretHandler := handler.EnqueueRequestsFromMapFunc(mappers.tlsSecret)
+
+	referencedSecretHandler := handler.EnqueueRequestsFromMapFunc(mappers.referencedSecret)
+
+	gpgKeyConfigMapHandler := handler.EnqueueRequestsFromMapFunc(mappers.gpgKeyConfigMap)
 
 	bldr.Watches(&source.Kind{Type: &v1.ClusterRoleBinding{}}, clusterResourceHandler)
 
@@ -976,6 +1077,12 @@ func (r *ArgoCDReconciler) setResourceWatches(bldr *builder.Builder, clusterReso
 			common.ArgoCDArgoprojKeyManagedByClusterArgoCD: "cluster",
 		}}}}, clusterSecretResourceHandler)
 
+	// Watch for secrets referenced by the Dex connectors, repositories, managed clusters, GPG keys and notifications services
+	bldr.Watches(&source.Kind{Type: &corev1.Secret{}}, referencedSecretHandler)
+
+	// Watch for config maps referenced by the GPG keys
+	bldr.Watches(&source.Kind{Type: &corev1.ConfigMap{}}, gpgKeyConfigMapHandler)
+
 	// Watch for changes to Secret sub-resources owned by ArgoCD instances.
 	bldr.Owns(&appsv1.StatefulSet{})
 
@@ -988,6 +1095,22 @@ func (r *ArgoCDReconciler) setResourceWatches(bldr *builder.Builder, clusterReso
 		bldr.Owns(&routev1.Route{})
 	}
 
+	// Watch the Gateway API HTTPRoute and GRPCRoute sub-resources owned by ArgoCD instances, for the kinds that are served.
+	for _, kind := range []string{networking.HTTPRouteKind, networking.GRPCRouteKind} {
+		if networking.IsGatewayRouteKindAvailable(kind) {
+			route := &unstructured.Unstructured{}
+			route.SetGroupVersionKind(networking.GatewayRouteGVK(kind))
+			bldr.Owns(route)
+		}
+	}
+
+	if certmanager.IsCertManagerAPIAvailable() {
+		// Watch cert-manager Certificates owned by ArgoCD instances, so that workloads are rolled out once issued.
+		certificate := &unstructured.Unstructured{}
+		certificate.SetGroupVersionKind(certmanager.CertificateGVK())
+		bldr.Owns(certificate)
+	}
+
 	if monitoring.IsPrometheusAPIAvailable() {
 		// Watch Prometheus sub-resources owned by ArgoCD instances.
 		bldr.Owns(&monitoringv1.Prometheus{})
@@ -1005,7 +1128,7 @@ func (r *ArgoCDReconciler) setResourceWatches(bldr *builder.Builder, clusterReso
 			builder.WithPredicates(deploymentConfigPred))
 	}
 
-	namespaceHandler := handler.EnqueueRequestsFromMapFunc(namespaceResourceMapper)
+	namespaceHandler := handler.EnqueueRequestsFromMapFunc(mappers.namespaceResource)
 
 	bldr.Watches(&source.Kind{Type: &corev1.Namespace{}}, namespaceHandler, builder.WithPredicates(namespaceFilterPredicate()))
 
@@ -1025,6 +1148,35 @@ func (r *ArgoCDReconciler) triggerRollout(obj interface{}, key string) error {
 	}
 }
 
+// isRolloutComplete returns true once the given Deployment or StatefulSet runs its current pod template on all of its
+// replicas, or when it is not found.
+func (r *ArgoCDReconciler) isRolloutComplete(obj interface{}) (bool, error) {
+	switch res := obj.(type) {
+	case *appsv1.Deployment:
+		if !util.IsObjectFound(r.Client, res.Namespace, res.Name, res) {
+			return true, nil
+		}
+		replicas := int32(1)
+		if res.Spec.Replicas != nil {
+			replicas = *res.Spec.Replicas
+		}
+		return res.Status.ObservedGeneration >= res.Generation && res.Status.UpdatedReplicas == replicas &&
+			res.Status.Replicas == replicas && res.Status.AvailableReplicas == replicas, nil
+	case *appsv1.StatefulSet:
+		if !util.IsObjectFound(r.Client, res.Namespace, res.Name, res) {
+			return true, nil
+		}
+		replicas := int32(1)
+		if res.Spec.Replicas != nil {
+			replicas = *res.Spec.Replicas
+		}
+		return res.Status.ObservedGeneration >= res.Generation && res.Status.UpdatedReplicas == replicas &&
+			res.Status.CurrentRevision == res.Status.UpdateRevision, nil
+	default:
+		return false, fmt.Errorf("resource of unknown type %T, cannot check rollout", res)
+	}
+}
+
 func allowedNamespace(current string, namespaces string) bool {
 
 	clusterConfigNamespaces := util.SplitList(namespaces)
@@ -62,6 +62,7 @@ func (r *ArgoCDExportReconciler) Reconcile(ctx context.Context, request ctrl.Req
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			deleteExportMetrics(request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -0,0 +1,381 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdexport
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/pkg/permissions"
+	util "github.com/argoproj-labs/argocd-operator/pkg/util"
+)
+
+const (
+	// credentialsVolumeName is the name of the Volume holding the cloud storage credentials for the export process.
+	credentialsVolumeName = "credentials-storage"
+
+	// credentialsMountPath is the path the cloud storage credentials are mounted at.
+	credentialsMountPath = "/credentials"
+)
+
+// storageBackend returns the normalised storage backend for the given ArgoCDExport.
+func storageBackend(cr *argoprojv1alpha1.ArgoCDExport) string {
+	if cr.Spec.Storage == nil || len(cr.Spec.Storage.Backend) <= 0 {
+		return common.ArgoCDExportStorageBackendLocal
+	}
+	return strings.ToLower(cr.Spec.Storage.Backend)
+}
+
+// getWorkloadIdentity returns the workload identity options for the configured cloud backend, if any.
+func getWorkloadIdentity(cr *argoprojv1alpha1.ArgoCDExport) *argoprojv1alpha1.ArgoCDExportWorkloadIdentitySpec {
+	if cr.Spec.Storage == nil {
+		return nil
+	}
+
+	switch storageBackend(cr) {
+	case common.ArgoCDExportStorageBackendAzure:
+		if cr.Spec.Storage.Azure != nil {
+			return cr.Spec.Storage.Azure.WorkloadIdentity
+		}
+	case common.ArgoCDExportStorageBackendGCP:
+		if cr.Spec.Storage.GCP != nil {
+			return cr.Spec.Storage.GCP.WorkloadIdentity
+		}
+	}
+	return nil
+}
+
+// UsesWorkloadIdentity returns true if the given ArgoCDExport accesses its cloud storage through workload identity.
+func UsesWorkloadIdentity(cr *argoprojv1alpha1.ArgoCDExport) bool {
+	return getWorkloadIdentity(cr) != nil
+}
+
+// getWorkloadIdentityServiceAccountName returns the name of the ServiceAccount used by export Jobs with workload identity.
+func getWorkloadIdentityServiceAccountName(cr *argoprojv1alpha1.ArgoCDExport) string {
+	return util.NameWithSuffix(cr.Name, "export")
+}
+
+// getCredentialsSecretName returns the name of the Secret holding the cloud storage credentials.
+func getCredentialsSecretName(cr *argoprojv1alpha1.ArgoCDExport) string {
+	name := ""
+	switch storageBackend(cr) {
+	case common.ArgoCDExportStorageBackendAzure:
+		if cr.Spec.Storage.Azure != nil {
+			name = cr.Spec.Storage.Azure.CredentialsSecretName
+		}
+	case common.ArgoCDExportStorageBackendGCP:
+		if cr.Spec.Storage.GCP != nil {
+			name = cr.Spec.Storage.GCP.CredentialsSecretName
+		}
+	}
+
+	if len(name) <= 0 {
+		name = FetchStorageSecretName(cr)
+	}
+	return name
+}
+
+// usesCredentialsVolume returns true if the cloud storage credentials must be mounted as a file.
+func usesCredentialsVolume(cr *argoprojv1alpha1.ArgoCDExport) bool {
+	return storageBackend(cr) == common.ArgoCDExportStorageBackendGCP && getWorkloadIdentity(cr) == nil
+}
+
+// AzureStorageEnv returns the environment variables that configure the Azure Blob storage backend.
+func AzureStorageEnv(cr *argoprojv1alpha1.ArgoCDExport) []corev1.EnvVar {
+	env := make([]corev1.EnvVar, 0)
+	if cr.Spec.Storage == nil || cr.Spec.Storage.Azure == nil {
+		return env
+	}
+	azure := cr.Spec.Storage.Azure
+
+	env = append(env, corev1.EnvVar{Name: "AZURE_STORAGE_ACCOUNT", Value: azure.StorageAccount})
+	env = append(env, corev1.EnvVar{Name: "AZURE_STORAGE_CONTAINER", Value: azure.Container})
+
+	if len(azure.Prefix) > 0 {
+		env = append(env, corev1.EnvVar{Name: "AZURE_STORAGE_PREFIX", Value: azure.Prefix})
+	}
+
+	if len(azure.Endpoint) > 0 {
+		env = append(env, corev1.EnvVar{Name: "AZURE_STORAGE_ENDPOINT", Value: azure.Endpoint})
+	}
+
+	// With workload identity the webhook injects AZURE_CLIENT_ID and AZURE_FEDERATED_TOKEN_FILE instead.
+	if azure.WorkloadIdentity == nil {
+		env = append(env, corev1.EnvVar{
+			Name: "AZURE_STORAGE_KEY",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: getCredentialsSecretName(cr),
+					},
+					Key: common.ArgoCDKeyAzureStorageAccountKey,
+				},
+			},
+		})
+	}
+
+	return env
+}
+
+// GCPStorageEnv returns the environment variables that configure the Google Cloud Storage backend.
+func GCPStorageEnv(cr *argoprojv1alpha1.ArgoCDExport) []corev1.EnvVar {
+	env := make([]corev1.EnvVar, 0)
+	if cr.Spec.Storage == nil || cr.Spec.Storage.GCP == nil {
+		return env
+	}
+	gcp := cr.Spec.Storage.GCP
+
+	env = append(env, corev1.EnvVar{Name: "GCS_BUCKET", Value: gcp.Bucket})
+
+	if len(gcp.Prefix) > 0 {
+		env = append(env, corev1.EnvVar{Name: "GCS_PREFIX", Value: gcp.Prefix})
+	}
+
+	// STORAGE_EMULATOR_HOST is honoured by the GCS client libraries, e.g. for fake-gcs-server.
+	if len(gcp.Endpoint) > 0 {
+		env = append(env, corev1.EnvVar{Name: "STORAGE_EMULATOR_HOST", Value: gcp.Endpoint})
+	}
+
+	if usesCredentialsVolume(cr) {
+		env = append(env, corev1.EnvVar{
+			Name:  "GOOGLE_APPLICATION_CREDENTIALS",
+			Value: fmt.Sprintf("%s/%s", credentialsMountPath, common.ArgoCDKeyGCPCredentials),
+		})
+	}
+
+	return env
+}
+
+// CredentialsVolume returns the Volume and VolumeMount for the cloud storage credentials, if the backend needs them.
+func CredentialsVolume(cr *argoprojv1alpha1.ArgoCDExport) (*corev1.Volume, *corev1.VolumeMount) {
+	if !usesCredentialsVolume(cr) {
+		return nil, nil
+	}
+
+	volume := &corev1.Volume{
+		Name: credentialsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: getCredentialsSecretName(cr),
+				Items: []corev1.KeyToPath{{
+					Key:  common.ArgoCDKeyGCPCredentials,
+					Path: common.ArgoCDKeyGCPCredentials,
+				}},
+			},
+		},
+	}
+
+	mount := &corev1.VolumeMount{
+		Name:      credentialsVolumeName,
+		MountPath: credentialsMountPath,
+		ReadOnly:  true,
+	}
+
+	return volume, mount
+}
+
+// validateStorage will ensure that the storage options for the given ArgoCDExport are consistent.
+func validateStorage(cr *argoprojv1alpha1.ArgoCDExport) error {
+	if cr.Spec.Storage == nil {
+		return nil
+	}
+	storage := cr.Spec.Storage
+	backend := storageBackend(cr)
+
+	switch backend {
+	case common.ArgoCDExportStorageBackendLocal, common.ArgoCDExportStorageBackendAWS:
+	case common.ArgoCDExportStorageBackendAzure:
+		if storage.Azure == nil {
+			return fmt.Errorf("storage backend %q requires .spec.storage.azure to be set", backend)
+		}
+		if len(storage.Azure.StorageAccount) <= 0 {
+			return fmt.Errorf(".spec.storage.azure.storageAccount must not be empty")
+		}
+		if len(storage.Azure.Container) <= 0 {
+			return fmt.Errorf(".spec.storage.azure.container must not be empty")
+		}
+		if err := validateEndpoint(".spec.storage.azure.endpoint", storage.Azure.Endpoint); err != nil {
+			return err
+		}
+		if err := validateWorkloadIdentity(".spec.storage.azure.workloadIdentity", storage.Azure.WorkloadIdentity); err != nil {
+			return err
+		}
+	case common.ArgoCDExportStorageBackendGCP:
+		if storage.GCP == nil {
+			return fmt.Errorf("storage backend %q requires .spec.storage.gcp to be set", backend)
+		}
+		if len(storage.GCP.Bucket) <= 0 {
+			return fmt.Errorf(".spec.storage.gcp.bucket must not be empty")
+		}
+		if err := validateEndpoint(".spec.storage.gcp.endpoint", storage.GCP.Endpoint); err != nil {
+			return err
+		}
+		if err := validateWorkloadIdentity(".spec.storage.gcp.workloadIdentity", storage.GCP.WorkloadIdentity); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported storage backend %q, must be one of %q, %q, %q or %q", backend,
+			common.ArgoCDExportStorageBackendLocal, common.ArgoCDExportStorageBackendAWS,
+			common.ArgoCDExportStorageBackendAzure, common.ArgoCDExportStorageBackendGCP)
+	}
+
+	if storage.Azure != nil && backend != common.ArgoCDExportStorageBackendAzure {
+		return fmt.Errorf("cannot supply .spec.storage.azure when storage backend is %q", backend)
+	}
+	if storage.GCP != nil && backend != common.ArgoCDExportStorageBackendGCP {
+		return fmt.Errorf("cannot supply .spec.storage.gcp when storage backend is %q", backend)
+	}
+
+	return nil
+}
+
+// validateEndpoint will ensure that an optional endpoint override is an absolute URL.
+func validateEndpoint(field, endpoint string) error {
+	if len(endpoint) <= 0 {
+		return nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || len(u.Scheme) <= 0 || len(u.Host) <= 0 {
+		return fmt.Errorf("%s %q is not a valid absolute URL", field, endpoint)
+	}
+	return nil
+}
+
+// validateWorkloadIdentity will ensure that the workload identity options, when set, name an identity.
+func validateWorkloadIdentity(field string, wi *argoprojv1alpha1.ArgoCDExportWorkloadIdentitySpec) error {
+	if wi != nil && len(wi.Identity) <= 0 {
+		return fmt.Errorf("%s.identity must not be empty", field)
+	}
+	return nil
+}
+
+// reconcileWorkloadIdentity will ensure the ServiceAccount and RoleBinding used by export Jobs with workload identity are present.
+func (r *ArgoCDExportReconciler) reconcileWorkloadIdentity(cr *argoprojv1alpha1.ArgoCDExport) error {
+	name := getWorkloadIdentityServiceAccountName(cr)
+	wi := getWorkloadIdentity(cr)
+	if wi == nil {
+		// Only clean up what a previous workload identity configuration created for this export.
+		existingSA, err := permissions.GetServiceAccount(name, cr.Namespace, r.Client)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if !metav1.IsControlledBy(existingSA, cr) {
+			return nil
+		}
+		if err := permissions.DeleteRoleBinding(name, cr.Namespace, r.Client); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("deleting workload identity serviceaccount: %s", name))
+		return permissions.DeleteServiceAccount(name, cr.Namespace, r.Client)
+	}
+
+	argocdName, err := r.argocdName(cr.Namespace)
+	if err != nil {
+		return err
+	}
+
+	// Only the annotation naming the identity is managed, others added to the ServiceAccount are left alone.
+	annotations := map[string]string{}
+	switch storageBackend(cr) {
+	case common.ArgoCDExportStorageBackendAzure:
+		annotations[common.AzureWorkloadIdentityKeyClientID] = wi.Identity
+	case common.ArgoCDExportStorageBackendGCP:
+		annotations[common.GKEWorkloadIdentityKeyServiceAccount] = wi.Identity
+	}
+
+	sa := permissions.RequestServiceAccount(permissions.ServiceAccountRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   cr.Namespace,
+			Labels:      common.DefaultLabels(name, cr.Name, ""),
+			Annotations: annotations,
+		},
+	})
+
+	existingSA, err := permissions.GetServiceAccount(name, cr.Namespace, r.Client)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if err := controllerutil.SetControllerReference(cr, sa, r.Scheme); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("creating new workload identity serviceaccount: %s", name))
+		if err := permissions.CreateServiceAccount(sa, r.Client); err != nil {
+			return err
+		}
+	} else if !metav1.IsControlledBy(existingSA, cr) {
+		return fmt.Errorf("serviceaccount %s already exists and is not controlled by ArgoCDExport %s", name, cr.Name)
+	} else {
+		changed := false
+		for key, value := range annotations {
+			if existingSA.Annotations[key] != value {
+				if existingSA.Annotations == nil {
+					existingSA.Annotations = map[string]string{}
+				}
+				existingSA.Annotations[key] = value
+				changed = true
+			}
+		}
+		if changed {
+			if err := permissions.UpdateServiceAccount(existingSA, r.Client); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The export Job needs the same access to Argo CD resources as the application controller it otherwise runs as.
+	rb := permissions.RequestRoleBinding(permissions.RoleBindingRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels:    common.DefaultLabels(name, cr.Name, ""),
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     fmt.Sprintf("%s-%s", argocdName, "argocd-application-controller"),
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      name,
+			Namespace: cr.Namespace,
+		}},
+	})
+
+	if _, err := permissions.GetRoleBinding(name, cr.Namespace, r.Client); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if err := controllerutil.SetControllerReference(cr, rb, r.Scheme); err != nil {
+			return err
+		}
+		return permissions.CreateRoleBinding(rb, r.Client)
+	}
+
+	return nil
+}
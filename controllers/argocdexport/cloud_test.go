@@ -0,0 +1,352 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdexport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const (
+	testNamespace  = "argocd"
+	testArgoCDName = "argocd"
+	testExportName = "test-export"
+
+	// Endpoints of the emulators used in place of the real cloud services.
+	testAzuriteEndpoint = "http://azurite:10000/devstoreaccount1"
+	testFakeGCSEndpoint = "http://fake-gcs-server:4443"
+)
+
+type exportOpt func(*argoprojv1alpha1.ArgoCDExport)
+
+func makeTestExport(opts ...exportOpt) *argoprojv1alpha1.ArgoCDExport {
+	e := &argoprojv1alpha1.ArgoCDExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testExportName,
+			Namespace: testNamespace,
+		},
+		Spec: argoprojv1alpha1.ArgoCDExportSpec{
+			Argocd: testArgoCDName,
+		},
+	}
+	for _, o := range opts {
+		o(e)
+	}
+	return e
+}
+
+func withAzure(azure *argoprojv1alpha1.ArgoCDExportAzureStorageSpec) exportOpt {
+	return func(e *argoprojv1alpha1.ArgoCDExport) {
+		e.Spec.Storage = &argoprojv1alpha1.ArgoCDExportStorageSpec{
+			Backend: common.ArgoCDExportStorageBackendAzure,
+			Azure:   azure,
+		}
+	}
+}
+
+func withGCP(gcp *argoprojv1alpha1.ArgoCDExportGCPStorageSpec) exportOpt {
+	return func(e *argoprojv1alpha1.ArgoCDExport) {
+		e.Spec.Storage = &argoprojv1alpha1.ArgoCDExportStorageSpec{
+			Backend: common.ArgoCDExportStorageBackendGCP,
+			GCP:     gcp,
+		}
+	}
+}
+
+func makeTestArgoCD() *argoproj.ArgoCD {
+	return &argoproj.ArgoCD{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testArgoCDName,
+			Namespace: testNamespace,
+		},
+	}
+}
+
+func makeTestReconciler(t *testing.T, objs ...runtime.Object) *ArgoCDExportReconciler {
+	s := scheme.Scheme
+	assert.NoError(t, argoproj.AddToScheme(s))
+	assert.NoError(t, argoprojv1alpha1.AddToScheme(s))
+
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build()
+	return &ArgoCDExportReconciler{
+		Client: cl,
+		Scheme: s,
+	}
+}
+
+func envByName(env []corev1.EnvVar) map[string]corev1.EnvVar {
+	m := map[string]corev1.EnvVar{}
+	for _, e := range env {
+		m[e.Name] = e
+	}
+	return m
+}
+
+func TestValidateStorage(t *testing.T) {
+	tests := []struct {
+		name    string
+		export  *argoprojv1alpha1.ArgoCDExport
+		wantErr bool
+	}{
+		{
+			name:   "no storage",
+			export: makeTestExport(),
+		},
+		{
+			name: "azure with credentials secret",
+			export: makeTestExport(withAzure(&argoprojv1alpha1.ArgoCDExportAzureStorageSpec{
+				StorageAccount: "devstoreaccount1",
+				Container:      "backups",
+				Endpoint:       testAzuriteEndpoint,
+			})),
+		},
+		{
+			name:    "azure without options",
+			export:  makeTestExport(withAzure(nil)),
+			wantErr: true,
+		},
+		{
+			name: "azure without container",
+			export: makeTestExport(withAzure(&argoprojv1alpha1.ArgoCDExportAzureStorageSpec{
+				StorageAccount: "devstoreaccount1",
+			})),
+			wantErr: true,
+		},
+		{
+			name: "azure with empty workload identity",
+			export: makeTestExport(withAzure(&argoprojv1alpha1.ArgoCDExportAzureStorageSpec{
+				StorageAccount:   "devstoreaccount1",
+				Container:        "backups",
+				WorkloadIdentity: &argoprojv1alpha1.ArgoCDExportWorkloadIdentitySpec{},
+			})),
+			wantErr: true,
+		},
+		{
+			name: "gcp with workload identity",
+			export: makeTestExport(withGCP(&argoprojv1alpha1.ArgoCDExportGCPStorageSpec{
+				Bucket: "backups",
+				WorkloadIdentity: &argoprojv1alpha1.ArgoCDExportWorkloadIdentitySpec{
+					Identity: "argocd-export@project.iam.gserviceaccount.com",
+				},
+			})),
+		},
+		{
+			name:    "gcp without bucket",
+			export:  makeTestExport(withGCP(&argoprojv1alpha1.ArgoCDExportGCPStorageSpec{})),
+			wantErr: true,
+		},
+		{
+			name: "gcp with relative endpoint",
+			export: makeTestExport(withGCP(&argoprojv1alpha1.ArgoCDExportGCPStorageSpec{
+				Bucket:   "backups",
+				Endpoint: "fake-gcs-server",
+			})),
+			wantErr: true,
+		},
+		{
+			name: "gcp options with azure backend",
+			export: makeTestExport(func(e *argoprojv1alpha1.ArgoCDExport) {
+				e.Spec.Storage = &argoprojv1alpha1.ArgoCDExportStorageSpec{
+					Backend: common.ArgoCDExportStorageBackendAzure,
+					Azure: &argoprojv1alpha1.ArgoCDExportAzureStorageSpec{
+						StorageAccount: "devstoreaccount1",
+						Container:      "backups",
+					},
+					GCP: &argoprojv1alpha1.ArgoCDExportGCPStorageSpec{Bucket: "backups"},
+				}
+			}),
+			wantErr: true,
+		},
+		{
+			name: "unsupported backend",
+			export: makeTestExport(func(e *argoprojv1alpha1.ArgoCDExport) {
+				e.Spec.Storage = &argoprojv1alpha1.ArgoCDExportStorageSpec{Backend: "ftp"}
+			}),
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateStorage(test.export)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateExport_surfacesInvalidStorageInStatus(t *testing.T) {
+	export := makeTestExport(withGCP(&argoprojv1alpha1.ArgoCDExportGCPStorageSpec{}))
+	r := makeTestReconciler(t, export)
+
+	assert.Error(t, r.validateExport(export))
+
+	got := &argoprojv1alpha1.ArgoCDExport{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: testExportName, Namespace: testNamespace}, got))
+	assert.Equal(t, exportPhaseFailed, got.Status.Phase)
+	assert.True(t, meta.IsStatusConditionFalse(got.Status.Conditions, exportConditionStorageValid))
+
+	// fixing the spec resets the export to pending
+	got.Spec.Storage.GCP.Bucket = "backups"
+	assert.NoError(t, r.validateExport(got))
+	assert.Equal(t, exportPhasePending, got.Status.Phase)
+	assert.True(t, meta.IsStatusConditionTrue(got.Status.Conditions, exportConditionStorageValid))
+}
+
+func TestGetArgoExportContainerEnv_azure(t *testing.T) {
+	export := makeTestExport(withAzure(&argoprojv1alpha1.ArgoCDExportAzureStorageSpec{
+		StorageAccount: "devstoreaccount1",
+		Container:      "backups",
+		Prefix:         "team-a",
+		Endpoint:       testAzuriteEndpoint,
+	}))
+
+	env := envByName(getArgoExportContainerEnv(export))
+	assert.Equal(t, "devstoreaccount1", env["AZURE_STORAGE_ACCOUNT"].Value)
+	assert.Equal(t, "backups", env["AZURE_STORAGE_CONTAINER"].Value)
+	assert.Equal(t, "team-a", env["AZURE_STORAGE_PREFIX"].Value)
+	assert.Equal(t, testAzuriteEndpoint, env["AZURE_STORAGE_ENDPOINT"].Value)
+	assert.Equal(t, &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: FetchStorageSecretName(export)},
+		Key:                  common.ArgoCDKeyAzureStorageAccountKey,
+	}, env["AZURE_STORAGE_KEY"].ValueFrom.SecretKeyRef)
+
+	// workload identity replaces the account key and opts the pod in
+	export.Spec.Storage.Azure.WorkloadIdentity = &argoprojv1alpha1.ArgoCDExportWorkloadIdentitySpec{Identity: "client-id"}
+	env = envByName(getArgoExportContainerEnv(export))
+	assert.NotContains(t, env, "AZURE_STORAGE_KEY")
+
+	template := newPodTemplateSpec(export, testArgoCDName, nil)
+	assert.Equal(t, "true", template.Labels[common.AzureWorkloadIdentityKeyUse])
+	assert.Equal(t, getWorkloadIdentityServiceAccountName(export), template.Spec.ServiceAccountName)
+}
+
+func TestGetArgoExportContainerEnv_gcp(t *testing.T) {
+	export := makeTestExport(withGCP(&argoprojv1alpha1.ArgoCDExportGCPStorageSpec{
+		Bucket:                "backups",
+		Endpoint:              testFakeGCSEndpoint,
+		CredentialsSecretName: "gcs-credentials",
+	}))
+
+	env := envByName(getArgoExportContainerEnv(export))
+	assert.Equal(t, "backups", env["GCS_BUCKET"].Value)
+	assert.Equal(t, testFakeGCSEndpoint, env["STORAGE_EMULATOR_HOST"].Value)
+	assert.Equal(t, "/credentials/gcp.credentials.json", env["GOOGLE_APPLICATION_CREDENTIALS"].Value)
+
+	spec := newExportPodSpec(export, testArgoCDName, nil)
+	assert.Equal(t, "argocd-argocd-application-controller", spec.ServiceAccountName)
+	assert.Len(t, spec.Volumes, 3)
+	assert.Equal(t, "gcs-credentials", spec.Volumes[2].Secret.SecretName)
+	assert.Contains(t, spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      credentialsVolumeName,
+		MountPath: credentialsMountPath,
+		ReadOnly:  true,
+	})
+
+	// workload identity does not mount a key file
+	export.Spec.Storage.GCP.WorkloadIdentity = &argoprojv1alpha1.ArgoCDExportWorkloadIdentitySpec{Identity: "sa@project.iam.gserviceaccount.com"}
+	env = envByName(getArgoExportContainerEnv(export))
+	assert.NotContains(t, env, "GOOGLE_APPLICATION_CREDENTIALS")
+	assert.Len(t, newExportPodSpec(export, testArgoCDName, nil).Volumes, 2)
+}
+
+func TestReconcileWorkloadIdentity(t *testing.T) {
+	export := makeTestExport(withGCP(&argoprojv1alpha1.ArgoCDExportGCPStorageSpec{
+		Bucket: "backups",
+		WorkloadIdentity: &argoprojv1alpha1.ArgoCDExportWorkloadIdentitySpec{
+			Identity: "sa@project.iam.gserviceaccount.com",
+		},
+	}))
+	r := makeTestReconciler(t, export, makeTestArgoCD())
+	name := getWorkloadIdentityServiceAccountName(export)
+
+	assert.NoError(t, r.reconcileWorkloadIdentity(export))
+
+	sa := &corev1.ServiceAccount{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, sa))
+	assert.Equal(t, "sa@project.iam.gserviceaccount.com", sa.Annotations[common.GKEWorkloadIdentityKeyServiceAccount])
+
+	rb := &rbacv1.RoleBinding{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, rb))
+	assert.Equal(t, "argocd-argocd-application-controller", rb.RoleRef.Name)
+
+	// annotations added by others are kept when the identity changes
+	sa.Annotations["example.com/owner"] = "platform"
+	assert.NoError(t, r.Client.Update(context.TODO(), sa))
+	export.Spec.Storage.GCP.WorkloadIdentity.Identity = "other@project.iam.gserviceaccount.com"
+	assert.NoError(t, r.reconcileWorkloadIdentity(export))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, sa))
+	assert.Equal(t, map[string]string{
+		common.GKEWorkloadIdentityKeyServiceAccount: "other@project.iam.gserviceaccount.com",
+		"example.com/owner":                         "platform",
+	}, sa.Annotations)
+
+	// switching to a credentials Secret removes the ServiceAccount and RoleBinding
+	export.Spec.Storage.GCP.WorkloadIdentity = nil
+	assert.NoError(t, r.reconcileWorkloadIdentity(export))
+
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, sa)
+	assert.True(t, errors.IsNotFound(err))
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, rb)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReconcileWorkloadIdentity_notControlled(t *testing.T) {
+	export := makeTestExport(withGCP(&argoprojv1alpha1.ArgoCDExportGCPStorageSpec{
+		Bucket: "backups",
+		WorkloadIdentity: &argoprojv1alpha1.ArgoCDExportWorkloadIdentitySpec{
+			Identity: "sa@project.iam.gserviceaccount.com",
+		},
+	}))
+	name := getWorkloadIdentityServiceAccountName(export)
+	existing := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   testNamespace,
+			Annotations: map[string]string{common.GKEWorkloadIdentityKeyServiceAccount: "user@project.iam.gserviceaccount.com"},
+		},
+	}
+	r := makeTestReconciler(t, export, makeTestArgoCD(), existing)
+
+	assert.EqualError(t, r.reconcileWorkloadIdentity(export),
+		"serviceaccount test-export-export already exists and is not controlled by ArgoCDExport test-export")
+
+	sa := &corev1.ServiceAccount{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, sa))
+	assert.Equal(t, existing.Annotations, sa.Annotations)
+
+	// nor is it deleted once workload identity is no longer used
+	export.Spec.Storage.GCP.WorkloadIdentity = nil
+	assert.NoError(t, r.reconcileWorkloadIdentity(export))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, sa))
+}
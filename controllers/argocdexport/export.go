@@ -16,8 +16,11 @@ package argocdexport
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/sethvargo/go-password/password"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
@@ -26,6 +29,56 @@ import (
 	util "github.com/argoproj-labs/argocd-operator/pkg/util"
 )
 
+const (
+	// exportPhasePending is the phase of an ArgoCDExport whose resources have not all been created yet.
+	exportPhasePending = "Pending"
+
+	// exportPhaseFailed is the phase of an ArgoCDExport that cannot be processed.
+	exportPhaseFailed = "Failed"
+
+	// exportConditionStorageValid is the condition reporting whether the storage options are valid.
+	exportConditionStorageValid = "StorageValid"
+
+	// exportReasonValidStorage is the reason used when the storage options are valid.
+	exportReasonValidStorage = "ValidStorage"
+
+	// exportReasonInvalidStorage is the reason used when the storage options are invalid.
+	exportReasonInvalidStorage = "InvalidStorage"
+
+	// exportConditionFilterValid is the condition reporting whether the filter options are valid.
+	exportConditionFilterValid = "FilterValid"
+
+	// exportReasonValidFilter is the reason used when the filter options are valid.
+	exportReasonValidFilter = "ValidFilter"
+
+	// exportReasonInvalidFilter is the reason used when the filter options are invalid.
+	exportReasonInvalidFilter = "InvalidFilter"
+)
+
+// exportValidation pairs a validation of the ArgoCDExport with the condition reporting its outcome.
+type exportValidation struct {
+	condition     string
+	validReason   string
+	invalidReason string
+	validate      func(cr *argoprojv1alpha1.ArgoCDExport) error
+}
+
+// exportValidations are the validations performed on every ArgoCDExport before its resources are reconciled.
+var exportValidations = []exportValidation{
+	{
+		condition:     exportConditionStorageValid,
+		validReason:   exportReasonValidStorage,
+		invalidReason: exportReasonInvalidStorage,
+		validate:      validateStorage,
+	},
+	{
+		condition:     exportConditionFilterValid,
+		validReason:   exportReasonValidFilter,
+		invalidReason: exportReasonInvalidFilter,
+		validate:      validateFilter,
+	},
+}
+
 // generateBackupKey will generate and return the backup key for the export process.
 func generateBackupKey() ([]byte, error) {
 	pass, err := password.Generate(
@@ -106,8 +159,37 @@ func (r *ArgoCDExportReconciler) reconcileExportSecret(cr *argoprojv1alpha1.Argo
 
 // validateExport will ensure that the given ArgoCDExport is valid.
 func (r *ArgoCDExportReconciler) validateExport(cr *argoprojv1alpha1.ArgoCDExport) error {
-	if len(cr.Status.Phase) <= 0 {
-		cr.Status.Phase = "Pending"
+	changed := len(cr.Status.Phase) <= 0
+	for _, v := range exportValidations {
+		if err := v.validate(cr); err != nil {
+			log.Error(err, fmt.Sprintf("invalid configuration for ArgoCDExport %s in namespace %s", cr.Name, cr.Namespace))
+			cr.Status.Phase = exportPhaseFailed
+			meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+				Type:    v.condition,
+				Status:  metav1.ConditionFalse,
+				Reason:  v.invalidReason,
+				Message: err.Error(),
+			})
+			if updateErr := r.Client.Status().Update(context.TODO(), cr); updateErr != nil {
+				return updateErr
+			}
+			return err
+		}
+
+		if !meta.IsStatusConditionTrue(cr.Status.Conditions, v.condition) {
+			changed = true
+			meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+				Type:   v.condition,
+				Status: metav1.ConditionTrue,
+				Reason: v.validReason,
+			})
+		}
+	}
+
+	if changed {
+		if cr.Status.Phase == exportPhaseFailed || len(cr.Status.Phase) <= 0 {
+			cr.Status.Phase = exportPhasePending
+		}
 		return r.Client.Status().Update(context.TODO(), cr)
 	}
 	return nil
@@ -0,0 +1,110 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdexport
+
+import (
+	"fmt"
+	"strings"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// exportKinds is the set of kinds that can be selected for export.
+var exportKinds = map[string]bool{
+	common.ArgoCDExportKindApplications:    true,
+	common.ArgoCDExportKindApplicationSets: true,
+	common.ArgoCDExportKindClusters:        true,
+	common.ArgoCDExportKindRepositories:    true,
+	common.ArgoCDExportKindSettings:        true,
+}
+
+// kindsToStrings converts the given export kinds to their string values.
+func kindsToStrings(kinds []argoprojv1alpha1.ArgoCDExportKind) []string {
+	values := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		values = append(values, string(kind))
+	}
+	return values
+}
+
+// appendFilterArg appends the given flag and its comma separated values to the command, if there are any values.
+func appendFilterArg(cmd []string, flag string, values []string) []string {
+	if len(values) <= 0 {
+		return cmd
+	}
+	return append(cmd, flag, strings.Join(values, ","))
+}
+
+// getArgoExportFilterArgs will return the export command arguments for the filter of the given ArgoCDExport.
+func getArgoExportFilterArgs(cr *argoprojv1alpha1.ArgoCDExport) []string {
+	args := make([]string, 0)
+	filter := cr.Spec.Filter
+	if filter == nil {
+		return args
+	}
+
+	args = appendFilterArg(args, "--include-projects", filter.IncludeProjects)
+	args = appendFilterArg(args, "--exclude-projects", filter.ExcludeProjects)
+	args = appendFilterArg(args, "--include-namespaces", filter.IncludeNamespaces)
+	args = appendFilterArg(args, "--exclude-namespaces", filter.ExcludeNamespaces)
+	args = appendFilterArg(args, "--include-kinds", kindsToStrings(filter.IncludeKinds))
+	args = appendFilterArg(args, "--exclude-kinds", kindsToStrings(filter.ExcludeKinds))
+	return args
+}
+
+// validateFilterValues ensures that the given include and exclude lists have no empty or overlapping entries.
+func validateFilterValues(field string, include, exclude []string) error {
+	included := make(map[string]bool)
+	for _, value := range include {
+		if len(strings.TrimSpace(value)) <= 0 || strings.Contains(value, ",") {
+			return fmt.Errorf(".spec.filter.include%s contains an invalid entry %q", field, value)
+		}
+		included[value] = true
+	}
+
+	for _, value := range exclude {
+		if len(strings.TrimSpace(value)) <= 0 || strings.Contains(value, ",") {
+			return fmt.Errorf(".spec.filter.exclude%s contains an invalid entry %q", field, value)
+		}
+		if included[value] {
+			return fmt.Errorf("%q is both included and excluded by .spec.filter", value)
+		}
+	}
+	return nil
+}
+
+// validateFilter will ensure that the filter options of the given ArgoCDExport are valid.
+func validateFilter(cr *argoprojv1alpha1.ArgoCDExport) error {
+	filter := cr.Spec.Filter
+	if filter == nil {
+		return nil
+	}
+
+	if err := validateFilterValues("Projects", filter.IncludeProjects, filter.ExcludeProjects); err != nil {
+		return err
+	}
+	if err := validateFilterValues("Namespaces", filter.IncludeNamespaces, filter.ExcludeNamespaces); err != nil {
+		return err
+	}
+
+	kinds := append(kindsToStrings(filter.IncludeKinds), kindsToStrings(filter.ExcludeKinds)...)
+	for _, kind := range kinds {
+		if !exportKinds[kind] {
+			return fmt.Errorf("unsupported export kind %q, must be one of applications, applicationsets, repositories, clusters or settings", kind)
+		}
+	}
+	return validateFilterValues("Kinds", kindsToStrings(filter.IncludeKinds), kindsToStrings(filter.ExcludeKinds))
+}
@@ -0,0 +1,138 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdexport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func withFilter(filter *argoprojv1alpha1.ArgoCDExportFilterSpec) exportOpt {
+	return func(e *argoprojv1alpha1.ArgoCDExport) {
+		e.Spec.Filter = filter
+	}
+}
+
+func withLocalStorage() exportOpt {
+	return func(e *argoprojv1alpha1.ArgoCDExport) {
+		e.Spec.Storage = &argoprojv1alpha1.ArgoCDExportStorageSpec{
+			Backend: common.ArgoCDExportStorageBackendLocal,
+		}
+	}
+}
+
+func TestGetArgoExportCommand_filter(t *testing.T) {
+	export := makeTestExport(withLocalStorage())
+	assert.Equal(t, []string{"uid_entrypoint.sh", "argocd-operator-util", "export", "local"}, getArgoExportCommand(export))
+
+	export = makeTestExport(withLocalStorage(), withFilter(&argoprojv1alpha1.ArgoCDExportFilterSpec{
+		IncludeProjects:   []string{"team-a", "team-b"},
+		ExcludeNamespaces: []string{"sandbox"},
+		ExcludeKinds:      []argoprojv1alpha1.ArgoCDExportKind{common.ArgoCDExportKindSettings, common.ArgoCDExportKindClusters},
+	}))
+	assert.Equal(t, []string{
+		"uid_entrypoint.sh", "argocd-operator-util", "export", "local",
+		"--include-projects", "team-a,team-b",
+		"--exclude-namespaces", "sandbox",
+		"--exclude-kinds", "settings,clusters",
+	}, getArgoExportCommand(export))
+}
+
+func TestValidateFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  *argoprojv1alpha1.ArgoCDExportFilterSpec
+		wantErr bool
+	}{
+		{
+			name: "no filter",
+		},
+		{
+			name: "valid filter",
+			filter: &argoprojv1alpha1.ArgoCDExportFilterSpec{
+				IncludeProjects:   []string{"team-a"},
+				IncludeNamespaces: []string{"team-a-apps"},
+				IncludeKinds:      []argoprojv1alpha1.ArgoCDExportKind{common.ArgoCDExportKindApplications},
+			},
+		},
+		{
+			name: "unsupported kind",
+			filter: &argoprojv1alpha1.ArgoCDExportFilterSpec{
+				ExcludeKinds: []argoprojv1alpha1.ArgoCDExportKind{"secrets"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "project both included and excluded",
+			filter: &argoprojv1alpha1.ArgoCDExportFilterSpec{
+				IncludeProjects: []string{"team-a"},
+				ExcludeProjects: []string{"team-a"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty namespace",
+			filter: &argoprojv1alpha1.ArgoCDExportFilterSpec{
+				ExcludeNamespaces: []string{""},
+			},
+			wantErr: true,
+		},
+		{
+			name: "comma in project",
+			filter: &argoprojv1alpha1.ArgoCDExportFilterSpec{
+				IncludeProjects: []string{"team-a,team-b"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateFilter(makeTestExport(withFilter(test.filter)))
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateExport_surfacesInvalidFilterInStatus(t *testing.T) {
+	export := makeTestExport(withLocalStorage(), withFilter(&argoprojv1alpha1.ArgoCDExportFilterSpec{
+		IncludeKinds: []argoprojv1alpha1.ArgoCDExportKind{"secrets"},
+	}))
+	r := makeTestReconciler(t, export)
+
+	assert.Error(t, r.validateExport(export))
+
+	got := &argoprojv1alpha1.ArgoCDExport{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: testExportName, Namespace: testNamespace}, got))
+	assert.Equal(t, exportPhaseFailed, got.Status.Phase)
+	assert.True(t, meta.IsStatusConditionTrue(got.Status.Conditions, exportConditionStorageValid))
+	assert.True(t, meta.IsStatusConditionFalse(got.Status.Conditions, exportConditionFilterValid))
+
+	got.Spec.Filter.IncludeKinds = []argoprojv1alpha1.ArgoCDExportKind{common.ArgoCDExportKindApplications}
+	assert.NoError(t, r.validateExport(got))
+	assert.Equal(t, exportPhasePending, got.Status.Phase)
+	assert.True(t, meta.IsStatusConditionTrue(got.Status.Conditions, exportConditionFilterValid))
+}
@@ -17,6 +17,7 @@ package argocdexport
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -39,13 +40,14 @@ func getArgoExportCommand(cr *argoprojv1alpha1.ArgoCDExport) []string {
 	cmd = append(cmd, "argocd-operator-util")
 	cmd = append(cmd, "export")
 	cmd = append(cmd, cr.Spec.Storage.Backend)
+	cmd = append(cmd, getArgoExportFilterArgs(cr)...)
 	return cmd
 }
 
 func getArgoExportContainerEnv(cr *argoprojv1alpha1.ArgoCDExport) []corev1.EnvVar {
 	env := make([]corev1.EnvVar, 0)
 
-	switch cr.Spec.Storage.Backend {
+	switch storageBackend(cr) {
 	case common.ArgoCDExportStorageBackendAWS:
 		env = append(env, corev1.EnvVar{
 			Name: "AWS_ACCESS_KEY_ID",
@@ -70,6 +72,10 @@ func getArgoExportContainerEnv(cr *argoprojv1alpha1.ArgoCDExport) []corev1.EnvVa
 				},
 			},
 		})
+	case common.ArgoCDExportStorageBackendAzure:
+		env = append(env, AzureStorageEnv(cr)...)
+	case common.ArgoCDExportStorageBackendGCP:
+		env = append(env, GCPStorageEnv(cr)...)
 	}
 
 	return env
@@ -91,7 +97,7 @@ func getArgoExportContainerImage(cr *argoprojv1alpha1.ArgoCDExport) string {
 }
 
 // getArgoExportVolumeMounts will return the VolumneMounts for the given ArgoCDExport.
-func getArgoExportVolumeMounts() []corev1.VolumeMount {
+func getArgoExportVolumeMounts(cr *argoprojv1alpha1.ArgoCDExport) []corev1.VolumeMount {
 	mounts := make([]corev1.VolumeMount, 0)
 
 	mounts = append(mounts, corev1.VolumeMount{
@@ -104,6 +110,10 @@ func getArgoExportVolumeMounts() []corev1.VolumeMount {
 		MountPath: "/secrets",
 	})
 
+	if _, mount := CredentialsVolume(cr); mount != nil {
+		mounts = append(mounts, *mount)
+	}
+
 	return mounts
 }
 
@@ -174,6 +184,8 @@ func newExportPodSpec(cr *argoprojv1alpha1.ArgoCDExport, argocdName string, clie
 		Image:           getArgoExportContainerImage(cr),
 		ImagePullPolicy: corev1.PullAlways,
 		Name:            "argocd-export",
+		// Surface the tail of the log in Events when the export fails without writing a termination message.
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		SecurityContext: &corev1.SecurityContext{
 			AllowPrivilegeEscalation: util.BoolPtr(false),
 			Capabilities: &corev1.Capabilities{
@@ -183,15 +195,21 @@ func newExportPodSpec(cr *argoprojv1alpha1.ArgoCDExport, argocdName string, clie
 			},
 			RunAsNonRoot: util.BoolPtr(true),
 		},
-		VolumeMounts: getArgoExportVolumeMounts(),
+		VolumeMounts: getArgoExportVolumeMounts(cr),
 	}}
 
 	pod.RestartPolicy = corev1.RestartPolicyOnFailure
 	pod.ServiceAccountName = fmt.Sprintf("%s-%s", argocdName, "argocd-application-controller")
+	if getWorkloadIdentity(cr) != nil {
+		pod.ServiceAccountName = getWorkloadIdentityServiceAccountName(cr)
+	}
 	pod.Volumes = []corev1.Volume{
 		getArgoStorageVolume("backup-storage", cr),
 		getArgoSecretVolume("secret-storage", cr),
 	}
+	if volume, _ := CredentialsVolume(cr); volume != nil {
+		pod.Volumes = append(pod.Volumes, *volume)
+	}
 
 	// Configure runAsUser, runAsGroup and fsGroup so that the job can write to the PV
 	// 999 is the uid/gid of the argocd user that the container runs as
@@ -210,11 +228,18 @@ func newExportPodSpec(cr *argoprojv1alpha1.ArgoCDExport, argocdName string, clie
 }
 
 func newPodTemplateSpec(cr *argoprojv1alpha1.ArgoCDExport, argocdName string, client client.Client) corev1.PodTemplateSpec {
+	labels := common.DefaultLabels(cr.Name, cr.Name, "")
+
+	// Azure AD Workload Identity only injects the federated token into Pods that opt in.
+	if storageBackend(cr) == common.ArgoCDExportStorageBackendAzure && getWorkloadIdentity(cr) != nil {
+		labels[common.AzureWorkloadIdentityKeyUse] = "true"
+	}
+
 	return corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cr.Name,
 			Namespace: cr.Namespace,
-			Labels:    common.DefaultLabels(cr.Name, cr.Name, ""),
+			Labels:    labels,
 		},
 		Spec: newExportPodSpec(cr, argocdName, client),
 	}
@@ -228,11 +253,21 @@ func (r *ArgoCDExportReconciler) reconcileCronJob(cr *argoprojv1alpha1.ArgoCDExp
 
 	cj := newCronJob(cr)
 	if util.IsObjectFound(r.Client, cr.Namespace, cj.Name, cj) {
+		changed := false
 		if *cr.Spec.Schedule != cj.Spec.Schedule {
 			cj.Spec.Schedule = *cr.Spec.Schedule
+			changed = true
+		}
+		// Keep the export command, which carries the filter options, in sync for the next scheduled run.
+		containers := cj.Spec.JobTemplate.Spec.Template.Spec.Containers
+		if cmd := getArgoExportCommand(cr); len(containers) > 0 && !reflect.DeepEqual(containers[0].Command, cmd) {
+			containers[0].Command = cmd
+			changed = true
+		}
+		if changed {
 			return r.Client.Update(context.TODO(), cj)
 		}
-		return nil
+		return r.reconcileCronJobStatus(cr, cj)
 	}
 
 	cj.Spec.Schedule = *cr.Spec.Schedule
@@ -247,6 +282,7 @@ func (r *ArgoCDExportReconciler) reconcileCronJob(cr *argoprojv1alpha1.ArgoCDExp
 	job := newJob(cr)
 	job.Spec.Template = newPodTemplateSpec(cr, argocdName, r.Client)
 
+	cj.Spec.JobTemplate.ObjectMeta.Labels = job.Labels
 	cj.Spec.JobTemplate.Spec = job.Spec
 
 	if err := controllerutil.SetControllerReference(cr, cj, r.Scheme); err != nil {
@@ -263,6 +299,9 @@ func (r *ArgoCDExportReconciler) reconcileJob(cr *argoprojv1alpha1.ArgoCDExport)
 
 	job := newJob(cr)
 	if util.IsObjectFound(r.Client, cr.Namespace, job.Name, job) {
+		if err := r.reconcileJobStatus(cr, job); err != nil {
+			return err
+		}
 		if job.Status.Succeeded > 0 && cr.Status.Phase != common.ArgoCDStatusCompleted {
 			// Mark status Phase as Complete
 			cr.Status.Phase = common.ArgoCDStatusCompleted
@@ -0,0 +1,66 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdexport
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// ExportLastSuccessTimestamp is a prometheus metric which keeps track of the
+	// completion time of the last successful export for a given ArgoCDExport
+	ExportLastSuccessTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "argocd_export_last_success_timestamp_seconds",
+			Help: "Unix time of the last successful export",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// ExportDuration is a prometheus metric which keeps track of the
+	// duration of the last successful export for a given ArgoCDExport
+	ExportDuration = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "argocd_export_duration_seconds",
+			Help: "Duration of the last successful export",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// ExportSize is a prometheus metric which keeps track of the
+	// size reported by the last successful export for a given ArgoCDExport
+	ExportSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "argocd_export_size_bytes",
+			Help: "Size of the data written by the last successful export",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// ExportFailures is a prometheus metric which keeps track of the
+	// number of failed export Jobs for a given ArgoCDExport
+	ExportFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_export_failures_total",
+			Help: "Number of failed export Jobs",
+		},
+		[]string{"namespace", "name"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(ExportLastSuccessTimestamp, ExportDuration, ExportSize, ExportFailures)
+}
@@ -0,0 +1,134 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdexport
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/pkg/monitoring"
+	util "github.com/argoproj-labs/argocd-operator/pkg/util"
+)
+
+// getPrometheusRuleName returns the name of the PrometheusRule for the given ArgoCDExport.
+func getPrometheusRuleName(cr *argoprojv1alpha1.ArgoCDExport) string {
+	return util.NameWithSuffix(cr.Name, "export-alert")
+}
+
+// getExportMaxAge returns how long after the last successful export the stale export alert fires.
+func getExportMaxAge(cr *argoprojv1alpha1.ArgoCDExport) time.Duration {
+	if cr.Spec.Monitoring.MaxAge != nil && cr.Spec.Monitoring.MaxAge.Duration > 0 {
+		return cr.Spec.Monitoring.MaxAge.Duration
+	}
+	return common.ArgoCDDefaultExportMaxAge
+}
+
+// getPrometheusRuleSpec returns the alerting rules for failed or stale exports of the given ArgoCDExport.
+func getPrometheusRuleSpec(cr *argoprojv1alpha1.ArgoCDExport) monitoringv1.PrometheusRuleSpec {
+	selector := fmt.Sprintf("namespace=\"%s\", name=\"%s\"", cr.Namespace, cr.Name)
+
+	return monitoringv1.PrometheusRuleSpec{
+		Groups: []monitoringv1.RuleGroup{
+			{
+				Name: "ArgoCDExportStatus",
+				Rules: []monitoringv1.Rule{
+					{
+						Alert: "ArgoCDExportFailed",
+						Annotations: map[string]string{
+							"message": fmt.Sprintf("export job for ArgoCDExport %s in namespace %s has failed", cr.Name, cr.Namespace),
+						},
+						Expr: intstr.IntOrString{
+							Type:   intstr.String,
+							StrVal: fmt.Sprintf("increase(argocd_export_failures_total{%s}[1h]) > 0", selector),
+						},
+						Labels: map[string]string{
+							"severity": "warning",
+						},
+					},
+					{
+						Alert: "ArgoCDExportStale",
+						Annotations: map[string]string{
+							"message": fmt.Sprintf("ArgoCDExport %s in namespace %s has not completed successfully in the last %s", cr.Name, cr.Namespace, getExportMaxAge(cr)),
+						},
+						Expr: intstr.IntOrString{
+							Type:   intstr.String,
+							StrVal: fmt.Sprintf("time() - argocd_export_last_success_timestamp_seconds{%s} > %d", selector, int64(getExportMaxAge(cr).Seconds())),
+						},
+						For: "5m",
+						Labels: map[string]string{
+							"severity": "critical",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// reconcilePrometheusRule will ensure that the PrometheusRule alerting on failed or stale exports is present when monitoring is enabled.
+func (r *ArgoCDExportReconciler) reconcilePrometheusRule(cr *argoprojv1alpha1.ArgoCDExport) error {
+	if !monitoring.IsPrometheusAPIAvailable() {
+		return nil // Prometheus Operator not installed, nothing to do
+	}
+
+	name := getPrometheusRuleName(cr)
+	existing, err := monitoring.GetPrometheusRule(name, cr.Namespace, r.Client)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	if !cr.Spec.Monitoring.Enabled {
+		if existing == nil {
+			return nil
+		}
+		log.Info(fmt.Sprintf("export monitoring disabled, deleting prometheusrule: %s", name))
+		return monitoring.DeletePrometheusRule(name, cr.Namespace, r.Client)
+	}
+
+	desired, err := monitoring.RequestPrometheusRule(monitoring.PrometheusRuleRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels:    common.DefaultLabels(name, cr.Name, ""),
+		},
+		Spec: getPrometheusRuleSpec(cr),
+	})
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		if reflect.DeepEqual(existing.Spec, desired.Spec) {
+			return nil
+		}
+		existing.Spec = desired.Spec
+		return monitoring.UpdatePrometheusRule(existing, r.Client)
+	}
+
+	if err := controllerutil.SetControllerReference(cr, desired, r.Scheme); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("export monitoring enabled, creating prometheusrule: %s", name))
+	return monitoring.CreatePrometheusRule(desired, r.Client)
+}
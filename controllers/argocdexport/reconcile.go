@@ -15,11 +15,15 @@
 package argocdexport
 
 import (
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/pkg/monitoring"
 )
 
 // ArgoCDReconcilerExportResources will reconcile all ArgoCDExport resources for the give CR.
@@ -35,6 +39,10 @@ func (r *ArgoCDExportReconciler) reconcileArgoCDExportResources(cr *argoprojv1al
 	if err := r.reconcileExport(cr); err != nil {
 		return err
 	}
+
+	if err := r.reconcilePrometheusRule(cr); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -49,11 +57,19 @@ func setResourceWatches(bld *builder.Builder) *builder.Builder {
 	// Watch for changes to Job sub-resources owned by ArgoCD instances.
 	bld.Owns(&batchv1.Job{})
 
+	// Watch for changes to Job sub-resources spawned by CronJobs owned by ArgoCDExport instances.
+	bld.Watches(&source.Kind{Type: &batchv1.Job{}}, handler.EnqueueRequestsFromMapFunc(cronJobExportMapper))
+
 	// Watch for changes to PersistentVolumeClaim sub-resources owned by ArgoCD instances.
 	bld.Owns(&corev1.PersistentVolumeClaim{})
 
 	// Watch for changes to Secret sub-resources owned by ArgoCD instances.
 	bld.Owns(&corev1.Secret{})
 
+	if monitoring.IsPrometheusAPIAvailable() {
+		// Watch for changes to PrometheusRule sub-resources owned by ArgoCDExport instances.
+		bld.Owns(&monitoringv1.PrometheusRule{})
+	}
+
 	return bld
 }
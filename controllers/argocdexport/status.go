@@ -0,0 +1,194 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdexport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	util "github.com/argoproj-labs/argocd-operator/pkg/util"
+)
+
+const (
+	jobPhaseRunning   = "Running"
+	jobPhaseSucceeded = "Succeeded"
+	jobPhaseFailed    = "Failed"
+)
+
+// exportTerminationMessage is the optional JSON document the export container writes as its termination message.
+type exportTerminationMessage struct {
+	// Size is the size in bytes of the data written to the storage backend.
+	Size *int64 `json:"size,omitempty"`
+}
+
+// getJobPhase returns the phase of the given export Job, or an empty string if it has not started yet.
+func getJobPhase(job *batchv1.Job) string {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return jobPhaseSucceeded
+		case batchv1.JobFailed:
+			return jobPhaseFailed
+		}
+	}
+
+	if job.Status.Succeeded > 0 {
+		return jobPhaseSucceeded
+	}
+	if job.Status.StartTime != nil {
+		return jobPhaseRunning
+	}
+	return ""
+}
+
+// getJobTerminationMessage returns the termination message of the most recently terminated export container of the given Job.
+func (r *ArgoCDExportReconciler) getJobTerminationMessage(job *batchv1.Job) string {
+	pods := &corev1.PodList{}
+	if err := r.Client.List(context.TODO(), pods, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name}); err != nil {
+		log.Error(err, fmt.Sprintf("unable to list pods for export job %s", job.Name))
+		return ""
+	}
+
+	message := ""
+	var finishedAt metav1.Time
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			for _, state := range []corev1.ContainerState{status.State, status.LastTerminationState} {
+				if state.Terminated == nil || state.Terminated.FinishedAt.Before(&finishedAt) {
+					continue
+				}
+				finishedAt = state.Terminated.FinishedAt
+				message = strings.TrimSpace(state.Terminated.Message)
+			}
+		}
+	}
+	return message
+}
+
+// recordExportSuccess updates the export metrics for the given successful Job.
+func recordExportSuccess(cr *argoprojv1alpha1.ArgoCDExport, job *batchv1.Job, message string) {
+	if job.Status.CompletionTime != nil {
+		ExportLastSuccessTimestamp.WithLabelValues(cr.Namespace, cr.Name).Set(float64(job.Status.CompletionTime.Unix()))
+		if job.Status.StartTime != nil {
+			ExportDuration.WithLabelValues(cr.Namespace, cr.Name).Set(job.Status.CompletionTime.Sub(job.Status.StartTime.Time).Seconds())
+		}
+	}
+
+	result := exportTerminationMessage{}
+	if err := json.Unmarshal([]byte(message), &result); err == nil && result.Size != nil {
+		ExportSize.WithLabelValues(cr.Namespace, cr.Name).Set(float64(*result.Size))
+	}
+}
+
+// deleteExportMetrics removes the metric series of the ArgoCDExport with the given name.
+func deleteExportMetrics(namespace, name string) {
+	ExportLastSuccessTimestamp.DeleteLabelValues(namespace, name)
+	ExportDuration.DeleteLabelValues(namespace, name)
+	ExportSize.DeleteLabelValues(namespace, name)
+	ExportFailures.DeleteLabelValues(namespace, name)
+}
+
+// withTerminationMessage appends the container termination message, if any, to the given Event message.
+func withTerminationMessage(message, terminationMessage string) string {
+	if len(terminationMessage) <= 0 {
+		return message
+	}
+	return fmt.Sprintf("%s Termination message: %s", message, terminationMessage)
+}
+
+// reconcileJobStatus will emit Events and record metrics for phase transitions of the given export Job.
+func (r *ArgoCDExportReconciler) reconcileJobStatus(cr *argoprojv1alpha1.ArgoCDExport, job *batchv1.Job) error {
+	phase := getJobPhase(job)
+	observed := job.Annotations[common.ArgoCDExportKeyObservedPhase]
+	if len(phase) <= 0 || phase == observed {
+		return nil // Nothing new to report
+	}
+
+	// The involved object is fetched from the cache, which does not populate TypeMeta.
+	typeMeta := metav1.TypeMeta{Kind: "ArgoCDExport", APIVersion: argoprojv1alpha1.GroupVersion.String()}
+
+	if len(observed) <= 0 {
+		message := fmt.Sprintf("Started export job %s.", job.Name)
+		if err := util.CreateEvent(r.Client, corev1.EventTypeNormal, "Exporting", message, "ExportJobStarted", cr.ObjectMeta, typeMeta); err != nil {
+			return err
+		}
+	}
+
+	switch phase {
+	case jobPhaseSucceeded:
+		terminationMessage := r.getJobTerminationMessage(job)
+		recordExportSuccess(cr, job, terminationMessage)
+		message := withTerminationMessage(fmt.Sprintf("Export job %s completed successfully.", job.Name), terminationMessage)
+		if err := util.CreateEvent(r.Client, corev1.EventTypeNormal, "Exporting", message, "ExportJobSucceeded", cr.ObjectMeta, typeMeta); err != nil {
+			return err
+		}
+	case jobPhaseFailed:
+		ExportFailures.WithLabelValues(cr.Namespace, cr.Name).Inc()
+		message := withTerminationMessage(fmt.Sprintf("Export job %s failed.", job.Name), r.getJobTerminationMessage(job))
+		if err := util.CreateEvent(r.Client, corev1.EventTypeWarning, "Exporting", message, "ExportJobFailed", cr.ObjectMeta, typeMeta); err != nil {
+			return err
+		}
+	}
+
+	// Remember what has been reported so that Events and counters are not repeated on the next reconcile.
+	if job.Annotations == nil {
+		job.Annotations = map[string]string{}
+	}
+	job.Annotations[common.ArgoCDExportKeyObservedPhase] = phase
+	return r.Client.Update(context.TODO(), job)
+}
+
+// reconcileCronJobStatus will report on the Jobs spawned by the CronJob of the given ArgoCDExport.
+func (r *ArgoCDExportReconciler) reconcileCronJobStatus(cr *argoprojv1alpha1.ArgoCDExport, cj *batchv1.CronJob) error {
+	jobs := &batchv1.JobList{}
+	if err := r.Client.List(context.TODO(), jobs, client.InNamespace(cr.Namespace)); err != nil {
+		return err
+	}
+
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if !metav1.IsControlledBy(job, cj) {
+			continue
+		}
+		if err := r.reconcileJobStatus(cr, job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cronJobExportMapper maps Jobs spawned by a CronJob to the ArgoCDExport that owns the CronJob, which shares its name.
+func cronJobExportMapper(o client.Object) []reconcile.Request {
+	owner := metav1.GetControllerOf(o)
+	if owner == nil || owner.Kind != "CronJob" {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: client.ObjectKey{Namespace: o.GetNamespace(), Name: owner.Name},
+	}}
+}
@@ -0,0 +1,152 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdexport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	util "github.com/argoproj-labs/argocd-operator/pkg/util"
+)
+
+func makeTestJob(status batchv1.JobStatus) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testExportName,
+			Namespace: testNamespace,
+		},
+		Status: status,
+	}
+}
+
+func makeTestExportPod(message string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testExportName + "-abcde",
+			Namespace: testNamespace,
+			Labels:    map[string]string{"job-name": testExportName},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "argocd-export",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message:    message,
+						FinishedAt: metav1.Now(),
+					},
+				},
+			}},
+		},
+	}
+}
+
+func listEventReasons(t *testing.T, c client.Client) []string {
+	events := &corev1.EventList{}
+	assert.NoError(t, c.List(context.TODO(), events, client.InNamespace(testNamespace)))
+	reasons := []string{}
+	for _, e := range events.Items {
+		reasons = append(reasons, e.Reason)
+	}
+	return reasons
+}
+
+func TestReconcileJobStatus_succeeded(t *testing.T) {
+	start := metav1.NewTime(time.Unix(1000, 0))
+	end := metav1.NewTime(time.Unix(1030, 0))
+	export := makeTestExport()
+	job := makeTestJob(batchv1.JobStatus{
+		StartTime:      &start,
+		CompletionTime: &end,
+		Succeeded:      1,
+		Conditions:     []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+	})
+	r := makeTestReconciler(t, export, job, makeTestExportPod(`{"size": 2048}`))
+	defer deleteExportMetrics(testNamespace, testExportName)
+
+	assert.NoError(t, r.reconcileJobStatus(export, job))
+
+	assert.ElementsMatch(t, []string{"ExportJobStarted", "ExportJobSucceeded"}, listEventReasons(t, r.Client))
+	assert.Equal(t, jobPhaseSucceeded, job.Annotations[common.ArgoCDExportKeyObservedPhase])
+	assert.Equal(t, float64(1030), testutil.ToFloat64(ExportLastSuccessTimestamp.WithLabelValues(testNamespace, testExportName)))
+	assert.Equal(t, float64(30), testutil.ToFloat64(ExportDuration.WithLabelValues(testNamespace, testExportName)))
+	assert.Equal(t, float64(2048), testutil.ToFloat64(ExportSize.WithLabelValues(testNamespace, testExportName)))
+
+	// a phase that has already been reported is not reported again
+	assert.NoError(t, r.reconcileJobStatus(export, job))
+	assert.Len(t, listEventReasons(t, r.Client), 2)
+}
+
+func TestReconcileJobStatus_failed(t *testing.T) {
+	start := metav1.Now()
+	export := makeTestExport()
+	job := makeTestJob(batchv1.JobStatus{
+		StartTime:  &start,
+		Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}},
+	})
+	job.Annotations = map[string]string{common.ArgoCDExportKeyObservedPhase: jobPhaseRunning}
+	r := makeTestReconciler(t, export, job, makeTestExportPod("unable to reach bucket"))
+	defer deleteExportMetrics(testNamespace, testExportName)
+
+	assert.NoError(t, r.reconcileJobStatus(export, job))
+
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(testNamespace)))
+	assert.Len(t, events.Items, 1)
+	assert.Equal(t, "ExportJobFailed", events.Items[0].Reason)
+	assert.Equal(t, corev1.EventTypeWarning, events.Items[0].Type)
+	assert.Contains(t, events.Items[0].Message, "unable to reach bucket")
+	assert.Equal(t, float64(1), testutil.ToFloat64(ExportFailures.WithLabelValues(testNamespace, testExportName)))
+}
+
+func TestCronJobExportMapper(t *testing.T) {
+	job := makeTestJob(batchv1.JobStatus{})
+	assert.Empty(t, cronJobExportMapper(job))
+
+	job.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: "batch/v1",
+		Kind:       "CronJob",
+		Name:       testExportName,
+		Controller: util.BoolPtr(true),
+	}}
+	requests := cronJobExportMapper(job)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, client.ObjectKey{Namespace: testNamespace, Name: testExportName}, requests[0].NamespacedName)
+}
+
+func TestGetPrometheusRuleSpec(t *testing.T) {
+	export := makeTestExport(func(e *argoprojv1alpha1.ArgoCDExport) {
+		e.Spec.Monitoring = argoprojv1alpha1.ArgoCDExportMonitoringSpec{
+			Enabled: true,
+			MaxAge:  &metav1.Duration{Duration: 2 * time.Hour},
+		}
+	})
+
+	rules := getPrometheusRuleSpec(export).Groups[0].Rules
+	assert.Equal(t, "ArgoCDExportStale", rules[1].Alert)
+	assert.Equal(t, `time() - argocd_export_last_success_timestamp_seconds{namespace="argocd", name="test-export"} > 7200`, rules[1].Expr.StrVal)
+
+	export.Spec.Monitoring.MaxAge = nil
+	assert.Equal(t, common.ArgoCDDefaultExportMaxAge, getExportMaxAge(export))
+}
@@ -35,5 +35,10 @@ func (r *ArgoCDExportReconciler) reconcileStorage(cr *argoprojv1alpha1.ArgoCDExp
 		return err
 	}
 
+	// Workload identity for cloud storage
+	if err := r.reconcileWorkloadIdentity(cr); err != nil {
+		return err
+	}
+
 	return nil
 }
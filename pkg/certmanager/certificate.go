@@ -0,0 +1,211 @@
+package certmanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/argoproj-labs/argocd-operator/pkg/mutation"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	cntrlClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// CertManagerAPIGroup is the API group of the cert-manager API.
+	CertManagerAPIGroup = "cert-manager.io"
+
+	// CertManagerAPIVersion is the version of the cert-manager API used for certificates.
+	CertManagerAPIVersion = "v1"
+
+	// CertificateKind is the kind of a cert-manager Certificate.
+	CertificateKind = "Certificate"
+
+	// IssuerKind is the kind of a namespaced cert-manager Issuer.
+	IssuerKind = "Issuer"
+
+	// ClusterIssuerKind is the kind of a cluster scoped cert-manager ClusterIssuer.
+	ClusterIssuerKind = "ClusterIssuer"
+)
+
+// IssuerReference identifies the cert-manager issuer that signs a certificate.
+type IssuerReference struct {
+	Name  string
+	Kind  string
+	Group string
+}
+
+// CertificateRequest objects contain all the required information to produce a Certificate object in return
+type CertificateRequest struct {
+	ObjectMeta metav1.ObjectMeta
+	IssuerRef  IssuerReference
+	CommonName string
+	DNSNames   []string
+
+	// SecretName is the name of the kubernetes.io/tls Secret that cert-manager stores the certificate in
+	SecretName string
+	// SecretAnnotations and SecretLabels are copied by cert-manager to the Secret holding the certificate
+	SecretAnnotations map[string]string
+	SecretLabels      map[string]string
+
+	// array of functions to mutate certificate before returning to requester
+	Mutations []mutation.MutateFunc
+	Client    cntrlClient.Client
+}
+
+// CertificateGVK returns the GroupVersionKind of a cert-manager Certificate.
+func CertificateGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   CertManagerAPIGroup,
+		Version: CertManagerAPIVersion,
+		Kind:    CertificateKind,
+	}
+}
+
+// newCertificate returns a new, empty cert-manager Certificate.
+func newCertificate(objectMeta metav1.ObjectMeta) *unstructured.Unstructured {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(CertificateGVK())
+	certificate.SetName(objectMeta.Name)
+	certificate.SetNamespace(objectMeta.Namespace)
+	certificate.SetLabels(objectMeta.Labels)
+	certificate.SetAnnotations(objectMeta.Annotations)
+	return certificate
+}
+
+func getIssuerRef(issuerRef IssuerReference) map[string]interface{} {
+	ref := map[string]interface{}{
+		"name": issuerRef.Name,
+	}
+	if len(issuerRef.Kind) > 0 {
+		ref["kind"] = issuerRef.Kind
+	}
+	if len(issuerRef.Group) > 0 {
+		ref["group"] = issuerRef.Group
+	}
+	return ref
+}
+
+func getStringMap(m map[string]string) map[string]interface{} {
+	values := make(map[string]interface{}, len(m))
+	for key, val := range m {
+		values[key] = val
+	}
+	return values
+}
+
+func getStringSlice(s []string) []interface{} {
+	values := make([]interface{}, 0, len(s))
+	for _, val := range s {
+		values = append(values, val)
+	}
+	return values
+}
+
+// RequestCertificate returns a cert-manager Certificate built from the given request.
+func RequestCertificate(request CertificateRequest) (*unstructured.Unstructured, error) {
+	certificate := newCertificate(request.ObjectMeta)
+
+	spec := map[string]interface{}{
+		"secretName": request.SecretName,
+		"issuerRef":  getIssuerRef(request.IssuerRef),
+	}
+	if len(request.CommonName) > 0 {
+		spec["commonName"] = request.CommonName
+	}
+	if len(request.DNSNames) > 0 {
+		spec["dnsNames"] = getStringSlice(request.DNSNames)
+	}
+	if len(request.SecretAnnotations) > 0 || len(request.SecretLabels) > 0 {
+		secretTemplate := map[string]interface{}{}
+		if len(request.SecretAnnotations) > 0 {
+			secretTemplate["annotations"] = getStringMap(request.SecretAnnotations)
+		}
+		if len(request.SecretLabels) > 0 {
+			secretTemplate["labels"] = getStringMap(request.SecretLabels)
+		}
+		spec["secretTemplate"] = secretTemplate
+	}
+	certificate.Object["spec"] = spec
+
+	if len(request.Mutations) > 0 {
+		for _, mutation := range request.Mutations {
+			err := mutation(nil, certificate, request.Client)
+			if err != nil {
+				return certificate, fmt.Errorf("RequestCertificate: one or more mutation functions could not be applied: %s", err)
+			}
+		}
+	}
+	return certificate, nil
+}
+
+// CreateCertificate creates the specified Certificate using the provided client.
+func CreateCertificate(certificate *unstructured.Unstructured, client cntrlClient.Client) error {
+	return client.Create(context.TODO(), certificate)
+}
+
+// GetCertificate retrieves the Certificate with the given name and namespace using the provided client.
+func GetCertificate(name, namespace string, client cntrlClient.Client) (*unstructured.Unstructured, error) {
+	existingCertificate := &unstructured.Unstructured{}
+	existingCertificate.SetGroupVersionKind(CertificateGVK())
+	err := client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, existingCertificate)
+	if err != nil {
+		return nil, err
+	}
+	return existingCertificate, nil
+}
+
+// UpdateCertificate updates the specified Certificate using the provided client.
+func UpdateCertificate(certificate *unstructured.Unstructured, client cntrlClient.Client) error {
+	_, err := GetCertificate(certificate.GetName(), certificate.GetNamespace(), client)
+	if err != nil {
+		return err
+	}
+
+	if err = client.Update(context.TODO(), certificate); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteCertificate deletes the Certificate with the given name and namespace, if it exists, using the provided client.
+func DeleteCertificate(name, namespace string, client cntrlClient.Client) error {
+	existingCertificate, err := GetCertificate(name, namespace, client)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	if err := client.Delete(context.TODO(), existingCertificate); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CertificateNeedsUpdate returns true if the existing Certificate differs from the desired one. Fields that are only
+// present on the existing Certificate, such as those defaulted by cert-manager, are ignored.
+func CertificateNeedsUpdate(existing, desired *unstructured.Unstructured) bool {
+	return !util.IsUnstructuredSubset(desired.Object["spec"], existing.Object["spec"]) ||
+		!util.StringMapsEqual(existing.GetLabels(), desired.GetLabels()) ||
+		!util.StringMapsEqual(existing.GetAnnotations(), desired.GetAnnotations())
+}
+
+// IsCertificateReady returns true if cert-manager reports the Certificate as issued and up to date.
+func IsCertificateReady(certificate *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(certificate.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == "Ready" {
+			return condition["status"] == string(metav1.ConditionTrue)
+		}
+	}
+	return false
+}
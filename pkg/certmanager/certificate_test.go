@@ -0,0 +1,136 @@
+package certmanager
+
+import (
+	"errors"
+	"testing"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/pkg/mutation"
+	"github.com/stretchr/testify/assert"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	cntrlClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	testName      = "test-name"
+	testNamespace = "test-ns"
+	testKVP       = map[string]string{
+		"test-key": "test-value",
+	}
+)
+
+func testMutationFuncFailed(cr *argoproj.ArgoCD, resource interface{}, client cntrlClient.Client) error {
+	return errors.New("test-mutation-error")
+}
+
+func getTestCertificateRequest() CertificateRequest {
+	return CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+			Labels:    testKVP,
+		},
+		IssuerRef:         IssuerReference{Name: "issuer", Kind: ClusterIssuerKind, Group: CertManagerAPIGroup},
+		CommonName:        "argocd-server",
+		DNSNames:          []string{"argocd-server", "argocd-server.test-ns.svc"},
+		SecretName:        "argocd-server-tls",
+		SecretAnnotations: testKVP,
+	}
+}
+
+func TestRequestCertificate(t *testing.T) {
+	certificate, err := RequestCertificate(getTestCertificateRequest())
+	assert.NoError(t, err)
+	assert.Equal(t, CertificateGVK(), certificate.GroupVersionKind())
+	assert.Equal(t, testName, certificate.GetName())
+	assert.Equal(t, testNamespace, certificate.GetNamespace())
+	assert.Equal(t, testKVP, certificate.GetLabels())
+
+	spec, _, _ := unstructured.NestedMap(certificate.Object, "spec")
+	assert.Equal(t, map[string]interface{}{
+		"secretName": "argocd-server-tls",
+		"commonName": "argocd-server",
+		"dnsNames":   []interface{}{"argocd-server", "argocd-server.test-ns.svc"},
+		"issuerRef": map[string]interface{}{
+			"name":  "issuer",
+			"kind":  ClusterIssuerKind,
+			"group": CertManagerAPIGroup,
+		},
+		"secretTemplate": map[string]interface{}{
+			"annotations": map[string]interface{}{"test-key": "test-value"},
+		},
+	}, spec)
+
+	request := getTestCertificateRequest()
+	request.Mutations = []mutation.MutateFunc{testMutationFuncFailed}
+	_, err = RequestCertificate(request)
+	assert.Error(t, err)
+}
+
+func TestIsCertificateReady(t *testing.T) {
+	certificate, err := RequestCertificate(getTestCertificateRequest())
+	assert.NoError(t, err)
+	assert.False(t, IsCertificateReady(certificate))
+
+	setReady := func(status string) {
+		assert.NoError(t, unstructured.SetNestedSlice(certificate.Object, []interface{}{
+			map[string]interface{}{"type": "Issuing", "status": "True"},
+			map[string]interface{}{"type": "Ready", "status": status},
+		}, "status", "conditions"))
+	}
+
+	setReady("False")
+	assert.False(t, IsCertificateReady(certificate))
+
+	setReady("True")
+	assert.True(t, IsCertificateReady(certificate))
+}
+
+func TestCertificateNeedsUpdate(t *testing.T) {
+	desired, err := RequestCertificate(getTestCertificateRequest())
+	assert.NoError(t, err)
+
+	// fields defaulted by cert-manager and the status must not trigger an update
+	existing := desired.DeepCopy()
+	assert.NoError(t, unstructured.SetNestedField(existing.Object, "RSA", "spec", "privateKey", "algorithm"))
+	assert.NoError(t, unstructured.SetNestedField(existing.Object, "2023-01-01T00:00:00Z", "status", "notAfter"))
+	assert.False(t, CertificateNeedsUpdate(existing, desired))
+
+	existing = desired.DeepCopy()
+	assert.NoError(t, unstructured.SetNestedField(existing.Object, "other-issuer", "spec", "issuerRef", "name"))
+	assert.True(t, CertificateNeedsUpdate(existing, desired))
+
+	existing = desired.DeepCopy()
+	assert.NoError(t, unstructured.SetNestedStringSlice(existing.Object, []string{"argocd-server"}, "spec", "dnsNames"))
+	assert.True(t, CertificateNeedsUpdate(existing, desired))
+}
+
+func TestCertificateLifecycle(t *testing.T) {
+	testClient := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+
+	certificate, err := RequestCertificate(getTestCertificateRequest())
+	assert.NoError(t, err)
+	assert.NoError(t, CreateCertificate(certificate, testClient))
+
+	existing, err := GetCertificate(testName, testNamespace, testClient)
+	assert.NoError(t, err)
+	assert.False(t, CertificateNeedsUpdate(existing, certificate))
+
+	assert.NoError(t, unstructured.SetNestedField(existing.Object, "other-issuer", "spec", "issuerRef", "name"))
+	assert.NoError(t, UpdateCertificate(existing, testClient))
+	existing, err = GetCertificate(testName, testNamespace, testClient)
+	assert.NoError(t, err)
+	name, _, _ := unstructured.NestedString(existing.Object, "spec", "issuerRef", "name")
+	assert.Equal(t, "other-issuer", name)
+
+	assert.NoError(t, DeleteCertificate(testName, testNamespace, testClient))
+	_, err = GetCertificate(testName, testNamespace, testClient)
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	// deleting a certificate that does not exist is not an error
+	assert.NoError(t, DeleteCertificate(testName, testNamespace, testClient))
+}
@@ -0,0 +1,26 @@
+package certmanager
+
+import (
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+)
+
+var certManagerAPIFound = false
+
+// IsCertManagerAPIAvailable returns true if the cert-manager API is present.
+func IsCertManagerAPIAvailable() bool {
+	return certManagerAPIFound
+}
+
+func SetCertManagerAPIFound(found bool) {
+	certManagerAPIFound = found
+}
+
+// VerifyCertManagerAPI will verify that the cert-manager API is present.
+func VerifyCertManagerAPI() error {
+	found, err := util.VerifyAPI(CertManagerAPIGroup, CertManagerAPIVersion)
+	if err != nil {
+		return err
+	}
+	certManagerAPIFound = found
+	return nil
+}
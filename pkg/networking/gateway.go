@@ -0,0 +1,270 @@
+package networking
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/argoproj-labs/argocd-operator/pkg/mutation"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	cntrlClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// GatewayAPIGroup is the API group of the Kubernetes Gateway API.
+	GatewayAPIGroup = "gateway.networking.k8s.io"
+
+	// GatewayAPIVersion is the preferred version of the Kubernetes Gateway API used for routes.
+	GatewayAPIVersion = "v1"
+
+	// HTTPRouteKind is the kind of a Gateway API HTTPRoute.
+	HTTPRouteKind = "HTTPRoute"
+
+	// GRPCRouteKind is the kind of a Gateway API GRPCRoute.
+	GRPCRouteKind = "GRPCRoute"
+)
+
+// GatewayParentReference identifies a Gateway, or one of its listeners, that a route attaches to.
+type GatewayParentReference struct {
+	Name        string
+	Namespace   string
+	SectionName string
+	Port        *int32
+}
+
+// GatewayBackendReference identifies the Service port that a route forwards traffic to.
+type GatewayBackendReference struct {
+	Name string
+	Port int32
+}
+
+// HTTPRouteRequest objects contain all the required information to produce a HTTPRoute object in return
+type HTTPRouteRequest struct {
+	ObjectMeta metav1.ObjectMeta
+	ParentRefs []GatewayParentReference
+	Hostnames  []string
+	Path       string
+	Backend    GatewayBackendReference
+
+	// HTTPSRedirect produces a route that redirects all matching requests to https instead of forwarding them to the backend
+	HTTPSRedirect bool
+
+	// array of functions to mutate route before returning to requester
+	Mutations []mutation.MutateFunc
+	Client    cntrlClient.Client
+}
+
+// GRPCRouteRequest objects contain all the required information to produce a GRPCRoute object in return
+type GRPCRouteRequest struct {
+	ObjectMeta metav1.ObjectMeta
+	ParentRefs []GatewayParentReference
+	Hostnames  []string
+	Backend    GatewayBackendReference
+
+	// array of functions to mutate route before returning to requester
+	Mutations []mutation.MutateFunc
+	Client    cntrlClient.Client
+}
+
+// GatewayRouteGVK returns the GroupVersionKind of the Gateway API route of the given kind, using the version served by
+// the cluster.
+func GatewayRouteGVK(kind string) schema.GroupVersionKind {
+	version, found := gatewayRouteVersions[kind]
+	if !found {
+		version = GatewayAPIVersion
+	}
+	return schema.GroupVersionKind{
+		Group:   GatewayAPIGroup,
+		Version: version,
+		Kind:    kind,
+	}
+}
+
+// newGatewayRoute returns a new, empty Gateway API route of the given kind.
+func newGatewayRoute(kind string, objectMeta metav1.ObjectMeta) *unstructured.Unstructured {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(GatewayRouteGVK(kind))
+	route.SetName(objectMeta.Name)
+	route.SetNamespace(objectMeta.Namespace)
+	route.SetLabels(objectMeta.Labels)
+	route.SetAnnotations(objectMeta.Annotations)
+	return route
+}
+
+func getParentRefs(parentRefs []GatewayParentReference) []interface{} {
+	refs := make([]interface{}, 0, len(parentRefs))
+	for _, parentRef := range parentRefs {
+		ref := map[string]interface{}{
+			"name": parentRef.Name,
+		}
+		if len(parentRef.Namespace) > 0 {
+			ref["namespace"] = parentRef.Namespace
+		}
+		if len(parentRef.SectionName) > 0 {
+			ref["sectionName"] = parentRef.SectionName
+		}
+		if parentRef.Port != nil {
+			ref["port"] = int64(*parentRef.Port)
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+func getHostnames(hostnames []string) []interface{} {
+	values := make([]interface{}, 0, len(hostnames))
+	for _, hostname := range hostnames {
+		values = append(values, hostname)
+	}
+	return values
+}
+
+func getBackendRefs(backend GatewayBackendReference) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"name": backend.Name,
+			"port": int64(backend.Port),
+		},
+	}
+}
+
+// getGatewayRouteSpec returns the fields common to all Gateway API route specs.
+func getGatewayRouteSpec(parentRefs []GatewayParentReference, hostnames []string) map[string]interface{} {
+	spec := map[string]interface{}{
+		"parentRefs": getParentRefs(parentRefs),
+	}
+	if len(hostnames) > 0 {
+		spec["hostnames"] = getHostnames(hostnames)
+	}
+	return spec
+}
+
+// applyGatewayRouteMutations runs the given mutations against the route.
+func applyGatewayRouteMutations(route *unstructured.Unstructured, mutations []mutation.MutateFunc, client cntrlClient.Client) error {
+	var mutationErr error
+	for _, mutation := range mutations {
+		if err := mutation(nil, route, client); err != nil {
+			mutationErr = err
+		}
+	}
+	return mutationErr
+}
+
+// RequestHTTPRoute returns a HTTPRoute built from the given request.
+func RequestHTTPRoute(request HTTPRouteRequest) (*unstructured.Unstructured, error) {
+	route := newGatewayRoute(HTTPRouteKind, request.ObjectMeta)
+
+	path := request.Path
+	if len(path) <= 0 {
+		path = "/"
+	}
+
+	rule := map[string]interface{}{
+		"matches": []interface{}{
+			map[string]interface{}{
+				"path": map[string]interface{}{
+					"type":  "PathPrefix",
+					"value": path,
+				},
+			},
+		},
+	}
+
+	if request.HTTPSRedirect {
+		rule["filters"] = []interface{}{
+			map[string]interface{}{
+				"type": "RequestRedirect",
+				"requestRedirect": map[string]interface{}{
+					"scheme":     "https",
+					"statusCode": int64(301),
+				},
+			},
+		}
+	} else {
+		rule["backendRefs"] = getBackendRefs(request.Backend)
+	}
+
+	spec := getGatewayRouteSpec(request.ParentRefs, request.Hostnames)
+	spec["rules"] = []interface{}{rule}
+	route.Object["spec"] = spec
+
+	if err := applyGatewayRouteMutations(route, request.Mutations, request.Client); err != nil {
+		return route, fmt.Errorf("RequestHTTPRoute: one or more mutation functions could not be applied: %s", err)
+	}
+	return route, nil
+}
+
+// RequestGRPCRoute returns a GRPCRoute built from the given request.
+func RequestGRPCRoute(request GRPCRouteRequest) (*unstructured.Unstructured, error) {
+	route := newGatewayRoute(GRPCRouteKind, request.ObjectMeta)
+
+	spec := getGatewayRouteSpec(request.ParentRefs, request.Hostnames)
+	spec["rules"] = []interface{}{
+		map[string]interface{}{
+			"backendRefs": getBackendRefs(request.Backend),
+		},
+	}
+	route.Object["spec"] = spec
+
+	if err := applyGatewayRouteMutations(route, request.Mutations, request.Client); err != nil {
+		return route, fmt.Errorf("RequestGRPCRoute: one or more mutation functions could not be applied: %s", err)
+	}
+	return route, nil
+}
+
+// CreateGatewayRoute creates the specified Gateway API route using the provided client.
+func CreateGatewayRoute(route *unstructured.Unstructured, client cntrlClient.Client) error {
+	return client.Create(context.TODO(), route)
+}
+
+// GetGatewayRoute retrieves the Gateway API route of the given kind using the provided client.
+func GetGatewayRoute(kind, name, namespace string, client cntrlClient.Client) (*unstructured.Unstructured, error) {
+	existingRoute := &unstructured.Unstructured{}
+	existingRoute.SetGroupVersionKind(GatewayRouteGVK(kind))
+	err := client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, existingRoute)
+	if err != nil {
+		return nil, err
+	}
+	return existingRoute, nil
+}
+
+// UpdateGatewayRoute updates the specified Gateway API route using the provided client.
+func UpdateGatewayRoute(route *unstructured.Unstructured, client cntrlClient.Client) error {
+	_, err := GetGatewayRoute(route.GetKind(), route.GetName(), route.GetNamespace(), client)
+	if err != nil {
+		return err
+	}
+
+	if err = client.Update(context.TODO(), route); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteGatewayRoute deletes the Gateway API route of the given kind, if it exists, using the provided client.
+func DeleteGatewayRoute(kind, name, namespace string, client cntrlClient.Client) error {
+	existingRoute, err := GetGatewayRoute(kind, name, namespace, client)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	if err := client.Delete(context.TODO(), existingRoute); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GatewayRouteNeedsUpdate returns true if the existing Gateway API route differs from the desired one. Fields that are
+// only present on the existing route, such as those defaulted by the API server, are ignored.
+func GatewayRouteNeedsUpdate(existing, desired *unstructured.Unstructured) bool {
+	return !util.IsUnstructuredSubset(desired.Object["spec"], existing.Object["spec"]) ||
+		!util.StringMapsEqual(existing.GetLabels(), desired.GetLabels()) ||
+		!util.StringMapsEqual(existing.GetAnnotations(), desired.GetAnnotations())
+}
@@ -0,0 +1,181 @@
+package networking
+
+import (
+	"testing"
+
+	"github.com/argoproj-labs/argocd-operator/pkg/mutation"
+	"github.com/stretchr/testify/assert"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func getTestHTTPRouteRequest() HTTPRouteRequest {
+	port := int32(443)
+	return HTTPRouteRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+			Labels:    testKVP,
+		},
+		ParentRefs: []GatewayParentReference{
+			{Name: "gateway", Namespace: "gateway-ns", SectionName: "https", Port: &port},
+		},
+		Hostnames: []string{"argocd.example.com"},
+		Backend:   GatewayBackendReference{Name: testApplicationName, Port: 80},
+	}
+}
+
+func TestRequestHTTPRoute(t *testing.T) {
+	route, err := RequestHTTPRoute(getTestHTTPRouteRequest())
+	assert.NoError(t, err)
+	assert.Equal(t, GatewayRouteGVK(HTTPRouteKind), route.GroupVersionKind())
+	assert.Equal(t, testName, route.GetName())
+	assert.Equal(t, testNamespace, route.GetNamespace())
+	assert.Equal(t, testKVP, route.GetLabels())
+
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "gateway", "namespace": "gateway-ns", "sectionName": "https", "port": int64(443)},
+	}, parentRefs)
+
+	hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	assert.Equal(t, []string{"argocd.example.com"}, hostnames)
+
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"matches": []interface{}{
+				map[string]interface{}{"path": map[string]interface{}{"type": "PathPrefix", "value": "/"}},
+			},
+			"backendRefs": []interface{}{
+				map[string]interface{}{"name": testApplicationName, "port": int64(80)},
+			},
+		},
+	}, rules)
+}
+
+func TestRequestHTTPRoute_redirect(t *testing.T) {
+	request := getTestHTTPRouteRequest()
+	request.Path = "/api/webhook"
+	request.HTTPSRedirect = true
+
+	route, err := RequestHTTPRoute(request)
+	assert.NoError(t, err)
+
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"matches": []interface{}{
+				map[string]interface{}{"path": map[string]interface{}{"type": "PathPrefix", "value": "/api/webhook"}},
+			},
+			"filters": []interface{}{
+				map[string]interface{}{
+					"type":            "RequestRedirect",
+					"requestRedirect": map[string]interface{}{"scheme": "https", "statusCode": int64(301)},
+				},
+			},
+		},
+	}, rules)
+}
+
+func TestRequestHTTPRoute_mutationFailed(t *testing.T) {
+	request := getTestHTTPRouteRequest()
+	request.Mutations = []mutation.MutateFunc{testMutationFuncFailed}
+
+	_, err := RequestHTTPRoute(request)
+	assert.Error(t, err)
+}
+
+func TestRequestGRPCRoute(t *testing.T) {
+	route, err := RequestGRPCRoute(GRPCRouteRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+		ParentRefs: []GatewayParentReference{{Name: "gateway"}},
+		Backend:    GatewayBackendReference{Name: testApplicationName, Port: 443},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, GatewayRouteGVK(GRPCRouteKind), route.GroupVersionKind())
+
+	_, found, _ := unstructured.NestedSlice(route.Object, "spec", "hostnames")
+	assert.False(t, found)
+
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"backendRefs": []interface{}{
+				map[string]interface{}{"name": testApplicationName, "port": int64(443)},
+			},
+		},
+	}, rules)
+}
+
+func TestGatewayRouteNeedsUpdate(t *testing.T) {
+	desired, err := RequestHTTPRoute(getTestHTTPRouteRequest())
+	assert.NoError(t, err)
+
+	// fields defaulted by the API server must not trigger an update
+	existing := desired.DeepCopy()
+	rules, _, _ := unstructured.NestedSlice(existing.Object, "spec", "rules")
+	backendRef := rules[0].(map[string]interface{})["backendRefs"].([]interface{})[0].(map[string]interface{})
+	backendRef["group"] = ""
+	backendRef["kind"] = "Service"
+	backendRef["weight"] = int64(1)
+	assert.NoError(t, unstructured.SetNestedSlice(existing.Object, rules, "spec", "rules"))
+	assert.False(t, GatewayRouteNeedsUpdate(existing, desired))
+
+	existing = desired.DeepCopy()
+	assert.NoError(t, unstructured.SetNestedStringSlice(existing.Object, []string{"other.example.com"}, "spec", "hostnames"))
+	assert.True(t, GatewayRouteNeedsUpdate(existing, desired))
+
+	existing = desired.DeepCopy()
+	existing.SetAnnotations(testKVP)
+	assert.True(t, GatewayRouteNeedsUpdate(existing, desired))
+}
+
+func TestGatewayRouteLifecycle(t *testing.T) {
+	testClient := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+
+	route, err := RequestHTTPRoute(getTestHTTPRouteRequest())
+	assert.NoError(t, err)
+
+	assert.NoError(t, CreateGatewayRoute(route, testClient))
+
+	existing, err := GetGatewayRoute(HTTPRouteKind, testName, testNamespace, testClient)
+	assert.NoError(t, err)
+	assert.False(t, GatewayRouteNeedsUpdate(existing, route))
+
+	existing.SetLabels(map[string]string{"updated": "true"})
+	assert.NoError(t, UpdateGatewayRoute(existing, testClient))
+	existing, err = GetGatewayRoute(HTTPRouteKind, testName, testNamespace, testClient)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"updated": "true"}, existing.GetLabels())
+
+	assert.NoError(t, DeleteGatewayRoute(HTTPRouteKind, testName, testNamespace, testClient))
+	_, err = GetGatewayRoute(HTTPRouteKind, testName, testNamespace, testClient)
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	// deleting a route that does not exist is not an error
+	assert.NoError(t, DeleteGatewayRoute(HTTPRouteKind, testName, testNamespace, testClient))
+}
+
+func TestGatewayRouteGVK_servedVersions(t *testing.T) {
+	SetGatewayAPIFound(false)
+	defer SetGatewayAPIFound(false)
+
+	assert.False(t, IsGatewayAPIAvailable())
+	assert.False(t, IsGatewayRouteKindAvailable(GRPCRouteKind))
+
+	SetGatewayRouteVersion(HTTPRouteKind, GatewayAPIVersion)
+	SetGatewayRouteVersion(GRPCRouteKind, "v1alpha2")
+
+	assert.True(t, IsGatewayAPIAvailable())
+	assert.True(t, IsGatewayRouteKindAvailable(GRPCRouteKind))
+	assert.Equal(t, "v1", GatewayRouteGVK(HTTPRouteKind).Version)
+	assert.Equal(t, "v1alpha2", GatewayRouteGVK(GRPCRouteKind).Version)
+
+	SetGatewayRouteVersion(GRPCRouteKind, "")
+	assert.False(t, IsGatewayRouteKindAvailable(GRPCRouteKind))
+	assert.True(t, IsGatewayRouteKindAvailable(HTTPRouteKind))
+}
@@ -25,3 +25,64 @@ func VerifyRouteAPI() error {
 	routeAPIFound = found
 	return nil
 }
+
+// gatewayRouteVersions holds the served version of each Gateway API route kind, keyed by kind. Kinds that are not
+// served by the cluster are absent.
+var gatewayRouteVersions = map[string]string{}
+
+// gatewayRouteCandidateVersions lists, in order of preference, the versions of each Gateway API route kind that can be
+// managed. GRPCRoute is only served as v1alpha2 before Gateway API v1.1, and HTTPRoute as v1beta1 before v1.0.
+var gatewayRouteCandidateVersions = map[string][]string{
+	HTTPRouteKind: {GatewayAPIVersion, "v1beta1"},
+	GRPCRouteKind: {GatewayAPIVersion, "v1alpha2"},
+}
+
+// IsGatewayAPIAvailable returns true if any of the Gateway API route kinds is served.
+func IsGatewayAPIAvailable() bool {
+	return len(gatewayRouteVersions) > 0
+}
+
+// IsGatewayRouteKindAvailable returns true if the given Gateway API route kind is served.
+func IsGatewayRouteKindAvailable(kind string) bool {
+	_, found := gatewayRouteVersions[kind]
+	return found
+}
+
+// SetGatewayAPIFound marks all the Gateway API route kinds as served with the default version, or none of them.
+func SetGatewayAPIFound(gatewayFound bool) {
+	gatewayRouteVersions = map[string]string{}
+	if gatewayFound {
+		for kind := range gatewayRouteCandidateVersions {
+			gatewayRouteVersions[kind] = GatewayAPIVersion
+		}
+	}
+}
+
+// SetGatewayRouteVersion records the served version of the given Gateway API route kind. An empty version marks the
+// kind as not served.
+func SetGatewayRouteVersion(kind, version string) {
+	if len(version) <= 0 {
+		delete(gatewayRouteVersions, kind)
+		return
+	}
+	gatewayRouteVersions[kind] = version
+}
+
+// VerifyGatewayAPI will verify which Gateway API route kinds are served, and with which version.
+func VerifyGatewayAPI() error {
+	versions := map[string]string{}
+	for kind, candidates := range gatewayRouteCandidateVersions {
+		for _, version := range candidates {
+			found, err := util.VerifyAPIResource(GatewayAPIGroup, version, kind)
+			if err != nil {
+				return err
+			}
+			if found {
+				versions[kind] = version
+				break
+			}
+		}
+	}
+	gatewayRouteVersions = versions
+	return nil
+}
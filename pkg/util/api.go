@@ -17,6 +17,7 @@ package util
 import (
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
@@ -47,3 +48,36 @@ func VerifyAPI(group string, version string) (bool, error) {
 
 	return true, nil
 }
+
+// VerifyAPIResource will verify that the given kind is served by the given group/version in the cluster.
+func VerifyAPIResource(group string, version string, kind string) (bool, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return false, fmt.Errorf("VerifyAPIResource: unable to get k8s config: %w", err)
+	}
+
+	k8s, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return false, fmt.Errorf("VerifyAPIResource: unable to create k8s client: %w", err)
+	}
+
+	gv := schema.GroupVersion{
+		Group:   group,
+		Version: version,
+	}
+
+	resources, err := k8s.Discovery().ServerResourcesForGroupVersion(gv.String())
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("VerifyAPIResource: unable to discover resources for %s: %w", gv.String(), err)
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Kind == kind {
+			return true, nil
+		}
+	}
+	return false, nil
+}
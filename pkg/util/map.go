@@ -26,3 +26,16 @@ func AppendStringMap(src map[string]string, add map[string]string) map[string]st
 	}
 	return res
 }
+
+// StringMapsEqual returns true if both maps contain the same keys and values. A nil map equals an empty map.
+func StringMapsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, val := range a {
+		if other, ok := b[key]; !ok || other != val {
+			return false
+		}
+	}
+	return true
+}
@@ -15,6 +15,10 @@
 package util
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -24,6 +28,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"net"
 	"time"
 
 	tlsutil "github.com/operator-framework/operator-sdk/pkg/tls"
@@ -32,21 +37,135 @@ import (
 )
 
 const (
-	rsaPrivateKeyType = "RSA PRIVATE KEY"
-	certificateType   = "CERTIFICATE"
+	rsaPrivateKeyType   = "RSA PRIVATE KEY"
+	ecPrivateKeyType    = "EC PRIVATE KEY"
+	pkcs8PrivateKeyType = "PRIVATE KEY"
+	certificateType     = "CERTIFICATE"
 )
 
+// CertificateProfile describes the private key and lifetime of the certificates generated by the operator.
+type CertificateProfile struct {
+	// KeyAlgorithm is one of RSA, ECDSA or Ed25519. Defaults to RSA.
+	KeyAlgorithm string
+	// KeySize is the RSA modulus length in bits, or the ECDSA curve size. Ignored for Ed25519.
+	KeySize int
+	// Validity is how long the certificates are valid for. Defaults to 365 days.
+	Validity time.Duration
+	// ExtraSANs are additional DNS names or IP addresses of the signed certificates.
+	ExtraSANs []string
+}
+
+// GetKeyAlgorithm returns the key algorithm of the profile, or the default RSA algorithm.
+func (p CertificateProfile) GetKeyAlgorithm() string {
+	if len(p.KeyAlgorithm) > 0 {
+		return p.KeyAlgorithm
+	}
+	return common.ArgoCDKeyAlgorithmRSA
+}
+
+// GetKeySize returns the key size of the profile, or the default size of its key algorithm. It is 0 for Ed25519.
+func (p CertificateProfile) GetKeySize() int {
+	switch {
+	case p.GetKeyAlgorithm() == common.ArgoCDKeyAlgorithmEd25519:
+		return 0
+	case p.KeySize > 0:
+		return p.KeySize
+	case p.GetKeyAlgorithm() == common.ArgoCDKeyAlgorithmECDSA:
+		return common.ArgoCDDefaultECDSAKeySize
+	}
+	return common.ArgoCDDefaultRSAKeySize
+}
+
+// Validate returns an error if the key algorithm or the key size of the profile is not supported.
+func (p CertificateProfile) Validate() error {
+	switch p.GetKeyAlgorithm() {
+	case common.ArgoCDKeyAlgorithmRSA:
+		if p.GetKeySize() < common.ArgoCDDefaultRSAKeySize {
+			return fmt.Errorf("unsupported RSA key size %d, must be at least %d", p.KeySize, common.ArgoCDDefaultRSAKeySize)
+		}
+		return nil
+	case common.ArgoCDKeyAlgorithmEd25519:
+		return nil
+	case common.ArgoCDKeyAlgorithmECDSA:
+		switch p.GetKeySize() {
+		case 256, 384, 521:
+			return nil
+		}
+		return fmt.Errorf("unsupported ECDSA key size %d, must be one of 256, 384 or 521", p.KeySize)
+	}
+	return fmt.Errorf("unsupported key algorithm %s", p.KeyAlgorithm)
+}
+
+// GetValidity returns the validity of the profile, or the default validity of 365 days.
+func (p CertificateProfile) GetValidity() time.Duration {
+	if p.Validity > 0 {
+		return p.Validity
+	}
+	return common.ArgoCDDuration365Days
+}
+
 // NewPrivateKey returns randomly generated RSA private key.
-func NewPrivateKey() (*rsa.PrivateKey, error) {
-	return rsa.GenerateKey(rand.Reader, common.ArgoCDDefaultRSAKeySize)
+func NewPrivateKey() (crypto.Signer, error) {
+	return NewPrivateKeyForProfile(CertificateProfile{})
+}
+
+// NewPrivateKeyForProfile returns a randomly generated private key using the algorithm and key size of the given profile.
+func NewPrivateKeyForProfile(profile CertificateProfile) (crypto.Signer, error) {
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch profile.GetKeyAlgorithm() {
+	case common.ArgoCDKeyAlgorithmECDSA:
+		curve := elliptic.P256()
+		switch profile.GetKeySize() {
+		case 384:
+			curve = elliptic.P384()
+		case 521:
+			curve = elliptic.P521()
+		}
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	case common.ArgoCDKeyAlgorithmEd25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	}
+	return rsa.GenerateKey(rand.Reader, profile.GetKeySize())
+}
+
+// GetKeyAlgorithm returns the key algorithm of the given public key, as used in a CertificateProfile.
+func GetKeyAlgorithm(key crypto.PublicKey) string {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return common.ArgoCDKeyAlgorithmRSA
+	case *ecdsa.PublicKey:
+		return common.ArgoCDKeyAlgorithmECDSA
+	case ed25519.PublicKey:
+		return common.ArgoCDKeyAlgorithmEd25519
+	}
+	return ""
+}
+
+// GetKeySize returns the key size of the given public key, as used in a CertificateProfile.
+func GetKeySize(key crypto.PublicKey) int {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return k.N.BitLen()
+	case *ecdsa.PublicKey:
+		return k.Curve.Params().BitSize
+	}
+	return 0
 }
 
-// EncodePrivateKeyPEM encodes the given private key pem and returns bytes (base64).
-func EncodePrivateKeyPEM(key *rsa.PrivateKey) []byte {
+// EncodePrivateKeyPEM encodes the given private key as PKCS#8 pem and returns bytes (base64).
+func EncodePrivateKeyPEM(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
 	return pem.EncodeToMemory(&pem.Block{
-		Type:  rsaPrivateKeyType,
-		Bytes: x509.MarshalPKCS1PrivateKey(key),
-	})
+		Type:  pkcs8PrivateKeyType,
+		Bytes: der,
+	}), nil
 }
 
 // EncodeCertificatePEM encodes the given certificate pem and returns bytes (base64).
@@ -66,18 +185,73 @@ func ParsePEMEncodedCert(pemdata []byte) (*x509.Certificate, error) {
 	return x509.ParseCertificate(decoded.Bytes)
 }
 
-// ParsePEMEncodedPrivateKey parses a private key from given pemdata
-func ParsePEMEncodedPrivateKey(pemdata []byte) (*rsa.PrivateKey, error) {
+// ParsePEMEncodedCerts parses all the certificates, such as a CA bundle, from the given pemdata
+func ParsePEMEncodedCerts(pemdata []byte) ([]*x509.Certificate, error) {
+	certs := []*x509.Certificate{}
+	for {
+		var decoded *pem.Block
+		decoded, pemdata = pem.Decode(pemdata)
+		if decoded == nil {
+			break
+		}
+		if decoded.Type != certificateType {
+			continue
+		}
+		cert, err := x509.ParseCertificate(decoded.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM data found")
+	}
+	return certs, nil
+}
+
+// ParsePEMEncodedPrivateKey parses a private key from given pemdata. PKCS#8 keys, as well as PKCS#1 RSA and SEC 1 EC
+// keys generated by previous versions of the operator, are supported.
+func ParsePEMEncodedPrivateKey(pemdata []byte) (crypto.Signer, error) {
 	decoded, _ := pem.Decode(pemdata)
 	if decoded == nil {
 		return nil, errors.New("no PEM data found")
 	}
-	return x509.ParsePKCS1PrivateKey(decoded.Bytes)
+
+	switch decoded.Type {
+	case rsaPrivateKeyType:
+		return x509.ParsePKCS1PrivateKey(decoded.Bytes)
+	case ecPrivateKeyType:
+		return x509.ParseECPrivateKey(decoded.Bytes)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(decoded.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return signer, nil
+}
+
+// getKeyUsage returns the key usage of a certificate for the given key. Key encipherment only applies to RSA keys.
+func getKeyUsage(key crypto.Signer) x509.KeyUsage {
+	usage := x509.KeyUsageDigitalSignature
+	if _, ok := key.Public().(*rsa.PublicKey); ok {
+		usage |= x509.KeyUsageKeyEncipherment
+	}
+	return usage
 }
 
 // NewSelfSignedCACertificate returns a self-signed CA certificate based on given configuration and private key.
 // The certificate has one-year lease.
-func NewSelfSignedCACertificate(name string, key *rsa.PrivateKey) (*x509.Certificate, error) {
+func NewSelfSignedCACertificate(name string, key crypto.Signer) (*x509.Certificate, error) {
+	return NewSelfSignedCACertificateForProfile(name, key, CertificateProfile{})
+}
+
+// NewSelfSignedCACertificateForProfile returns a self-signed CA certificate valid for the validity of the given profile.
+func NewSelfSignedCACertificateForProfile(name string, key crypto.Signer, profile CertificateProfile) (*x509.Certificate, error) {
 	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
 	if err != nil {
 		return nil, err
@@ -86,8 +260,8 @@ func NewSelfSignedCACertificate(name string, key *rsa.PrivateKey) (*x509.Certifi
 	tmpl := x509.Certificate{
 		SerialNumber:          serial,
 		NotBefore:             now.UTC(),
-		NotAfter:              now.Add(common.ArgoCDDuration365Days).UTC(),
-		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		NotAfter:              now.Add(profile.GetValidity()).UTC(),
+		KeyUsage:              getKeyUsage(key) | x509.KeyUsageCertSign,
 		BasicConstraintsValid: true,
 		IsCA:                  true,
 		Subject:               pkix.Name{CommonName: fmt.Sprintf("argocd-operator@%s", name)},
@@ -102,7 +276,13 @@ func NewSelfSignedCACertificate(name string, key *rsa.PrivateKey) (*x509.Certifi
 // NewSignedCertificate signs a certificate using the given private key, CA and returns a signed certificate.
 // The certificate could be used for both client and server auth.
 // The certificate has one-year lease.
-func NewSignedCertificate(cfg *tlsutil.CertConfig, dnsNames []string, key *rsa.PrivateKey, caCert *x509.Certificate, caKey *rsa.PrivateKey) (*x509.Certificate, error) {
+func NewSignedCertificate(cfg *tlsutil.CertConfig, dnsNames []string, key crypto.Signer, caCert *x509.Certificate, caKey crypto.Signer) (*x509.Certificate, error) {
+	return NewSignedCertificateForProfile(cfg, dnsNames, key, caCert, caKey, CertificateProfile{})
+}
+
+// NewSignedCertificateForProfile signs a certificate valid for the validity of the given profile. The extra SANs of the
+// profile are added to the given DNS names, as IP addresses when they parse as such.
+func NewSignedCertificateForProfile(cfg *tlsutil.CertConfig, dnsNames []string, key crypto.Signer, caCert *x509.Certificate, caKey crypto.Signer, profile CertificateProfile) (*x509.Certificate, error) {
 	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
 	if err != nil {
 		return nil, err
@@ -116,16 +296,28 @@ func NewSignedCertificate(cfg *tlsutil.CertConfig, dnsNames []string, key *rsa.P
 	case tlsutil.ClientAndServingCert:
 		eku = append(eku, x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth)
 	}
+
+	sans := append([]string{}, dnsNames...)
+	ips := []net.IP{}
+	for _, san := range profile.ExtraSANs {
+		if ip := net.ParseIP(san); ip != nil {
+			ips = append(ips, ip)
+			continue
+		}
+		sans = append(sans, san)
+	}
+
 	certTmpl := x509.Certificate{
 		Subject: pkix.Name{
 			CommonName:   cfg.CommonName,
 			Organization: cfg.Organization,
 		},
-		DNSNames:     dnsNames,
+		DNSNames:     sans,
+		IPAddresses:  ips,
 		SerialNumber: serial,
 		NotBefore:    caCert.NotBefore,
-		NotAfter:     time.Now().Add(common.ArgoCDDuration365Days).UTC(),
-		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		NotAfter:     time.Now().Add(profile.GetValidity()).UTC(),
+		KeyUsage:     getKeyUsage(key),
 		ExtKeyUsage:  eku,
 	}
 	certDERBytes, err := x509.CreateCertificate(rand.Reader, &certTmpl, caCert, key.Public(), caKey)
@@ -0,0 +1,151 @@
+package util
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	tlsutil "github.com/operator-framework/operator-sdk/pkg/tls"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func Test_NewPrivateKeyForProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile CertificateProfile
+		check   func(t *testing.T, key interface{})
+		wantErr bool
+	}{
+		{
+			name:    "defaults to RSA 2048",
+			profile: CertificateProfile{},
+			check: func(t *testing.T, key interface{}) {
+				assert.IsType(t, &rsa.PrivateKey{}, key)
+				assert.Equal(t, common.ArgoCDDefaultRSAKeySize, key.(*rsa.PrivateKey).N.BitLen())
+			},
+		},
+		{
+			name:    "ECDSA defaults to P-256",
+			profile: CertificateProfile{KeyAlgorithm: common.ArgoCDKeyAlgorithmECDSA},
+			check: func(t *testing.T, key interface{}) {
+				assert.Equal(t, elliptic.P256(), key.(*ecdsa.PrivateKey).Curve)
+			},
+		},
+		{
+			name:    "ECDSA P-384",
+			profile: CertificateProfile{KeyAlgorithm: common.ArgoCDKeyAlgorithmECDSA, KeySize: 384},
+			check: func(t *testing.T, key interface{}) {
+				assert.Equal(t, elliptic.P384(), key.(*ecdsa.PrivateKey).Curve)
+			},
+		},
+		{
+			name:    "Ed25519",
+			profile: CertificateProfile{KeyAlgorithm: common.ArgoCDKeyAlgorithmEd25519},
+			check: func(t *testing.T, key interface{}) {
+				assert.IsType(t, ed25519.PrivateKey{}, key)
+			},
+		},
+		{
+			name:    "unsupported ECDSA key size",
+			profile: CertificateProfile{KeyAlgorithm: common.ArgoCDKeyAlgorithmECDSA, KeySize: 128},
+			wantErr: true,
+		},
+		{
+			name:    "RSA key size below 2048",
+			profile: CertificateProfile{KeySize: 1024},
+			wantErr: true,
+		},
+		{
+			name:    "RSA 3072",
+			profile: CertificateProfile{KeySize: 3072},
+			check: func(t *testing.T, key interface{}) {
+				assert.Equal(t, 3072, key.(*rsa.PrivateKey).N.BitLen())
+			},
+		},
+		{
+			name:    "unsupported algorithm",
+			profile: CertificateProfile{KeyAlgorithm: "DSA"},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key, err := NewPrivateKeyForProfile(test.profile)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			test.check(t, key)
+			assert.Equal(t, test.profile.GetKeySize(), GetKeySize(key.Public()))
+
+			// keys are encoded as PKCS#8 and can be read back
+			encoded, err := EncodePrivateKeyPEM(key)
+			assert.NoError(t, err)
+			block, _ := pem.Decode(encoded)
+			assert.Equal(t, "PRIVATE KEY", block.Type)
+			parsed, err := ParsePEMEncodedPrivateKey(encoded)
+			assert.NoError(t, err)
+			assert.Equal(t, key.Public(), parsed.Public())
+		})
+	}
+}
+
+func Test_ParsePEMEncodedPrivateKey_legacyFormats(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	parsed, err := ParsePEMEncodedPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}))
+	assert.NoError(t, err)
+	assert.Equal(t, rsaKey.Public(), parsed.Public())
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(ecKey)
+	assert.NoError(t, err)
+	parsed, err = ParsePEMEncodedPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+	assert.NoError(t, err)
+	assert.Equal(t, ecKey.Public(), parsed.Public())
+
+	_, err = ParsePEMEncodedPrivateKey([]byte("not a key"))
+	assert.Error(t, err)
+}
+
+func Test_NewSignedCertificateForProfile(t *testing.T) {
+	for _, algorithm := range []string{common.ArgoCDKeyAlgorithmRSA, common.ArgoCDKeyAlgorithmECDSA, common.ArgoCDKeyAlgorithmEd25519} {
+		t.Run(algorithm, func(t *testing.T) {
+			profile := CertificateProfile{
+				KeyAlgorithm: algorithm,
+				Validity:     time.Hour * 24,
+				ExtraSANs:    []string{"argocd.example.com", "10.0.0.1"},
+			}
+
+			caKey, err := NewPrivateKeyForProfile(profile)
+			assert.NoError(t, err)
+			caCert, err := NewSelfSignedCACertificateForProfile("test", caKey, profile)
+			assert.NoError(t, err)
+			assert.WithinDuration(t, time.Now().Add(profile.Validity), caCert.NotAfter, time.Minute)
+			assert.Equal(t, algorithm, GetKeyAlgorithm(caCert.PublicKey))
+
+			key, err := NewPrivateKeyForProfile(profile)
+			assert.NoError(t, err)
+			cfg := &tlsutil.CertConfig{CertType: tlsutil.ClientAndServingCert, CommonName: "test"}
+			cert, err := NewSignedCertificateForProfile(cfg, []string{"argocd-server"}, key, caCert, caKey, profile)
+			assert.NoError(t, err)
+			assert.NoError(t, cert.CheckSignatureFrom(caCert))
+			assert.Equal(t, []string{"argocd-server", "argocd.example.com"}, cert.DNSNames)
+			assert.NoError(t, cert.VerifyHostname("10.0.0.1"))
+			assert.WithinDuration(t, time.Now().Add(profile.Validity), cert.NotAfter, time.Minute)
+
+			// key encipherment is only set for RSA keys
+			assert.Equal(t, algorithm == common.ArgoCDKeyAlgorithmRSA, cert.KeyUsage&x509.KeyUsageKeyEncipherment != 0)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package util
+
+import "fmt"
+
+// IsUnstructuredSubset returns true if every field set in desired is set to the same value in existing. Fields that are
+// only present in existing, such as those defaulted by the API server, are ignored. Lists must have the same length.
+func IsUnstructuredSubset(desired, existing interface{}) bool {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		e, ok := existing.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, val := range d {
+			if !IsUnstructuredSubset(val, e[key]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		e, ok := existing.([]interface{})
+		if !ok || len(e) != len(d) {
+			return false
+		}
+		for i := range d {
+			if !IsUnstructuredSubset(d[i], e[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		// numbers may be decoded as int64 or float64, compare their string representation instead
+		return fmt.Sprint(desired) == fmt.Sprint(existing)
+	}
+}
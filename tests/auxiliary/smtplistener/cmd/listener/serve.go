@@ -3,13 +3,16 @@ package main
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
 	"runtime"
 
 	//filelog "smtplistener/internal/log"
+	"smtplistener/internal/api"
 	"smtplistener/internal/processor"
+	"smtplistener/internal/store"
 	"smtplistener/internal/util"
 	"strconv"
 	"strings"
@@ -23,11 +26,18 @@ import (
 
 const (
 	defaultPidFile = "/var/run/blaze_listener.pid"
+
+	defaultStorageDir  = "/tmp/smtplistener"
+	defaultHTTPAddress = ":8080"
 )
 
 var (
-	configPath string
-	pidFile    string
+	configPath  string
+	pidFile     string
+	storageDir  string
+	httpAddress string
+	tlsCertFile string
+	tlsKeyFile  string
 
 	serveCmd = &cobra.Command{
 		Use:   "serve",
@@ -86,6 +96,14 @@ func init() {
 	// intentionally didn't specify default pidFile; value from config is used if flag is empty
 	serveCmd.PersistentFlags().StringVarP(&pidFile, "pidFile", "p",
 		"", "Path to the pid file")
+	serveCmd.PersistentFlags().StringVar(&storageDir, "storage-dir",
+		defaultStorageDir, "Directory received mails are stored in")
+	serveCmd.PersistentFlags().StringVar(&httpAddress, "http-address",
+		defaultHTTPAddress, "Address the HTTP API listens on")
+	serveCmd.PersistentFlags().StringVar(&tlsCertFile, "tls-cert-file",
+		"", "Path to the TLS certificate of the HTTP API and SMTP servers, enables TLS when set along with --tls-key-file")
+	serveCmd.PersistentFlags().StringVar(&tlsKeyFile, "tls-key-file",
+		"", "Path to the TLS private key of the HTTP API and SMTP servers")
 	rootCmd.AddCommand(serveCmd)
 }
 
@@ -124,15 +142,31 @@ func serve(cmd *cobra.Command, args []string) {
 	// See the reference docs here:
 	d = guerrilla.Daemon{Logger: mainlog}
 
+	s, err := store.New(storageDir)
+	if err != nil {
+		mainlog.WithError(err).Fatal("Error while creating storage")
+	}
+
 	// add the Processor to be identified as "MailDir"
-	d.AddProcessor("FileWriter", processor.FileWriter)
+	d.AddProcessor("FileWriter", processor.NewFileWriter(s))
 	// add the FastCGI processor
 	//d.AddProcessor("FastCGI", fcgi_processor.Processor)
 
-	err := readConfig(configPath, pidFile)
+	err = readConfig(configPath, pidFile)
 	if err != nil {
 		mainlog.WithError(err).Fatal("Error while reading config")
 	}
+	if (tlsCertFile == "") != (tlsKeyFile == "") {
+		mainlog.Fatal("--tls-cert-file and --tls-key-file must be set together")
+	}
+	if tlsCertFile != "" {
+		// offer STARTTLS on all servers with the given certificate
+		for i := range d.Config.Servers {
+			d.Config.Servers[i].TLS.PublicKeyFile = tlsCertFile
+			d.Config.Servers[i].TLS.PrivateKeyFile = tlsKeyFile
+			d.Config.Servers[i].TLS.StartTLSOn = true
+		}
+	}
 	// Check that max clients is not greater than system open file limit.
 	fileLimit := getFileLimit()
 	if fileLimit > 0 {
@@ -154,9 +188,27 @@ func serve(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	go serveAPI(s)
+
 	sigHandler()
 }
 
+// serveAPI serves the HTTP API to query the received mails, over TLS if configured.
+func serveAPI(s *store.Store) {
+	server := &http.Server{Addr: httpAddress, Handler: api.NewHandler(s)}
+	mainlog.Infof("Serving HTTP API on %s, storing mails in %s", httpAddress, s.Dir())
+
+	var err error
+	if tlsCertFile != "" {
+		err = server.ListenAndServeTLS(tlsCertFile, tlsKeyFile)
+	} else {
+		err = server.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
+		mainlog.WithError(err).Fatal("Error while serving HTTP API")
+	}
+}
+
 // Superset of `guerrilla.AppConfig` containing options specific
 // the the command line interface.
 type CmdConfig struct {
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"smtplistener/internal/store"
+
+	"github.com/sirupsen/logrus"
+)
+
+const messagesPath = "/api/messages"
+
+// NewHandler returns the HTTP API of the given store:
+//
+//	GET    /api/messages?to=<recipient>&subject=<text>  lists the received messages, oldest first
+//	GET    /api/messages/<id>                           returns a received message
+//	DELETE /api/messages                                removes all the received messages
+//	GET    /healthz                                     reports the listener is up
+func NewHandler(s *store.Store) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(messagesPath, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			filter := store.Filter{
+				To:      r.URL.Query().Get("to"),
+				Subject: r.URL.Query().Get("subject"),
+			}
+			messages, err := s.List(filter)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+			writeJSON(w, http.StatusOK, messages)
+		case http.MethodDelete:
+			if err := s.Reset(); err != nil {
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("Allow", "GET, DELETE")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc(messagesPath+"/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		m, err := s.Get(strings.TrimPrefix(r.URL.Path, messagesPath+"/"))
+		if err == store.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, m)
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logrus.WithError(err).Error("failed to write response")
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"smtplistener/internal/store"
+)
+
+func makeTestServer(t *testing.T) (*httptest.Server, *store.Store, func()) {
+	dir, err := ioutil.TempDir("", "smtplistener")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	s, err := store.New(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	server := httptest.NewServer(NewHandler(s))
+	return server, s, func() {
+		server.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func getMessages(t *testing.T, url string) (int, []store.Message) {
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	messages := []store.Message{}
+	if resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(&messages); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	return resp.StatusCode, messages
+}
+
+func TestHandler(t *testing.T) {
+	server, s, cleanup := makeTestServer(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	for _, m := range []*store.Message{
+		{ID: "a", To: []string{"team@example.com"}, Subject: "Application guestbook has been created.", Body: "created", ReceivedAt: now},
+		{ID: "b", To: []string{"ops@example.com"}, Subject: "Application guestbook has been deleted.", Body: "deleted", ReceivedAt: now.Add(time.Second)},
+	} {
+		if err := s.Save(m, []byte("raw")); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	status, messages := getMessages(t, server.URL+"/api/messages")
+	if status != http.StatusOK || len(messages) != 2 {
+		t.Fatalf("Expected 2 messages, got %d with status %d", len(messages), status)
+	}
+
+	status, messages = getMessages(t, server.URL+"/api/messages?to=ops@example.com&subject=deleted")
+	if status != http.StatusOK || len(messages) != 1 || messages[0].ID != "b" {
+		t.Fatalf("Expected message b, got %v with status %d", messages, status)
+	}
+
+	resp, err := http.Get(server.URL + "/api/messages/a")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	m := store.Message{}
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if m.Body != "created" {
+		t.Errorf("Unexpected body %q", m.Body)
+	}
+
+	resp, err = http.Get(server.URL + "/api/messages/missing")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, resp.StatusCode)
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, server.URL+"/api/messages", nil)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Expected status %d, got %d", http.StatusNoContent, resp.StatusCode)
+	}
+	if _, messages = getMessages(t, server.URL+"/api/messages"); len(messages) != 0 {
+		t.Errorf("Expected no messages after reset, got %d", len(messages))
+	}
+}
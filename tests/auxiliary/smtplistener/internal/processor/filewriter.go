@@ -2,37 +2,51 @@ package processor
 
 import (
 	"fmt"
-	"io/ioutil"
+	"time"
+
+	"smtplistener/internal/store"
 
 	"github.com/flashmob/go-guerrilla/backends"
 	"github.com/flashmob/go-guerrilla/mail"
 )
 
-var FileWriter = func() backends.Decorator {
-	initializer := backends.InitializeWith(func(backendConfig backends.BackendConfig) error {
-		return nil
-	})
-	// register our initializer
-	backends.Svc.AddInitializer(initializer)
-	// When shutting down
-	backends.Svc.AddShutdowner(backends.ShutdownWith(func() error {
-		return nil
-	}))
+// NewFileWriter returns a processor saving each received mail to the given store.
+func NewFileWriter(s *store.Store) backends.ProcessorConstructor {
+	return func() backends.Decorator {
+		initializer := backends.InitializeWith(func(backendConfig backends.BackendConfig) error {
+			return nil
+		})
+		// register our initializer
+		backends.Svc.AddInitializer(initializer)
+		// When shutting down
+		backends.Svc.AddShutdowner(backends.ShutdownWith(func() error {
+			return nil
+		}))
 
-	return func(p backends.Processor) backends.Processor {
-		return backends.ProcessWith(func(e *mail.Envelope, task backends.SelectTask) (backends.Result, error) {
-			if task == backends.TaskSaveMail {
-				var stringer fmt.Stringer
-				stringer = e
-				data := []byte(stringer.String())
-				key := fmt.Sprintf("%s%s", "/tmp/", e.QueuedId)
-				err := ioutil.WriteFile(key, data, 0666)
-				fmt.Println(err)
+		return func(p backends.Processor) backends.Processor {
+			return backends.ProcessWith(func(e *mail.Envelope, task backends.SelectTask) (backends.Result, error) {
+				if task == backends.TaskSaveMail {
+					if err := save(s, e); err != nil {
+						backends.Log().WithError(err).Error("failed to save mail")
+						return backends.NewResult(fmt.Sprintf("554 Error: %s", err)), err
+					}
+				}
 				return p.Process(e, task)
-			} else {
-				return p.Process(e, task)
-			}
+			})
+		}
+	}
+}
 
-		})
+// save stores the given envelope, keyed by its queued ID.
+func save(s *store.Store, e *mail.Envelope) error {
+	to := make([]string, 0, len(e.RcptTo))
+	for i := range e.RcptTo {
+		to = append(to, e.RcptTo[i].String())
+	}
+	raw := e.Data.Bytes()
+	m, err := store.Parse(e.QueuedId, e.MailFrom.String(), to, raw, time.Now().UTC())
+	if err != nil {
+		return err
 	}
+	return s.Save(m, raw)
 }
@@ -0,0 +1,63 @@
+package processor
+
+import (
+	"io/ioutil"
+	"net/smtp"
+	"os"
+	"strings"
+	"testing"
+
+	"smtplistener/internal/store"
+
+	"github.com/flashmob/go-guerrilla"
+	"github.com/flashmob/go-guerrilla/backends"
+	"github.com/flashmob/go-guerrilla/log"
+)
+
+func TestFileWriter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "smtplistener")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	s, err := store.New(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	d := guerrilla.Daemon{Config: &guerrilla.AppConfig{
+		LogFile:      log.OutputOff.String(),
+		AllowedHosts: []string{"example.com"},
+		BackendConfig: backends.BackendConfig{
+			"save_process":      "HeadersParser|FileWriter",
+			"save_workers_size": 1,
+		},
+		Servers: []guerrilla.ServerConfig{
+			{IsEnabled: true, ListenInterface: "127.0.0.1:2527", Hostname: "mail.example.com", MaxClients: 1, Timeout: 10, MaxSize: 100000},
+		},
+	}}
+	d.AddProcessor("FileWriter", NewFileWriter(s))
+	if err := d.Start(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer d.Shutdown()
+
+	mail := "Subject: Application guestbook has been created.\r\n\r\nApplication guestbook has been created.\r\n"
+	if err := smtp.SendMail("127.0.0.1:2527", nil, "argocd@example.com", []string{"team@example.com"}, []byte(mail)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	messages, err := s.List(store.Filter{To: "team@example.com"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	if messages[0].From != "argocd@example.com" || messages[0].Subject != "Application guestbook has been created." {
+		t.Errorf("Unexpected message %+v", messages[0])
+	}
+	if strings.TrimSpace(messages[0].Body) != "Application guestbook has been created." {
+		t.Errorf("Unexpected body %q", messages[0].Body)
+	}
+}
@@ -0,0 +1,109 @@
+package store
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+var wordDecoder = &mime.WordDecoder{}
+
+// Parse builds a message out of the given raw mail and SMTP envelope. The body is decoded according to its
+// Content-Transfer-Encoding, and the text/plain part is preferred for multipart mails.
+func Parse(id string, from string, to []string, raw []byte, receivedAt time.Time) (*Message, error) {
+	mm, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mail: %w", err)
+	}
+
+	headers := map[string][]string{}
+	for name, values := range mm.Header {
+		decoded := make([]string, 0, len(values))
+		for _, value := range values {
+			decoded = append(decoded, decodeHeader(value))
+		}
+		headers[name] = decoded
+	}
+
+	body, err := decodeBody(mm.Header.Get("Content-Type"), mm.Header.Get("Content-Transfer-Encoding"), mm.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode mail body: %w", err)
+	}
+
+	return &Message{
+		ID:         id,
+		From:       from,
+		To:         to,
+		Subject:    decodeHeader(mm.Header.Get("Subject")),
+		Headers:    headers,
+		Body:       body,
+		ReceivedAt: receivedAt,
+	}, nil
+}
+
+// decodeHeader decodes the RFC 2047 encoded words of the given header value, if any.
+func decodeHeader(value string) string {
+	decoded, err := wordDecoder.DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
+
+// decodeBody returns the text of the given body.
+func decodeBody(contentType string, encoding string, body io.Reader) (string, error) {
+	reader := decodeTransfer(encoding, body)
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		data, err := ioutil.ReadAll(reader)
+		return string(data), err
+	}
+
+	var fallback *string
+	parts := multipart.NewReader(reader, params["boundary"])
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		text, err := decodeBody(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+		if err != nil {
+			return "", err
+		}
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if partType == "" || partType == "text/plain" {
+			return text, nil
+		}
+		if fallback == nil {
+			fallback = &text
+		}
+	}
+	if fallback == nil {
+		return "", nil
+	}
+	return *fallback, nil
+}
+
+// decodeTransfer returns a reader decoding the given Content-Transfer-Encoding.
+func decodeTransfer(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	default:
+		return body
+	}
+}
@@ -0,0 +1,157 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned when a message does not exist in the store.
+var ErrNotFound = errors.New("message not found")
+
+// Message is a mail received by the listener.
+type Message struct {
+	ID         string              `json:"id"`
+	From       string              `json:"from"`
+	To         []string            `json:"to"`
+	Subject    string              `json:"subject"`
+	Headers    map[string][]string `json:"headers"`
+	Body       string              `json:"body"`
+	ReceivedAt time.Time           `json:"receivedAt"`
+}
+
+// Filter selects messages by recipient and subject. Empty fields match any message.
+type Filter struct {
+	// To matches messages with the given recipient, ignoring case.
+	To string
+	// Subject matches messages whose subject contains the given text.
+	Subject string
+}
+
+// Matches returns whether the given message is selected by the filter.
+func (f Filter) Matches(m *Message) bool {
+	if f.Subject != "" && !strings.Contains(m.Subject, f.Subject) {
+		return false
+	}
+	if f.To == "" {
+		return true
+	}
+	for _, to := range m.To {
+		if strings.EqualFold(to, f.To) {
+			return true
+		}
+	}
+	return false
+}
+
+// Store keeps received messages as JSON files in a directory, along with the raw mail.
+type Store struct {
+	dir string
+	mu  sync.RWMutex
+}
+
+// New returns a store keeping messages in the given directory, which is created if needed.
+func New(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory %s: %w", dir, err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Dir returns the directory messages are kept in.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Save stores the given message and its raw mail.
+func (s *Store) Save(m *Message, raw []byte) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := ioutil.WriteFile(filepath.Join(s.dir, m.ID+".eml"), raw, 0644); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(s.dir, m.ID+".json"), data, 0644)
+}
+
+// Get returns the message with the given ID.
+func (s *Store) Get(id string) (*Message, error) {
+	// IDs are file names, anything else cannot be a message
+	if id == "" || id != filepath.Base(id) {
+		return nil, ErrNotFound
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.read(filepath.Join(s.dir, id+".json"))
+}
+
+// List returns the messages selected by the given filter, oldest first.
+func (s *Store) List(f Filter) ([]*Message, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	messages := []*Message{}
+	for _, path := range paths {
+		m, err := s.read(path)
+		if err != nil {
+			return nil, err
+		}
+		if f.Matches(m) {
+			messages = append(messages, m)
+		}
+	}
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].ReceivedAt.Before(messages[j].ReceivedAt)
+	})
+	return messages, nil
+}
+
+// Reset removes all the messages from the store.
+func (s *Store) Reset() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, pattern := range []string{"*.json", "*.eml"} {
+		paths, err := filepath.Glob(filepath.Join(s.dir, pattern))
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Store) read(path string) (*Message, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	m := &Message{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to read message %s: %w", filepath.Base(path), err)
+	}
+	return m, nil
+}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testMail = "From: Argo CD <argocd@example.com>\r\n" +
+	"To: team@example.com\r\n" +
+	"Subject: =?utf-8?q?Application_guestbook_has_been_created=2E?=\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/alternative; boundary=BOUNDARY\r\n" +
+	"\r\n" +
+	"--BOUNDARY\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"\r\n" +
+	"<p>Application guestbook has been created.</p>\r\n" +
+	"--BOUNDARY\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"Application guestbook has been created=2E\r\n" +
+	"--BOUNDARY--\r\n"
+
+func TestParse(t *testing.T) {
+	receivedAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	m, err := Parse("1", "argocd@example.com", []string{"team@example.com"}, []byte(testMail), receivedAt)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if m.Subject != "Application guestbook has been created." {
+		t.Errorf("Unexpected subject %q", m.Subject)
+	}
+	if m.Body != "Application guestbook has been created." {
+		t.Errorf("Unexpected body %q", m.Body)
+	}
+	if !reflect.DeepEqual(m.Headers["To"], []string{"team@example.com"}) {
+		t.Errorf("Unexpected To header %v", m.Headers["To"])
+	}
+	if m.ReceivedAt != receivedAt {
+		t.Errorf("Unexpected receivedAt %v", m.ReceivedAt)
+	}
+}
+
+func TestParse_base64(t *testing.T) {
+	raw := "Subject: hello\r\nContent-Transfer-Encoding: base64\r\n\r\naGVsbG8g\r\nd29ybGQ=\r\n"
+	m, err := Parse("1", "", nil, []byte(raw), time.Now())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if m.Body != "hello world" {
+		t.Errorf("Unexpected body %q", m.Body)
+	}
+}
+
+func TestStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "smtplistener")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	s, err := New(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	now := time.Now().UTC()
+	messages := []*Message{
+		{ID: "b", To: []string{"team@example.com"}, Subject: "Application guestbook has been deleted.", ReceivedAt: now.Add(time.Second)},
+		{ID: "a", To: []string{"Team@example.com", "ops@example.com"}, Subject: "Application guestbook has been created.", ReceivedAt: now},
+	}
+	for _, m := range messages {
+		if err := s.Save(m, []byte("raw")); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{name: "no filter", filter: Filter{}, want: []string{"a", "b"}},
+		{name: "recipient", filter: Filter{To: "ops@example.com"}, want: []string{"a"}},
+		{name: "recipient ignoring case", filter: Filter{To: "TEAM@example.com"}, want: []string{"a", "b"}},
+		{name: "subject", filter: Filter{Subject: "deleted"}, want: []string{"b"}},
+		{name: "recipient and subject", filter: Filter{To: "ops@example.com", Subject: "deleted"}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.List(tt.filter)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			ids := []string{}
+			for _, m := range got {
+				ids = append(ids, m.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, ids)
+			}
+		})
+	}
+
+	m, err := s.Get("a")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if m.Subject != messages[1].Subject {
+		t.Errorf("Unexpected subject %q", m.Subject)
+	}
+	if _, err := s.Get("../a"); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	if err := s.Reset(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got, _ := s.List(Filter{}); len(got) != 0 {
+		t.Errorf("Expected no messages after reset, got %d", len(got))
+	}
+}
@@ -0,0 +1,258 @@
+//go:build e2e
+// +build e2e
+
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/yaml"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocd/notifications"
+	"github.com/argoproj-labs/argocd-operator/pkg/util"
+)
+
+const (
+	smtpListenerName = "smtplistener"
+	emailServiceName = "smtp"
+	recipient        = "e2e@example.com"
+	timeout          = 5 * time.Minute
+)
+
+// smtpMessage is a message returned by the HTTP API of the smtplistener.
+type smtpMessage struct {
+	ID      string   `json:"id"`
+	From    string   `json:"from"`
+	To      []string `json:"to"`
+	Subject string   `json:"subject"`
+	Body    string   `json:"body"`
+}
+
+// emailTemplate is the part of a notifications template rendered into an email.
+type emailTemplate struct {
+	Message string `json:"message"`
+	Email   struct {
+		Subject string `json:"subject"`
+	} `json:"email"`
+}
+
+type testEnv struct {
+	client    client.Client
+	clientset *kubernetes.Clientset
+	namespace string
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, argoproj.AddToScheme(s))
+	cl, err := client.New(cfg, client.Options{Scheme: s})
+	require.NoError(t, err)
+	clientset, err := kubernetes.NewForConfig(cfg)
+	require.NoError(t, err)
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "notifications-e2e-"}}
+	require.NoError(t, cl.Create(context.TODO(), ns))
+	t.Cleanup(func() {
+		assert.NoError(t, cl.Delete(context.TODO(), ns))
+	})
+	return &testEnv{client: cl, clientset: clientset, namespace: ns.Name}
+}
+
+// getSMTPListenerImage returns the smtplistener image set in SMTP_LISTENER_IMAGE, or skips the test. The published
+// smtplistener images predate the HTTP API, so the image must be built from tests/auxiliary/smtplistener.
+func getSMTPListenerImage(t *testing.T) string {
+	image := os.Getenv("SMTP_LISTENER_IMAGE")
+	if image == "" {
+		t.Skip("SMTP_LISTENER_IMAGE is not set: build and push the image of tests/auxiliary/smtplistener, which serves /api/messages, and set SMTP_LISTENER_IMAGE to it")
+	}
+	return image
+}
+
+// deploySMTPListener deploys the smtplistener, serving SMTP on port 2525 and its HTTP API on port 8080.
+func (e *testEnv) deploySMTPListener(t *testing.T, image string) {
+	labels := map[string]string{"app": smtpListenerName}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: smtpListenerName, Namespace: e.namespace, Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  smtpListenerName,
+						Image: image,
+						Ports: []corev1.ContainerPort{
+							{Name: "smtp", ContainerPort: 2525},
+							{Name: "http", ContainerPort: 8080},
+						},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromString("http")},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: smtpListenerName, Namespace: e.namespace, Labels: labels},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports: []corev1.ServicePort{
+				{Name: "smtp", Port: 2525, TargetPort: intstr.FromString("smtp")},
+				{Name: "http", Port: 8080, TargetPort: intstr.FromString("http")},
+			},
+		},
+	}
+	require.NoError(t, e.client.Create(context.TODO(), deployment))
+	require.NoError(t, e.client.Create(context.TODO(), service))
+	e.waitForDeployment(t, smtpListenerName)
+}
+
+// waitForDeployment waits for the given deployment to have all its replicas available.
+func (e *testEnv) waitForDeployment(t *testing.T, name string) {
+	err := wait.PollImmediate(2*time.Second, timeout, func() (bool, error) {
+		deployment := &appsv1.Deployment{}
+		if err := e.client.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: e.namespace}, deployment); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		return deployment.Status.AvailableReplicas > 0 && deployment.Status.AvailableReplicas == deployment.Status.Replicas, nil
+	})
+	require.NoError(t, err, "deployment %s is not available", name)
+}
+
+// getMessages returns the messages received by the smtplistener for the given recipient and subject, through the
+// service proxy of the API server.
+func (e *testEnv) getMessages(recipient string, subject string) ([]smtpMessage, error) {
+	data, err := e.clientset.CoreV1().Services(e.namespace).
+		ProxyGet("http", smtpListenerName, "8080", "/api/messages", map[string]string{"to": recipient, "subject": subject}).
+		DoRaw(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+	messages := []smtpMessage{}
+	return messages, json.Unmarshal(data, &messages)
+}
+
+// waitForMessage waits for a message with the given subject to be received by the given recipient.
+func (e *testEnv) waitForMessage(t *testing.T, recipient string, subject string) smtpMessage {
+	var message smtpMessage
+	err := wait.PollImmediate(5*time.Second, timeout, func() (bool, error) {
+		messages, err := e.getMessages(recipient, subject)
+		if err != nil || len(messages) == 0 {
+			return false, nil
+		}
+		message = messages[0]
+		return true, nil
+	})
+	require.NoError(t, err, "no message %q received by %s", subject, recipient)
+	return message
+}
+
+// renderDefaultTemplate renders the subject and message of the given built-in template for the given application.
+func renderDefaultTemplate(t *testing.T, name string, app map[string]interface{}) (string, string) {
+	tmpl := emailTemplate{}
+	require.NoError(t, yaml.Unmarshal([]byte(notifications.GetDefaultNotificationsConfig()["template."+name]), &tmpl))
+
+	render := func(text string) string {
+		buf := &bytes.Buffer{}
+		require.NoError(t, template.Must(template.New(name).Parse(text)).Execute(buf, map[string]interface{}{"app": app}))
+		return buf.String()
+	}
+	return render(tmpl.Email.Subject), render(tmpl.Message)
+}
+
+func TestNotificationsEmail(t *testing.T) {
+	image := getSMTPListenerImage(t)
+	e := newTestEnv(t)
+	e.deploySMTPListener(t, image)
+
+	argocd := &argoproj.ArgoCD{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd", Namespace: e.namespace},
+		Spec: argoproj.ArgoCDSpec{
+			Notifications: argoproj.ArgoCDNotifications{
+				Enabled: true,
+				Config: &argoproj.ArgoCDNotificationsConfigSpec{
+					Services: []argoproj.ArgoCDNotificationsService{{
+						Name: emailServiceName,
+						Email: &argoproj.ArgoCDNotificationsEmailService{
+							Host: smtpListenerName,
+							Port: 2525,
+							From: "argocd@example.com",
+						},
+					}},
+				},
+			},
+		},
+	}
+	require.NoError(t, e.client.Create(context.TODO(), argocd))
+	e.waitForDeployment(t, "argocd-notifications-controller")
+
+	appName := "notifications-e2e"
+	app := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata": map[string]interface{}{
+			"name":      appName,
+			"namespace": e.namespace,
+			"annotations": map[string]interface{}{
+				fmt.Sprintf("notifications.argoproj.io/subscribe.on-created.%s", emailServiceName): recipient,
+				fmt.Sprintf("notifications.argoproj.io/subscribe.on-deleted.%s", emailServiceName): recipient,
+			},
+		},
+		"spec": map[string]interface{}{
+			"project": "default",
+			"destination": map[string]interface{}{
+				"namespace": e.namespace,
+				"server":    "https://kubernetes.default.svc",
+			},
+			"source": map[string]interface{}{
+				"repoURL":        "https://github.com/argoproj/argocd-example-apps",
+				"path":           "guestbook",
+				"targetRevision": "HEAD",
+			},
+		},
+	}}
+	appData := map[string]interface{}{"metadata": map[string]interface{}{"name": appName}}
+
+	require.NoError(t, e.client.Create(context.TODO(), app))
+	subject, body := renderDefaultTemplate(t, "app-created", appData)
+	message := e.waitForMessage(t, recipient, subject)
+	assert.Equal(t, subject, message.Subject)
+	assert.Equal(t, strings.TrimSpace(body), strings.TrimSpace(message.Body))
+	assert.Equal(t, "argocd@example.com", message.From)
+	assert.True(t, util.ContainsString(message.To, recipient))
+
+	require.NoError(t, e.client.Delete(context.TODO(), app))
+	subject, body = renderDefaultTemplate(t, "app-deleted", appData)
+	message = e.waitForMessage(t, recipient, subject)
+	assert.Equal(t, strings.TrimSpace(body), strings.TrimSpace(message.Body))
+}